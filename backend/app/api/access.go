@@ -3,6 +3,7 @@ package api
 import (
 	"ALLinSSL/backend/app/dto/response"
 	"ALLinSSL/backend/internal/access"
+	"ALLinSSL/backend/internal/cert/apply"
 	"ALLinSSL/backend/internal/cert/deploy"
 	"ALLinSSL/backend/internal/cert/deploy/plugin"
 	"ALLinSSL/backend/public"
@@ -327,6 +328,10 @@ func TestAccess(c *gin.Context) {
 		result = deploy.QiniuAPITest(form.ID)
 	case "baidu":
 		result = deploy.BaiduyunAPITest(form.ID)
+	case "rfc2136":
+		result = apply.RFC2136APITest(form.ID)
+	case "lego-generic":
+		result = apply.LegoGenericAPITest(form.ID)
 	default:
 		public.FailMsg(c, "不支持测试的提供商")
 		return
@@ -341,6 +346,34 @@ func TestAccess(c *gin.Context) {
 	return
 }
 
+func DiagnoseDNS(c *gin.Context) {
+	var form struct {
+		Domain     string `form:"domain"`
+		ProviderID string `form:"provider_id"`
+		Provider   string `form:"provider"`
+		NameServer string `form:"name_server"`
+		MaxWait    string `form:"max_wait"`
+		CloseCname bool   `form:"close_cname"`
+		Publish    bool   `form:"publish"`
+	}
+	err := c.Bind(&form)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	cfg := map[string]any{"max_wait": form.MaxWait, "close_cname": form.CloseCname}
+	if form.NameServer != "" {
+		cfg["name_server"] = form.NameServer
+	}
+	result, err := apply.DiagnoseDNS(c.Request.Context(), form.Domain, form.Provider, form.ProviderID, cfg, form.Publish)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	public.SuccessData(c, result, 0)
+	return
+}
+
 func GetSiteList(c *gin.Context) {
 	var form struct {
 		ID     string `form:"id"`
@@ -100,6 +100,79 @@ func UpdateAccount(c *gin.Context) {
 	return
 }
 
+// RolloverAccountKey 向 CA 更换账号密钥
+func RolloverAccountKey(c *gin.Context) {
+	var form struct {
+		ID string `form:"id"`
+	}
+	err := c.Bind(&form)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	form.ID = strings.TrimSpace(form.ID)
+	if form.ID == "" {
+		public.FailMsg(c, "ID不能为空")
+		return
+	}
+	err = apply.RolloverAccountKey(form.ID)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	public.SuccessMsg(c, "更换密钥成功")
+	return
+}
+
+// UpdateAccountContact 更新账号在 CA 的联系邮箱
+func UpdateAccountContact(c *gin.Context) {
+	var form struct {
+		ID     string `form:"id"`
+		Emails string `form:"emails"`
+	}
+	err := c.Bind(&form)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	form.ID = strings.TrimSpace(form.ID)
+	if form.ID == "" {
+		public.FailMsg(c, "ID不能为空")
+		return
+	}
+	err = apply.UpdateAccountContact(form.ID, form.Emails)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	public.SuccessMsg(c, "更新成功")
+	return
+}
+
+// DeactivateAccount 在 CA 注销账号
+func DeactivateAccount(c *gin.Context) {
+	var form struct {
+		ID string `form:"id"`
+	}
+	err := c.Bind(&form)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	form.ID = strings.TrimSpace(form.ID)
+	if form.ID == "" {
+		public.FailMsg(c, "ID不能为空")
+		return
+	}
+	err = apply.DeactivateAccount(form.ID)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	public.SuccessMsg(c, "注销成功")
+	return
+}
+
 func GetAccountList(c *gin.Context) {
 	var form struct {
 		Page   int64  `form:"p"`
@@ -135,3 +208,101 @@ func GetCaList(c *gin.Context) {
 	public.SuccessData(c, cas, total)
 
 }
+
+func GetCARegistryList(c *gin.Context) {
+	var form struct {
+		Page   int64  `form:"p"`
+		Limit  int64  `form:"limit"`
+		Search string `form:"search"`
+	}
+	err := c.Bind(&form)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	if form.Page <= 0 {
+		form.Page = 1
+	}
+	if form.Limit <= 0 {
+		form.Limit = 10
+	}
+	cas, total, err := apply.GetCARegistryList(form.Search, form.Page, form.Limit)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	public.SuccessData(c, cas, total)
+}
+
+func AddCA(c *gin.Context) {
+	var form struct {
+		Name        string `form:"name"`
+		DirURL      string `form:"dir_url"`
+		RootPEM     string `form:"root_pem"`
+		EABRequired bool   `form:"eab_required"`
+		KeyTypes    string `form:"key_types"`
+	}
+	err := c.Bind(&form)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	err = apply.AddCA(form.Name, form.DirURL, form.RootPEM, form.EABRequired, form.KeyTypes)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	public.SuccessMsg(c, "添加成功")
+	return
+}
+
+func UpdateCA(c *gin.Context) {
+	var form struct {
+		ID          string `form:"id"`
+		Name        string `form:"name"`
+		DirURL      string `form:"dir_url"`
+		RootPEM     string `form:"root_pem"`
+		EABRequired bool   `form:"eab_required"`
+		KeyTypes    string `form:"key_types"`
+	}
+	err := c.Bind(&form)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	form.ID = strings.TrimSpace(form.ID)
+	if form.ID == "" {
+		public.FailMsg(c, "ID不能为空")
+		return
+	}
+	err = apply.UpdateCA(form.ID, form.Name, form.DirURL, form.RootPEM, form.EABRequired, form.KeyTypes)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	public.SuccessMsg(c, "更新成功")
+	return
+}
+
+func DelCA(c *gin.Context) {
+	var form struct {
+		ID string `form:"id"`
+	}
+	err := c.Bind(&form)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	form.ID = strings.TrimSpace(form.ID)
+	if form.ID == "" {
+		public.FailMsg(c, "ID不能为空")
+		return
+	}
+	err = apply.DelCA(form.ID)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	public.SuccessMsg(c, "删除成功")
+	return
+}
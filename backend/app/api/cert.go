@@ -2,6 +2,7 @@ package api
 
 import (
 	"ALLinSSL/backend/internal/cert"
+	"ALLinSSL/backend/internal/cert/apply"
 	"ALLinSSL/backend/public"
 	"archive/zip"
 	"bytes"
@@ -81,6 +82,74 @@ func DelCert(c *gin.Context) {
 	return
 }
 
+func RevokeCert(c *gin.Context) {
+	var form struct {
+		ID     string `form:"id"`
+		Reason string `form:"reason"`
+		Mode   string `form:"mode"`
+		Email  string `form:"email"`
+		CA     string `form:"ca"`
+	}
+	err := c.Bind(&form)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	form.ID = strings.TrimSpace(form.ID)
+	if form.ID == "" {
+		public.FailMsg(c, "ID不能为空")
+		return
+	}
+	reason, err := apply.ParseRevokeReason(strings.TrimSpace(form.Reason))
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	logger, err := public.NewLogger(public.GetSettingIgnoreError("log_path"))
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	defer logger.Close()
+	err = apply.RevokeCert(form.ID, reason, strings.TrimSpace(form.Mode), strings.TrimSpace(form.Email), strings.TrimSpace(form.CA), nil, logger)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	public.SuccessMsg(c, "吊销成功")
+	return
+}
+
+// RefreshOCSP 重新查询并缓存证书的 OCSP 响应
+func RefreshOCSP(c *gin.Context) {
+	var form struct {
+		ID string `form:"id"`
+	}
+	err := c.Bind(&form)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	form.ID = strings.TrimSpace(form.ID)
+	if form.ID == "" {
+		public.FailMsg(c, "ID不能为空")
+		return
+	}
+	certRow, err := cert.GetCertRow(form.ID)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	certStr, _ := certRow["cert"].(string)
+	_, err = cert.GetOCSPResponse(c.Request.Context(), certStr, true)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	public.SuccessMsg(c, "OCSP响应已更新")
+	return
+}
+
 func DownloadCert(c *gin.Context) {
 	ID := c.Query("id")
 
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"ALLinSSL/backend/internal/cert/apply"
+	"ALLinSSL/backend/internal/cert/privateca"
+	"ALLinSSL/backend/public"
+	"github.com/gin-gonic/gin"
+	"strings"
+)
+
+func GetPrivateCAList(c *gin.Context) {
+	var form struct {
+		Page   int64  `form:"p"`
+		Limit  int64  `form:"limit"`
+		Search string `form:"search"`
+	}
+	err := c.Bind(&form)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	if form.Page <= 0 {
+		form.Page = 1
+	}
+	if form.Limit <= 0 {
+		form.Limit = 10
+	}
+	cas, total, err := privateca.GetCAList(form.Search, form.Page, form.Limit)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	public.SuccessData(c, cas, total)
+}
+
+func AddPrivateCA(c *gin.Context) {
+	var form struct {
+		Name             string `form:"name"`
+		CommonName       string `form:"common_name"`
+		Organization     string `form:"organization"`
+		KeyType          string `form:"key_type"`
+		RootDays         int    `form:"root_days"`
+		IntermediateDays int    `form:"intermediate_days"`
+		CRLBaseURL       string `form:"crl_base_url"`
+	}
+	err := c.Bind(&form)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	if form.KeyType == "" {
+		form.KeyType = "EC256"
+	}
+	keyType, ok := apply.AlgorithmMap[form.KeyType]
+	if !ok {
+		public.FailMsg(c, "不支持的证书算法: "+form.KeyType)
+		return
+	}
+	err = privateca.AddCA(form.Name, form.CommonName, form.Organization, keyType, form.RootDays, form.IntermediateDays, form.CRLBaseURL)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	public.SuccessMsg(c, "添加成功")
+	return
+}
+
+func UpdatePrivateCA(c *gin.Context) {
+	var form struct {
+		ID         string `form:"id"`
+		Name       string `form:"name"`
+		CRLBaseURL string `form:"crl_base_url"`
+	}
+	err := c.Bind(&form)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	form.ID = strings.TrimSpace(form.ID)
+	if form.ID == "" {
+		public.FailMsg(c, "ID不能为空")
+		return
+	}
+	err = privateca.UpdateCA(form.ID, form.Name, form.CRLBaseURL)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	public.SuccessMsg(c, "更新成功")
+	return
+}
+
+func DelPrivateCA(c *gin.Context) {
+	var form struct {
+		ID string `form:"id"`
+	}
+	err := c.Bind(&form)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	form.ID = strings.TrimSpace(form.ID)
+	if form.ID == "" {
+		public.FailMsg(c, "ID不能为空")
+		return
+	}
+	err = privateca.DelCA(form.ID)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	public.SuccessMsg(c, "删除成功")
+	return
+}
+
+// DownloadPrivateCARoot 下载根证书，用于导入客户端信任库
+func DownloadPrivateCARoot(c *gin.Context) {
+	ID := c.Query("id")
+	if ID == "" {
+		public.FailMsg(c, "ID不能为空")
+		return
+	}
+	name, rootCert, err := privateca.GetRootCert(ID)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	c.Header("Content-Disposition", "attachment; filename="+strings.ReplaceAll(name, " ", "_")+"_root.crt")
+	c.Data(200, "application/x-pem-file", []byte(rootCert))
+	return
+}
+
+// PublishPrivateCACRL 立即重新签发 CRL
+func PublishPrivateCACRL(c *gin.Context) {
+	var form struct {
+		ID string `form:"id"`
+	}
+	err := c.Bind(&form)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	form.ID = strings.TrimSpace(form.ID)
+	if form.ID == "" {
+		public.FailMsg(c, "ID不能为空")
+		return
+	}
+	err = privateca.PublishCRL(form.ID)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	public.SuccessMsg(c, "发布成功")
+	return
+}
+
+// GetPrivateCACRL 公开的 CRL 分发点，无需登录，路径为 /crl/<id>.crl
+func GetPrivateCACRL(c *gin.Context) {
+	ID := strings.TrimSuffix(c.Param("file"), ".crl")
+	der, err := privateca.GetCRL(ID)
+	if err != nil {
+		c.Status(404)
+		return
+	}
+	c.Data(200, "application/pkix-crl", der)
+}
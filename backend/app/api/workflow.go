@@ -193,6 +193,27 @@ func StopWorkflow(c *gin.Context) {
 	return
 }
 
+// ResumeWorkflow 从执行记录中失败的节点恢复执行，id 为执行记录 ID
+func ResumeWorkflow(c *gin.Context) {
+	var form struct {
+		ID string `form:"id"`
+	}
+	err := c.Bind(&form)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	form.ID = strings.TrimSpace(form.ID)
+
+	err = workflow.ResumeWorkflow(form.ID)
+	if err != nil {
+		public.FailMsg(c, err.Error())
+		return
+	}
+	public.SuccessMsg(c, "执行成功")
+	return
+}
+
 func GetWorkflowHistory(c *gin.Context) {
 	var form struct {
 		ID    string `form:"id"`
@@ -133,6 +133,11 @@ func AddAccount(email, ca, Kid, HmacEncoded, CADirURL string) error {
 	} else if ca == "custom" && CADirURL == "" {
 		return fmt.Errorf("CADirURL is required for custom CA")
 	}
+	if caInfo, err := GetRegistryCA(ca); err == nil && caInfo != nil {
+		if eabRequired, ok := caInfo["eab_required"].(int64); ok && eabRequired == 1 && (Kid == "" || HmacEncoded == "") {
+			return fmt.Errorf("Kid and HmacEncoded are required for %s CA", ca)
+		}
+	}
 	account := map[string]interface{}{
 		"email":       email,
 		"type":        ca,
@@ -223,6 +228,7 @@ func GetAccountList(search, ca string, p, limit int64) ([]map[string]interface{}
 		data[i]["ca"] = data[i]["type"]
 		delete(data[i], "private_key")
 		delete(data[i], "reg")
+		delete(data[i], "pending_key")
 		delete(data[i], "type")
 	}
 
@@ -239,6 +245,16 @@ func GetCaList() ([]string, int, error) {
 		return nil, 0, fmt.Errorf("failed to get CA list: %w", err)
 	}
 	caList := []string{"letsencrypt", "buypass", "zerossl"}
+	// CA 注册表中的 CA，需列出全部，不能走分页查询
+	registry, err := getRegistryCANames()
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range registry {
+		if name, ok := registry[i]["name"].(string); ok && !containsString(caList, name) {
+			caList = append(caList, name)
+		}
+	}
 	for i := range data {
 		if data[i]["type"] == "Let's Encrypt" {
 			data[i]["type"] = "letsencrypt"
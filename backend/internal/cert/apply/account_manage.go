@@ -0,0 +1,384 @@
+package apply
+
+import (
+	"ALLinSSL/backend/public"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/registration"
+	jose "github.com/go-jose/go-jose/v4"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	accountDeactivated     = "deactivated"
+	accountDoesNotExistErr = "urn:ietf:params:acme:error:accountDoesNotExist"
+)
+
+// marshalAccountKey 账号私钥的存储格式，与 SaveUserToDB 保持一致
+func marshalAccountKey(key crypto.PrivateKey) (string, error) {
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "EC PRIVATE KEY",
+		Bytes: keyBytes,
+	})), nil
+}
+
+// acmeAccount 已在 CA 注册的账号
+type acmeAccount struct {
+	data       map[string]any
+	user       *MyUser
+	core       *api.Core
+	httpClient *http.Client
+}
+
+// getRegisteredAccount 获取已在 CA 注册的账号及用于访问 CA 的 ACME 客户端，key 不为空时使用该密钥签名
+func getRegisteredAccount(id string, key crypto.PrivateKey) (*acmeAccount, error) {
+	db, err := GetSqlite()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sqlite: %w", err)
+	}
+	defer db.Close()
+	data, err := db.Where("id=?", []any{id}).Select()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("账号不存在")
+	}
+	accData := data[0]
+	if status, _ := accData["status"].(string); status == accountDeactivated {
+		return nil, fmt.Errorf("账号已在CA注销")
+	}
+	email, _ := accData["email"].(string)
+	ca, _ := accData["type"].(string)
+
+	var reg registration.Resource
+	regStr, _ := accData["reg"].(string)
+	keyStr, _ := accData["private_key"].(string)
+	if regStr == "" || keyStr == "" || json.Unmarshal([]byte(regStr), &reg) != nil || reg.URI == "" {
+		return nil, fmt.Errorf("账号尚未在CA注册")
+	}
+	accountKey, err := public.ParsePrivateKey([]byte(keyStr))
+	if err != nil {
+		return nil, fmt.Errorf("账号私钥解析失败: %v", err)
+	}
+	user := &MyUser{Email: email, Registration: &reg, key: accountKey}
+	// 使用指定密钥时用于按密钥查询账号，请求需携带公钥而非账号 URL
+	kid := ""
+	if key == nil {
+		key, kid = accountKey, reg.URI
+	}
+
+	CADirURL, err := getCADirURL(ca, email)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	if caInfo, err := GetRegistryCA(ca); err == nil && caInfo != nil {
+		if httpClient, err = caHTTPClient(caInfo, httpClient); err != nil {
+			return nil, err
+		}
+	}
+	core, err := api.New(httpClient, "ALLinSSL", CADirURL, kid, key)
+	if err != nil {
+		return nil, fmt.Errorf("连接CA失败: %v", err)
+	}
+	return &acmeAccount{data: accData, user: user, core: core, httpClient: httpClient}, nil
+}
+
+func updateAccountRow(id string, data map[string]any, where string, args []any) (int64, error) {
+	db, err := GetSqlite()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get sqlite: %w", err)
+	}
+	defer db.Close()
+	data["update_time"] = time.Now().Format("2006-01-02 15:04:05")
+	return db.Where("id=?"+where, append([]any{id}, args...)).Update(data)
+}
+
+// RolloverAccountKey 向 CA 更换账号密钥（RFC 8555 7.3.5）
+//
+// 新密钥先暂存到 pending_key，CA 确认后在同一条语句中替换 private_key 并清空 pending_key；
+// 与 CA 通信失败或 CA 已更换但本地保存失败时保留暂存的密钥，再次执行时会用暂存的密钥向 CA 确认并完成替换。
+func RolloverAccountKey(id string) error {
+	acc, err := getRegisteredAccount(id, nil)
+	if err != nil {
+		return err
+	}
+	oldPEM, _ := acc.data["private_key"].(string)
+
+	if pendingPEM, _ := acc.data["pending_key"].(string); pendingPEM != "" {
+		if recovered, err := recoverPendingKey(id, acc.user, oldPEM, pendingPEM); err != nil || recovered {
+			return err
+		}
+	}
+
+	keyChangeURL := acc.core.GetDirectory().KeyChangeURL
+	if keyChangeURL == "" {
+		return fmt.Errorf("CA不支持更换账号密钥")
+	}
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	newPEM, err := marshalAccountKey(newKey)
+	if err != nil {
+		return err
+	}
+	if _, err = updateAccountRow(id, map[string]any{"pending_key": newPEM}, "", nil); err != nil {
+		return fmt.Errorf("暂存新密钥失败: %w", err)
+	}
+
+	err = postKeyChange(acc, keyChangeURL, newKey)
+	if err != nil {
+		if !keyChangeRejected(err) {
+			// 网络错误等情况下 CA 可能已更换密钥，保留暂存的密钥，下次执行时向 CA 确认
+			return fmt.Errorf("更换账号密钥时与CA通信失败，CA可能已更换密钥，新密钥已暂存，请重新执行更换密钥以确认: %v", err)
+		}
+		_, _ = updateAccountRow(id, map[string]any{"pending_key": ""}, "", nil)
+		return fmt.Errorf("更换账号密钥失败: %v", err)
+	}
+	affected, err := updateAccountRow(id, map[string]any{
+		"private_key": newPEM,
+		"pending_key": "",
+	}, " and private_key=?", []any{oldPEM})
+	if err != nil || affected == 0 {
+		return fmt.Errorf("CA已更换账号密钥，但保存新密钥失败，新密钥已暂存，请重新执行更换密钥以完成保存")
+	}
+	return nil
+}
+
+// keyChangeRejected 判断更换密钥请求是否被 CA 明确拒绝，此时 CA 仍使用旧密钥
+func keyChangeRejected(err error) bool {
+	var problem *acme.ProblemDetails
+	return errors.As(err, &problem) && problem.HTTPStatus >= http.StatusBadRequest && problem.HTTPStatus < http.StatusInternalServerError
+}
+
+// recoverPendingKey 用暂存的密钥向 CA 查询账号，若 CA 已使用该密钥则完成本地替换。
+// 只有 CA 明确答复该密钥没有对应账号时才丢弃暂存的密钥，其他错误保留密钥并返回错误，避免丢失 CA 已启用的密钥
+func recoverPendingKey(id string, user *MyUser, oldPEM, pendingPEM string) (bool, error) {
+	pendingKey, err := public.ParsePrivateKey([]byte(pendingPEM))
+	if err != nil {
+		// 暂存的密钥已损坏，无法再用于确认，直接丢弃
+		_, err = updateAccountRow(id, map[string]any{"pending_key": ""}, "", nil)
+		return false, err
+	}
+	pending, err := getRegisteredAccount(id, pendingKey)
+	if err != nil {
+		return false, fmt.Errorf("确认暂存的账号密钥失败，请稍后重试: %v", err)
+	}
+	pendingUser := &MyUser{Email: user.Email, Registration: user.Registration, key: pendingKey}
+	reg, err := registration.NewRegistrar(pending.core, pendingUser).ResolveAccountByKey()
+	if err != nil && !pendingKeyUnknown(err) {
+		return false, fmt.Errorf("确认暂存的账号密钥失败，请稍后重试: %v", err)
+	}
+	if err == nil && reg.URI == user.Registration.URI {
+		affected, err := updateAccountRow(id, map[string]any{
+			"private_key": pendingPEM,
+			"pending_key": "",
+		}, " and private_key=?", []any{oldPEM})
+		if err != nil || affected == 0 {
+			return false, fmt.Errorf("保存新密钥失败，请稍后重试")
+		}
+		return true, nil
+	}
+	// CA 未使用暂存的密钥，丢弃后重新更换
+	_, err = updateAccountRow(id, map[string]any{"pending_key": ""}, "", nil)
+	return false, err
+}
+
+// pendingKeyUnknown 判断 CA 是否明确答复该密钥没有对应的账号
+func pendingKeyUnknown(err error) bool {
+	var problem *acme.ProblemDetails
+	return errors.As(err, &problem) && problem.Type == accountDoesNotExistErr
+}
+
+// keyChangeNonce 从 CA 获取 Replay-Nonce
+type keyChangeNonce struct {
+	acc *acmeAccount
+}
+
+func (n keyChangeNonce) Nonce() (string, error) {
+	resp, err := n.acc.httpClient.Head(n.acc.core.GetDirectory().NewNonceURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	nonce := resp.Header.Get("Replay-Nonce")
+	if nonce == "" {
+		return "", fmt.Errorf("CA未返回nonce")
+	}
+	return nonce, nil
+}
+
+func jwsAlgorithm(key crypto.PrivateKey) (jose.SignatureAlgorithm, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return jose.RS256, nil
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jose.ES256, nil
+		case elliptic.P384():
+			return jose.ES384, nil
+		}
+	}
+	return "", fmt.Errorf("不支持的账号密钥类型")
+}
+
+// signKeyChange 按 RFC 8555 7.3.5 构造更换密钥的请求：
+// 内层 JWS 由新密钥签名并携带新公钥，外层 JWS 由旧密钥以账号 URL 作为 kid 签名
+func signKeyChange(keyChangeURL, accountURL string, oldKey, newKey crypto.PrivateKey, nonce jose.NonceSource) (string, error) {
+	newAlg, err := jwsAlgorithm(newKey)
+	if err != nil {
+		return "", err
+	}
+	oldAlg, err := jwsAlgorithm(oldKey)
+	if err != nil {
+		return "", err
+	}
+	oldSigner, ok := oldKey.(crypto.Signer)
+	if !ok {
+		return "", fmt.Errorf("不支持的账号密钥类型")
+	}
+	payload, err := json.Marshal(map[string]any{
+		"account": accountURL,
+		"oldKey":  jose.JSONWebKey{Key: oldSigner.Public()},
+	})
+	if err != nil {
+		return "", err
+	}
+	innerSigner, err := jose.NewSigner(jose.SigningKey{Algorithm: newAlg, Key: newKey}, &jose.SignerOptions{
+		EmbedJWK:     true,
+		ExtraHeaders: map[jose.HeaderKey]any{"url": keyChangeURL},
+	})
+	if err != nil {
+		return "", err
+	}
+	inner, err := innerSigner.Sign(payload)
+	if err != nil {
+		return "", err
+	}
+	outerSigner, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: oldAlg,
+		Key:       jose.JSONWebKey{Key: oldKey, KeyID: accountURL},
+	}, &jose.SignerOptions{
+		NonceSource:  nonce,
+		ExtraHeaders: map[jose.HeaderKey]any{"url": keyChangeURL},
+	})
+	if err != nil {
+		return "", err
+	}
+	outer, err := outerSigner.Sign([]byte(inner.FullSerialize()))
+	if err != nil {
+		return "", err
+	}
+	return outer.FullSerialize(), nil
+}
+
+// postKeyChange 提交更换密钥请求，nonce 失效时重试一次
+func postKeyChange(acc *acmeAccount, keyChangeURL string, newKey crypto.PrivateKey) error {
+	for i := 0; ; i++ {
+		body, err := signKeyChange(keyChangeURL, acc.user.Registration.URI, acc.user.key, newKey, keyChangeNonce{acc: acc})
+		if err != nil {
+			return err
+		}
+		resp, err := acc.httpClient.Post(keyChangeURL, "application/jose+json", strings.NewReader(body))
+		if err != nil {
+			return err
+		}
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		resp.Body.Close()
+		if resp.StatusCode < http.StatusBadRequest {
+			return nil
+		}
+		problem := &acme.ProblemDetails{}
+		_ = json.Unmarshal(raw, problem)
+		problem.HTTPStatus = resp.StatusCode
+		if problem.Type == acme.BadNonceErr && i == 0 {
+			continue
+		}
+		return problem
+	}
+}
+
+// parseContactEmails 解析逗号分隔的联系邮箱
+func parseContactEmails(emails string) ([]string, error) {
+	var contacts []string
+	for _, email := range strings.Split(emails, ",") {
+		email = strings.TrimSpace(email)
+		if email == "" {
+			continue
+		}
+		if !strings.Contains(email, "@") || strings.ContainsAny(email, " ;") {
+			return nil, fmt.Errorf("邮箱格式错误: %s", email)
+		}
+		contacts = append(contacts, "mailto:"+email)
+	}
+	if len(contacts) == 0 {
+		return nil, fmt.Errorf("联系邮箱不能为空")
+	}
+	return contacts, nil
+}
+
+// UpdateAccountContact 更新账号在 CA 的联系邮箱，多个邮箱以逗号分隔
+//
+// 账号记录中的 email 仍作为工作流选择账号的标识，不随联系邮箱变化。
+func UpdateAccountContact(id, emails string) error {
+	contacts, err := parseContactEmails(emails)
+	if err != nil {
+		return err
+	}
+	acc, err := getRegisteredAccount(id, nil)
+	if err != nil {
+		return err
+	}
+	account, err := acc.core.Accounts.Update(acc.user.Registration.URI, acme.Account{Contact: contacts})
+	if err != nil {
+		return fmt.Errorf("更新联系邮箱失败: %v", err)
+	}
+	acc.user.Registration.Body = account
+	regBytes, err := json.Marshal(acc.user.Registration)
+	if err != nil {
+		return err
+	}
+	_, err = updateAccountRow(id, map[string]any{"reg": string(regBytes)}, "", nil)
+	return err
+}
+
+// DeactivateAccount 在 CA 注销账号，注销后账号不可恢复，也不能再用于申请证书
+func DeactivateAccount(id string) error {
+	acc, err := getRegisteredAccount(id, nil)
+	if err != nil {
+		return err
+	}
+	if err = acc.core.Accounts.Deactivate(acc.user.Registration.URI); err != nil {
+		return fmt.Errorf("注销账号失败: %v", err)
+	}
+	acc.user.Registration.Body.Status = accountDeactivated
+	regBytes, err := json.Marshal(acc.user.Registration)
+	if err != nil {
+		return err
+	}
+	_, err = updateAccountRow(id, map[string]any{
+		"reg":    string(regBytes),
+		"status": accountDeactivated,
+	}, "", nil)
+	return err
+}
@@ -0,0 +1,147 @@
+package apply
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/registration"
+	jose "github.com/go-jose/go-jose/v4"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPostKeyChange(t *testing.T) {
+	oldKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	newKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	algs := []jose.SignatureAlgorithm{jose.ES256}
+
+	var server *httptest.Server
+	requests := 0
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Replay-Nonce", "nonce")
+		switch r.URL.Path {
+		case "/directory":
+			_ = json.NewEncoder(w).Encode(acme.Directory{
+				NewNonceURL:   server.URL + "/nonce",
+				NewAccountURL: server.URL + "/account",
+				NewOrderURL:   server.URL + "/order",
+				KeyChangeURL:  server.URL + "/key-change",
+			})
+		case "/nonce":
+		case "/key-change":
+			requests++
+			if requests == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(acme.ProblemDetails{Type: acme.BadNonceErr})
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			outer, err := jose.ParseSigned(string(body), algs)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			header := outer.Signatures[0].Protected
+			if header.KeyID != server.URL+"/account/1" || header.Nonce != "nonce" || header.ExtraHeaders["url"] != server.URL+"/key-change" {
+				t.Errorf("unexpected outer header: %+v", header)
+				return
+			}
+			innerRaw, err := outer.Verify(oldKey.Public())
+			if err != nil {
+				t.Error("outer JWS must be signed by the old key:", err)
+				return
+			}
+			inner, err := jose.ParseSigned(string(innerRaw), algs)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if inner.Signatures[0].Protected.JSONWebKey == nil || inner.Signatures[0].Protected.Nonce != "" {
+				t.Error("inner JWS must embed the new key without a nonce")
+				return
+			}
+			payload, err := inner.Verify(newKey.Public())
+			if err != nil {
+				t.Error("inner JWS must be signed by the new key:", err)
+				return
+			}
+			var req struct {
+				Account string          `json:"account"`
+				OldKey  jose.JSONWebKey `json:"oldKey"`
+			}
+			if err = json.Unmarshal(payload, &req); err != nil {
+				t.Error(err)
+				return
+			}
+			if req.Account != server.URL+"/account/1" || !req.OldKey.Key.(*ecdsa.PublicKey).Equal(oldKey.Public()) {
+				t.Errorf("unexpected key change payload: %s", payload)
+				return
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	accountURL := server.URL + "/account/1"
+	core, err := api.New(server.Client(), "ALLinSSL", server.URL+"/directory", accountURL, oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc := &acmeAccount{
+		user:       &MyUser{Registration: &registration.Resource{URI: accountURL}, key: oldKey},
+		core:       core,
+		httpClient: server.Client(),
+	}
+	if err = postKeyChange(acc, core.GetDirectory().KeyChangeURL, newKey); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected a retry after badNonce, got %d requests", requests)
+	}
+}
+
+func TestParseContactEmails(t *testing.T) {
+	contacts, err := parseContactEmails("a@example.com, b@example.com,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contacts) != 2 || contacts[0] != "mailto:a@example.com" || contacts[1] != "mailto:b@example.com" {
+		t.Fatalf("unexpected contacts: %v", contacts)
+	}
+	if _, err = parseContactEmails(" , "); err == nil {
+		t.Fatal("expected empty contacts to fail")
+	}
+	if _, err = parseContactEmails("not-an-email"); err == nil {
+		t.Fatal("expected invalid email to fail")
+	}
+}
+
+func TestKeyChangeErrors(t *testing.T) {
+	rejected := &acme.ProblemDetails{Type: "urn:ietf:params:acme:error:malformed", HTTPStatus: http.StatusBadRequest}
+	cases := []struct {
+		err               error
+		rejected, unknown bool
+	}{
+		{rejected, true, false},
+		{&acme.ProblemDetails{Type: "urn:ietf:params:acme:error:serverInternal", HTTPStatus: http.StatusInternalServerError}, false, false},
+		{&net.OpError{Op: "read", Err: errors.New("connection reset by peer")}, false, false},
+		{fmt.Errorf("new account: %w", &acme.ProblemDetails{Type: accountDoesNotExistErr, HTTPStatus: http.StatusBadRequest}), true, true},
+	}
+	for _, c := range cases {
+		if got := keyChangeRejected(c.err); got != c.rejected {
+			t.Errorf("keyChangeRejected(%v) = %v, want %v", c.err, got, c.rejected)
+		}
+		if got := pendingKeyUnknown(c.err); got != c.unknown {
+			t.Errorf("pendingKeyUnknown(%v) = %v, want %v", c.err, got, c.unknown)
+		}
+	}
+}
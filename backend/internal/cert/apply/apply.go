@@ -4,7 +4,9 @@ import (
 	"ALLinSSL/backend/internal/access"
 	"ALLinSSL/backend/internal/cert"
 	"ALLinSSL/backend/internal/cert/apply/lego/jdcloud"
+	"ALLinSSL/backend/internal/cert/privateca"
 	"ALLinSSL/backend/public"
+	"context"
 	"encoding/json"
 	"fmt"
 	azcorecloud "github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
@@ -191,6 +193,11 @@ func GetDNSProvider(providerName string, creds map[string]string, httpClient *ht
 		config.RegionId = "cn-north-1"
 		config.PropagationTimeout = maxWait
 		return jdcloud.NewDNSProviderConfig(config)
+	case "rfc2136":
+		provider, _, err := newRFC2136Provider(creds, maxWait)
+		return provider, err
+	case "lego-generic":
+		return NewLegoGenericProvider(creds, maxWait)
 
 	default:
 		return nil, fmt.Errorf("不支持的 DNS Provider: %s", providerName)
@@ -252,7 +259,8 @@ func getEABFromAccData(accData map[string]any, eabData *map[string]any) bool {
 	return false
 }
 
-func GetAcmeClient(email, algorithm, eabId, ca string, httpClient *http.Client, logger *public.Logger) (*lego.Client, error) {
+// ResolveCA 根据 eabId 和 ca 参数确定实际使用的 CA，使用旧版 EAB 授权时一并返回 EAB 信息
+func ResolveCA(eabId, ca string) (string, map[string]any, error) {
 	var (
 		eabData map[string]any
 		err     error
@@ -269,19 +277,27 @@ func GetAcmeClient(email, algorithm, eabId, ca string, httpClient *http.Client,
 	default:
 		eabData, err = access.GetEAB(eabId)
 		if err != nil {
-			return nil, err
+			return "", nil, err
 		}
 		if eabData == nil {
-			return nil, fmt.Errorf("未找到EAB信息")
+			return "", nil, fmt.Errorf("未找到EAB信息")
 		}
 		if eabData["Kid"] == nil {
-			return nil, fmt.Errorf("Kid不能为空")
+			return "", nil, fmt.Errorf("Kid不能为空")
 		}
 		if eabData["HmacEncoded"] == nil {
-			return nil, fmt.Errorf("HmacEncoded不能为空")
+			return "", nil, fmt.Errorf("HmacEncoded不能为空")
 		}
 		ca = eabData["ca"].(string)
 	}
+	return ca, eabData, nil
+}
+
+func GetAcmeClient(email, algorithm, eabId, ca string, httpClient *http.Client, logger *public.Logger) (*lego.Client, error) {
+	ca, eabData, err := ResolveCA(eabId, ca)
+	if err != nil {
+		return nil, err
+	}
 
 	CADirURL := CADirURLMap[ca]
 	if ca == "sslcom" {
@@ -291,11 +307,30 @@ func GetAcmeClient(email, algorithm, eabId, ca string, httpClient *http.Client,
 			CADirURL = CADirURLMap["sslcom-rsa"]
 		}
 	}
+	// CA 注册表中的自定义 CA，使用注册的目录地址及根证书
+	caInfo, err := GetRegistryCA(ca)
+	if err != nil {
+		logger.Debug("查询CA注册表失败", err)
+	}
+	if caInfo != nil {
+		if CADirURL == "" {
+			CADirURL, _ = caInfo["dir_url"].(string)
+		}
+		if err = checkCAKeyType(caInfo, algorithm); err != nil {
+			return nil, err
+		}
+		httpClient, err = caHTTPClient(caInfo, httpClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// 内置的免费 CA 及注册表中的 CA 可自动注册账号，其他 CA 需要在账号管理中预设账号
+	autoRegister := ca == "Let's Encrypt" || ca == "zerossl" || ca == "buypass" || caInfo != nil
 	db, err := GetSqlite()
 	var accData map[string]any
 	if err != nil {
 		logger.Debug("获取数据库连接失败", err)
-		if ca != "Let's Encrypt" && ca != "zerossl" && ca != "buypass" {
+		if !autoRegister {
 			return nil, fmt.Errorf("当前CA【%s】 需要从数据库获取预设账号，但是连接数据库失败，请稍后重试，err:%w", ca, err)
 		}
 	} else {
@@ -303,10 +338,13 @@ func GetAcmeClient(email, algorithm, eabId, ca string, httpClient *http.Client,
 		accData, err = GetAccount(db, email, ca)
 		if err != nil || accData == nil {
 			logger.Debug("获取acme账号信息失败")
-			if ca != "Let's Encrypt" && ca != "zerossl" && ca != "buypass" {
+			if !autoRegister {
 				return nil, fmt.Errorf("未找到%s账号信息，请先在账号管理中添加%s账号, email:%s", ca, ca, email)
 			}
 		}
+		if status, _ := accData["status"].(string); status == accountDeactivated {
+			return nil, fmt.Errorf("%s账号 %s 已在CA注销，请使用其他账号", ca, email)
+		}
 		if CADirURL == "" {
 			accCADirURL, ok := accData["CADirURL"].(string)
 			if !ok || accCADirURL == "" {
@@ -340,6 +378,10 @@ func GetAcmeClient(email, algorithm, eabId, ca string, httpClient *http.Client,
 					}
 				case "sslcom", "google":
 					return nil, fmt.Errorf("未找到EAB信息，请在账号管理中添加%s账号", ca)
+				default:
+					if eabRequired, ok := caInfo["eab_required"].(int64); ok && eabRequired == 1 {
+						return nil, fmt.Errorf("CA【%s】需要EAB，请在账号管理中添加带EAB信息的%s账号", ca, ca)
+					}
 				}
 			}
 		}
@@ -376,31 +418,34 @@ func GetAcmeClient(email, algorithm, eabId, ca string, httpClient *http.Client,
 	return client, nil
 }
 
-func GetCert(runId string, domainArr []string, endDay int, logger *public.Logger) (map[string]any, error) {
+// GetLastCert 获取当前工作流上次申请的、包含全部域名且未吊销的证书中剩余天数最多的一张
+//
+// algorithm 不为空时只匹配该算法的证书，用于同时签发多种算法证书的场景。
+func GetLastCert(runId string, domainArr []string, algorithm string) (map[string]any, float64, error) {
 	if runId == "" {
-		return nil, fmt.Errorf("参数错误：_runId")
+		return nil, 0, fmt.Errorf("参数错误：_runId")
 	}
 	s, err := public.NewSqlite("data/data.db", "")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	s.TableName = "workflow_history"
 	defer s.Close()
 	// 查询 workflowId
 	wh, err := s.Where("id=?", []interface{}{runId}).Select()
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	if len(wh) <= 0 {
-		return nil, fmt.Errorf("未获取到对应的workflowId")
+		return nil, 0, fmt.Errorf("未获取到对应的workflowId")
 	}
 	s.TableName = "cert"
 	certs, err := s.Where("workflow_id=?", []interface{}{wh[0]["workflow_id"]}).Select()
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	if len(certs) <= 0 {
-		return nil, fmt.Errorf("未获取到当前工作流下的证书")
+		return nil, 0, fmt.Errorf("未获取到当前工作流下的证书")
 	}
 	layout := "2006-01-02 15:04:05"
 	var maxDays float64
@@ -409,6 +454,16 @@ func GetCert(runId string, domainArr []string, endDay int, logger *public.Logger
 		if !public.ContainsAllIgnoreBRepeats(strings.Split(certs[i]["domains"].(string), ","), domainArr) {
 			continue
 		}
+		// 已吊销的证书不能复用
+		if revoked, ok := certs[i]["revoked"].(int64); ok && revoked == 1 {
+			continue
+		}
+		if algorithm != "" {
+			certStr, _ := certs[i]["cert"].(string)
+			if public.GetCertKeyAlgorithm(certStr) != algorithm {
+				continue
+			}
+		}
 		endTimeStr, ok := certs[i]["end_time"].(string)
 		if !ok {
 			continue
@@ -424,10 +479,37 @@ func GetCert(runId string, domainArr []string, endDay int, logger *public.Logger
 		}
 	}
 	if maxItem == nil {
-		return nil, fmt.Errorf("未获取到对应的证书")
+		return nil, 0, fmt.Errorf("未获取到对应的证书")
+	}
+	return maxItem, maxDays, nil
+}
+
+// GetCert 获取当前工作流上次申请的证书，未到续期时间时直接复用
+//
+// 优先按 CA 通过 ARI 建议的续期窗口判断，CA 不支持 ARI 时回退到 end_day；
+// 需要续期时返回的 error 不为空，并在支持 ARI 时返回旧证书的 ARI 标识。
+func GetCert(runId string, domainArr []string, algorithm string, endDay int, getClient func() (*lego.Client, error), logger *public.Logger) (map[string]any, string, error) {
+	maxItem, maxDays, err := GetLastCert(runId, domainArr, algorithm)
+	if err != nil {
+		return nil, "", err
+	}
+	if getClient != nil {
+		renew, replacesCertID, checked := checkARIRenewal(maxItem, getClient, logger)
+		if checked {
+			if renew {
+				return nil, replacesCertID, fmt.Errorf("证书已进入CA建议的续期窗口，剩余天数：%d", int(maxDays))
+			}
+			logger.Debug(fmt.Sprintf("上次证书申请成功,域名：%s，未到CA建议的续期时间，已跳过申请复用此证书", maxItem["domains"]))
+			return map[string]any{
+				"cert":       maxItem["cert"],
+				"key":        maxItem["key"],
+				"issuerCert": maxItem["issuer_cert"],
+				"skip":       true,
+			}, "", nil
+		}
 	}
 	if int(maxDays) <= endDay {
-		return nil, fmt.Errorf("证书已过期或即将过期，剩余天数：%d 小于%d天", int(maxDays), endDay)
+		return nil, "", fmt.Errorf("证书已过期或即将过期，剩余天数：%d 小于%d天", int(maxDays), endDay)
 	}
 	// 证书未过期，直接返回
 	logger.Debug(fmt.Sprintf("上次证书申请成功,域名：%s，剩余天数：%d 大于%d天，已跳过申请复用此证书", maxItem["domains"], int(maxDays), endDay))
@@ -436,11 +518,19 @@ func GetCert(runId string, domainArr []string, endDay int, logger *public.Logger
 		"key":        maxItem["key"],
 		"issuerCert": maxItem["issuer_cert"],
 		"skip":       true,
-	}, nil
+	}, "", nil
 }
 
-func Apply(cfg map[string]any, logger *public.Logger) (map[string]any, error) {
+// Apply 申请证书，ctx 取消时中断向 CA 的请求及 DNS 记录生效等待
+func Apply(ctx context.Context, cfg map[string]any, logger *public.Logger) (map[string]any, error) {
 	log.Logger = logger.GetLogger()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	// 内置私有 CA 不走 ACME 流程
+	if ca, _ := cfg["ca"].(string); ca == privateca.ProviderName {
+		return applyInternal(ctx, cfg, logger)
+	}
 	var err error
 	email, ok := cfg["email"].(string)
 	if !ok {
@@ -450,8 +540,30 @@ func Apply(cfg map[string]any, logger *public.Logger) (map[string]any, error) {
 	if !ok {
 		return nil, fmt.Errorf("参数错误：domains")
 	}
+	challengeType, ok := cfg["challenge"].(string)
+	if !ok || challengeType == "" {
+		challengeType = "dns-01"
+	}
+	domainProviders, err := ParseDomainProviders(cfg["domain_providers"])
+	if err != nil {
+		return nil, err
+	}
+	// DNS 别名模式：_acme-challenge 记录通过 CNAME 委派到由其他授权管理的验证域
+	aliasZone, _ := cfg["alias_zone"].(string)
+	aliasZone = strings.TrimSpace(aliasZone)
+	aliasProvider, _ := cfg["alias_provider"].(string)
+	var aliasProviderID string
+	switch v := cfg["alias_provider_id"].(type) {
+	case float64:
+		aliasProviderID = strconv.Itoa(int(v))
+	case string:
+		aliasProviderID = v
+	}
+	if aliasZone != "" && aliasProviderID == "" {
+		return nil, fmt.Errorf("参数错误：alias_provider_id")
+	}
 	providerStr, ok := cfg["provider"].(string)
-	if !ok {
+	if !ok && challengeType == "dns-01" && len(domainProviders) == 0 && aliasZone == "" {
 		return nil, fmt.Errorf("参数错误：provider")
 	}
 	endDay := 30
@@ -470,10 +582,13 @@ func Apply(cfg map[string]any, logger *public.Logger) (map[string]any, error) {
 	case int64:
 		endDay = int(v)
 	}
-	algorithm, ok := cfg["algorithm"].(string)
-	if !ok {
-		algorithm = "RSA2048"
+	// 证书算法，可同时指定多个（如 EC256,RSA2048）分别签发，第一个为主证书
+	algorithms, err := parseAlgorithms(cfg["algorithm"])
+	if err != nil {
+		return nil, err
 	}
+	algorithm := algorithms[0]
+	multiAlgorithm := len(algorithms) > 1
 	var httpClient *http.Client
 	proxy, ok := cfg["proxy"].(string)
 	if ok && proxy != "" {
@@ -489,6 +604,8 @@ func Apply(cfg map[string]any, logger *public.Logger) (map[string]any, error) {
 			Timeout: 30 * time.Second,
 		}
 	}
+	// 向 CA 的请求绑定 ctx；DNS provider 仍使用原客户端，取消后 lego 清理验证记录的请求不受影响
+	acmeHTTPClient := ContextHTTPClient(ctx, httpClient)
 	var eabId string
 	switch v := cfg["eabId"].(type) {
 	case float64:
@@ -510,24 +627,14 @@ func Apply(cfg map[string]any, logger *public.Logger) (map[string]any, error) {
 	case string:
 		providerID = v
 	default:
-		return nil, fmt.Errorf("参数错误：provider_id")
-	}
-	var NameServers []string
-	if cfg["name_server"] == nil {
-		NameServers = []string{
-			"8.8.8.8:53",
-			"1.1.1.1:53",
-		}
-	} else {
-		if nameServerStr, ok := cfg["name_server"].(string); ok {
-			NameServers = strings.Split(nameServerStr, ",")
-			for i := range NameServers {
-				NameServers[i] = strings.TrimSpace(NameServers[i])
-			}
-		} else {
-			return nil, fmt.Errorf("参数错误：name_server")
+		if challengeType == "dns-01" && len(domainProviders) == 0 && aliasZone == "" {
+			return nil, fmt.Errorf("参数错误：provider_id")
 		}
 	}
+	NameServers, err := parseNameServers(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	var skipCheck bool
 	if cfg["skip_check"] == nil {
@@ -619,132 +726,388 @@ func Apply(cfg map[string]any, logger *public.Logger) (map[string]any, error) {
 			return nil, fmt.Errorf("参数错误：close_cname")
 		}
 	}
-	var maxWait time.Duration
-	if cfg["max_wait"] == nil {
-		// 默认最大等待时间为2分钟
-		maxWait = 2 * time.Minute
-	} else {
-		switch v := cfg["max_wait"].(type) {
-		case int:
-			maxWait = time.Duration(v) * time.Second
-		case float64:
-			maxWait = time.Duration(v) * time.Second
-		case string:
-			maxWait = 2 * time.Minute // 默认值
-			if v != "" {
-				d, err := strconv.Atoi(v)
-				if err == nil {
-					maxWait = time.Duration(d) * time.Second
-				}
-			}
-		default:
-			maxWait = 2 * time.Minute
-		}
+	// 续期时复用上一张证书的私钥
+	reuseKey, err := parseBoolCfg(cfg, "reuse_key")
+	if err != nil {
+		return nil, err
 	}
+	// 备用 CA，主 CA 限流或不可用时按顺序切换
+	fallbackCAs, err := parseFallbackCAs(cfg["fallback_cas"], email)
+	if err != nil {
+		return nil, err
+	}
+	// 申请带 OCSP Must-Staple 扩展的证书
+	mustStaple, err := parseBoolCfg(cfg, "must_staple")
+	if err != nil {
+		return nil, err
+	}
+	// 使用自带的 CSR 申请，私钥可选
+	csrStr, _ := cfg["csr"].(string)
+	csrKeyStr, _ := cfg["csr_key"].(string)
+	if multiAlgorithm && strings.TrimSpace(csrStr) != "" {
+		return nil, fmt.Errorf("使用自带 CSR 申请时只能指定一种证书算法")
+	}
+
+	maxWait := parseMaxWait(cfg)
 
-	domainArr := strings.Split(domains, ",")
-	for i := range domainArr {
-		domainArr[i] = strings.TrimSpace(domainArr[i])
+	// IP 地址以 IP 标识提交，只能通过 HTTP-01 或 TLS-ALPN-01 验证
+	domainArr, hasIP := normalizeIdentifiers(strings.Split(domains, ","))
+	if hasIP && challengeType == "dns-01" {
+		return nil, fmt.Errorf("IP地址证书不支持dns-01验证，请使用http-01或tls-alpn-01")
 	}
+	// 域名数量超过 CA 单张证书上限时，可按数量或注册域名拆分为多个订单
+	splitMode, _ := cfg["split_mode"].(string)
+	splitMode = strings.TrimSpace(splitMode)
+	maxNames, knownLimit := defaultSplitSize, false
+	if acmeCA, _, err := ResolveCA(eabId, ca); err == nil && caMaxNames[acmeCA] > 0 {
+		maxNames, knownLimit = caMaxNames[acmeCA], true
+	}
+	if splitMode != "" && strings.TrimSpace(csrStr) != "" {
+		return nil, fmt.Errorf("使用自带 CSR 申请时不能拆分域名，请关闭 split_mode")
+	}
+	if splitMode != "" {
+		splitSize, err := parseSplitSize(cfg, maxNames, knownLimit)
+		if err != nil {
+			return nil, err
+		}
+		groups, err := splitDomains(domainArr, splitMode, splitSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(groups) > 1 {
+			return applySplit(ctx, cfg, domainArr, groups, logger)
+		}
+	} else if knownLimit && len(domainArr) > maxNames {
+		return nil, fmt.Errorf("域名数量 %d 超过CA单张证书上限 %d，请设置 split_mode 拆分申请", len(domainArr), maxNames)
+	}
+	// 证书配置（profile），如 Let's Encrypt 的 shortlived、tlsserver，为空时使用 CA 默认配置
+	profile, _ := cfg["profile"].(string)
+	profile = strings.TrimSpace(profile)
+	// 首选证书链，按根证书（顶级签发者）的通用名匹配，如 ISRG Root X1，CA 未提供时使用默认链
+	preferredChain, _ := cfg["preferred_chain"].(string)
+	preferredChain = strings.TrimSpace(preferredChain)
 
 	// 获取上次申请的证书
 	runId, ok := cfg["_runId"].(string)
 	if !ok {
 		return nil, fmt.Errorf("参数错误：_runId")
 	}
-	certData, err := GetCert(runId, domainArr, endDay, logger)
-	if err != nil {
-		logger.Debug("未获取到符合条件的本地证书:" + err.Error())
-	} else {
-		return certData, nil
+	// ACME 客户端按需创建，复用证书时仅在需要查询 ARI 时才会连接 CA
+	var client *lego.Client
+	getClient := func() (*lego.Client, error) {
+		if client != nil {
+			return client, nil
+		}
+		c, err := GetAcmeClient(email, algorithm, eabId, ca, acmeHTTPClient, logger)
+		if err != nil {
+			return nil, err
+		}
+		client = c
+		return client, nil
 	}
-	logger.Debug("正在申请证书，域名: " + domains)
-	os.Setenv("LEGO_DISABLE_CNAME_SUPPORT", strconv.FormatBool(closeCname))
-	// 创建 ACME 客户端
-	client, err := GetAcmeClient(email, algorithm, eabId, ca, httpClient, logger)
-	if err != nil {
-		return nil, err
+	// 按算法分别判断是否需要续期，未到续期时间的证书直接复用
+	results := make([]map[string]any, len(algorithms))
+	replacesCertIDs := make([]string, len(algorithms))
+	pending := 0
+	for i, alg := range algorithms {
+		var keyAlgorithm string
+		if multiAlgorithm {
+			keyAlgorithm = alg
+		}
+		certData, replacesCertID, err := GetCert(runId, domainArr, keyAlgorithm, endDay, getClient, logger)
+		if err != nil {
+			logger.Debug(fmt.Sprintf("未获取到符合条件的本地%s证书:%s", keyAlgorithm, err.Error()))
+			replacesCertIDs[i] = replacesCertID
+			pending++
+			continue
+		}
+		results[i] = certData
 	}
-	// 获取 DNS 验证提供者
-	providerData, err := access.GetAccess(providerID)
-	if err != nil {
-		return nil, err
+	if pending == 0 {
+		return mergeCertResults(algorithms, results), nil
 	}
-	providerConfigStr, ok := providerData["config"].(string)
-	if !ok {
-		return nil, fmt.Errorf("api配置错误")
+	logger.Debug("正在申请证书，域名: " + domains)
+	if profile != "" {
+		logger.Debug("使用证书配置：" + profile)
 	}
-	// 解析 JSON 配置
-	var providerConfig map[string]string
-	err = json.Unmarshal([]byte(providerConfigStr), &providerConfig)
-	if err != nil {
-		return nil, err
+	if aliasZone != "" && challengeType == "dns-01" {
+		// 别名模式依赖 CNAME 跟随，并在下单前确认委派记录已生效
+		closeCname = false
+		logger.Debug("使用DNS别名模式，验证域：" + aliasZone)
+		err = checkAliasCNAME(domainArr, aliasZone, NameServers)
+		if err != nil {
+			return nil, err
+		}
 	}
+	os.Setenv("LEGO_DISABLE_CNAME_SUPPORT", strconv.FormatBool(closeCname))
+	// 设置验证方式，切换 CA 后需要为新的客户端重新设置
+	setupChallenge := func(client *lego.Client) error {
+		var err error
+		switch challengeType {
+		case "dns-01":
+			// 获取 DNS 验证提供者
+			var provider challenge.Provider
+			if aliasZone != "" {
+				// 验证记录写入验证域，使用验证域的授权
+				provider, err = GetDNSProviderByAccess(aliasProvider, aliasProviderID, httpClient, maxWait)
+				if err != nil {
+					return fmt.Errorf("创建验证域 DNS provider 失败: %v", err)
+				}
+			} else if providerID != "" {
+				provider, err = GetDNSProviderByAccess(providerStr, providerID, httpClient, maxWait)
+				if err != nil {
+					return fmt.Errorf("创建 DNS provider 失败: %v", err)
+				}
+			}
+			// 按域名指定不同的 DNS 授权，未指定的域名使用默认授权
+			if len(domainProviders) > 0 && aliasZone == "" {
+				logger.Debug("按域名分配 DNS 授权")
+				provider, err = NewMultiDNSProvider(provider, domainProviders, httpClient, maxWait)
+				if err != nil {
+					return err
+				}
+			}
 
-	// DNS 验证
-	provider, err := GetDNSProvider(providerStr, providerConfig, httpClient, maxWait)
-	if err != nil {
-		return nil, fmt.Errorf("创建 DNS provider 失败: %v", err)
+			if skipCheck {
+				// 跳过预检查
+				err = client.Challenge.SetDNS01Provider(provider,
+					dns01.WrapPreCheck(func(domain, fqdn, value string, check dns01.PreCheckFunc) (bool, error) {
+						return true, ctx.Err()
+					}),
+				)
+			} else {
+				start := time.Now()
+				if ignoreCheck {
+					err = client.Challenge.SetDNS01Provider(provider,
+						dns01.AddRecursiveNameservers(NameServers),
+						dns01.WrapPreCheck(func(domain, fqdn, value string, check dns01.PreCheckFunc) (bool, error) {
+							// 取消执行时结束等待
+							if err := ctx.Err(); err != nil {
+								return false, err
+							}
+							ok, err := check(fqdn, value)
+							elapsed := time.Since(start)
+							if err != nil {
+								log.Printf("[WARN] DNS precheck error for %s: %v", fqdn, err)
+								if elapsed >= maxWait {
+									log.Printf("[WARN] Precheck error but forcing continue due to timeout for %s", fqdn)
+									return true, nil
+								}
+								return false, nil
+							}
+							if ok {
+								log.Printf("[OK] TXT record for %s is present.", fqdn)
+								return true, nil
+							}
+							if elapsed >= maxWait {
+								log.Printf("[WARN] TXT record for %s not found after %v, forcing continue.", fqdn, elapsed)
+								return true, nil
+							}
+							log.Printf("[INFO] TXT record for %s not yet found, waiting... elapsed %v", fqdn, elapsed)
+							return false, nil
+						}),
+					)
+				} else {
+					err = client.Challenge.SetDNS01Provider(provider,
+						dns01.AddRecursiveNameservers(NameServers),
+						dns01.WrapPreCheck(func(domain, fqdn, value string, check dns01.PreCheckFunc) (bool, error) {
+							if err := ctx.Err(); err != nil {
+								return false, err
+							}
+							return check(fqdn, value)
+						}),
+					)
+				}
+			}
+		case "http-01":
+			logger.Debug("使用 HTTP-01 验证")
+			var provider challenge.Provider
+			provider, err = GetHTTPProvider(ctx, cfg, logger)
+			if err != nil {
+				return fmt.Errorf("创建 HTTP-01 provider 失败: %v", err)
+			}
+			err = client.Challenge.SetHTTP01Provider(provider)
+		case "tls-alpn-01":
+			logger.Debug("使用 TLS-ALPN-01 验证")
+			var provider challenge.Provider
+			provider, err = GetTLSALPNProvider(cfg)
+			if err != nil {
+				return fmt.Errorf("创建 TLS-ALPN-01 provider 失败: %v", err)
+			}
+			err = client.Challenge.SetTLSALPN01Provider(provider)
+		default:
+			return fmt.Errorf("不支持的验证方式: %s", challengeType)
+		}
+		return err
 	}
 
-	if skipCheck {
-		// 跳过预检查
-		err = client.Challenge.SetDNS01Provider(provider,
-			dns01.WrapPreCheck(func(domain, fqdn, value string, check dns01.PreCheckFunc) (bool, error) {
-				return true, nil
-			}),
-		)
-	} else {
-		start := time.Now()
-		if ignoreCheck {
-			err = client.Challenge.SetDNS01Provider(provider,
-				dns01.AddRecursiveNameservers(NameServers),
-				dns01.WrapPreCheck(func(domain, fqdn, value string, check dns01.PreCheckFunc) (bool, error) {
-					ok, err := check(fqdn, value)
-					elapsed := time.Since(start)
-					if err != nil {
-						log.Printf("[WARN] DNS precheck error for %s: %v", fqdn, err)
-						if elapsed >= maxWait {
-							log.Printf("[WARN] Precheck error but forcing continue due to timeout for %s", fqdn)
-							return true, nil
-						}
-						return false, nil
-					}
-					if ok {
-						log.Printf("[OK] TXT record for %s is present.", fqdn)
-						return true, nil
-					}
-					if elapsed >= maxWait {
-						log.Printf("[WARN] TXT record for %s not found after %v, forcing continue.", fqdn, elapsed)
-						return true, nil
+	// 使用当前 CA 签发尚未复用的证书，各算法的订单共用同一账号，CA 会复用已通过的域名验证
+	issue := func() error {
+		acmeCA, _, err := ResolveCA(eabId, ca)
+		if err != nil {
+			return err
+		}
+		// CAA 记录不允许当前 CA 签发时，在下单前失败，避免验证后才被拒绝
+		if err = checkCAA(domainArr, acmeCA, NameServers, acmeHTTPClient, logger); err != nil {
+			return err
+		}
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+		if err = setupChallenge(client); err != nil {
+			return err
+		}
+		if err = checkRateLimit(domainArr, acmeCA, pending, logger); err != nil {
+			return err
+		}
+		for i, alg := range algorithms {
+			if results[i] != nil {
+				continue
+			}
+			opt := obtainOptions{
+				runId:          runId,
+				domainArr:      domainArr,
+				profile:        profile,
+				preferredChain: preferredChain,
+				replacesCertID: replacesCertIDs[i],
+				reuseKey:       reuseKey,
+				mustStaple:     mustStaple,
+				csrStr:         csrStr,
+				csrKeyStr:      csrKeyStr,
+				email:          email,
+				acmeCA:         acmeCA,
+				algorithm:      alg,
+			}
+			if multiAlgorithm {
+				logger.Debug(fmt.Sprintf("正在申请%s证书", alg))
+				if caInfo, err := GetRegistryCA(acmeCA); err == nil && caInfo != nil {
+					if err = checkCAKeyType(caInfo, alg); err != nil {
+						return err
 					}
-					log.Printf("[INFO] TXT record for %s not yet found, waiting... elapsed %v", fqdn, elapsed)
-					return false, nil
-				}),
-			)
-		} else {
-			err = client.Challenge.SetDNS01Provider(provider,
-				dns01.AddRecursiveNameservers(NameServers),
-			)
+				}
+			}
+			results[i], err = obtainCert(client, opt, logger)
+			if err != nil {
+				return err
+			}
 		}
-	}
-	if err != nil {
-		return nil, err
+		return nil
 	}
 
-	// fmt.Println(strings.Split(domains, ","))
-	request := certificate.ObtainRequest{
-		Domains: domainArr,
-		Bundle:  true,
+	// 依次尝试主 CA 及备用 CA，遇到限流或 CA 服务端错误时切换到下一个
+	attempts := append([]caAttempt{{CA: ca, Email: email, EabId: eabId}}, fallbackCAs...)
+	for n, attempt := range attempts {
+		if n > 0 {
+			logger.Info(fmt.Sprintf("切换到备用CA【%s】，账号：%s", attempt.CA, attempt.Email))
+			ca, email, eabId = attempt.CA, attempt.Email, attempt.EabId
+			client = nil
+			// 其他 CA 无法替换当前 CA 签发的证书
+			for i := range replacesCertIDs {
+				replacesCertIDs[i] = ""
+			}
+		}
+		err = issue()
+		if err == nil {
+			break
+		}
+		// 取消执行导致的请求错误不切换 CA
+		if n == len(attempts)-1 || ctx.Err() != nil || !isFailoverError(err) {
+			return nil, err
+		}
+		logger.Error(fmt.Sprintf("CA【%s】申请失败：%v", attempt.CA, err))
 	}
-	certObj, err := client.Certificate.Obtain(request)
-	if err != nil {
-		return nil, err
+	return mergeCertResults(algorithms, results), nil
+}
+
+type obtainOptions struct {
+	runId          string
+	domainArr      []string
+	profile        string
+	preferredChain string
+	replacesCertID string
+	reuseKey       bool
+	mustStaple     bool
+	csrStr         string
+	csrKeyStr      string
+	// algorithm 私钥算法，复用私钥时只复用同算法证书的私钥
+	algorithm string
+	email     string
+	acmeCA    string
+}
+
+// obtainCert 下单签发证书并保存到证书表
+func obtainCert(client *lego.Client, opt obtainOptions, logger *public.Logger) (map[string]any, error) {
+	var (
+		certObj *certificate.Resource
+		err     error
+	)
+	keySource := "generated"
+	if strings.TrimSpace(opt.csrStr) != "" {
+		csr, csrKey, err := parseCSR(opt.csrStr, opt.csrKeyStr, opt.domainArr)
+		if err != nil {
+			return nil, err
+		}
+		// 使用自带 CSR 时证书扩展由 CSR 决定，开启 must_staple 时 CSR 中必须包含该扩展
+		if opt.mustStaple && !csrHasMustStaple(csr) {
+			return nil, fmt.Errorf("已开启 OCSP Must-Staple，但自带的 CSR 未包含 TLS Feature(status_request) 扩展，请重新生成 CSR 或关闭 must_staple")
+		}
+		logger.Debug("使用自带的 CSR 申请证书")
+		certObj, err = client.Certificate.ObtainForCSR(certificate.ObtainForCSRRequest{
+			CSR:            csr,
+			PrivateKey:     csrKey,
+			Bundle:         true,
+			Profile:        opt.profile,
+			PreferredChain: opt.preferredChain,
+			ReplacesCertID: opt.replacesCertID,
+		})
+		RecordOrder(opt.domainArr, opt.acmeCA, err)
+		if err != nil {
+			return nil, err
+		}
+		keySource = "csr"
+	} else {
+		request := certificate.ObtainRequest{
+			Domains:        opt.domainArr,
+			Bundle:         true,
+			MustStaple:     opt.mustStaple,
+			Profile:        opt.profile,
+			PreferredChain: opt.preferredChain,
+			ReplacesCertID: opt.replacesCertID,
+		}
+		if opt.reuseKey {
+			privateKey, err := getReusableKey(opt.runId, opt.domainArr, opt.algorithm, logger)
+			if err != nil {
+				logger.Debug("未找到可复用的私钥，将生成新私钥:", err)
+			} else {
+				request.PrivateKey = privateKey
+				keySource = "reuse"
+			}
+		}
+		if request.PrivateKey == nil {
+			request.PrivateKey, err = certcrypto.GeneratePrivateKey(AlgorithmMap[opt.algorithm])
+			if err != nil {
+				return nil, err
+			}
+		}
+		certObj, err = client.Certificate.Obtain(request)
+		RecordOrder(opt.domainArr, opt.acmeCA, err)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	certStr := string(certObj.Certificate)
 	keyStr := string(certObj.PrivateKey)
 	issuerCertStr := string(certObj.IssuerCertificate)
+	issuers := chainIssuers(certObj.IssuerCertificate)
+	if opt.preferredChain != "" {
+		if matchPreferredChain(issuers, opt.preferredChain) {
+			logger.Debug("已使用首选证书链：" + opt.preferredChain)
+		} else {
+			logger.Info(fmt.Sprintf("CA未提供签发者为【%s】的证书链，使用默认链：%s", opt.preferredChain, joinChainIssuers(issuers)))
+		}
+	}
 
 	// 保存证书和私钥
 	data := map[string]any{
@@ -753,9 +1116,23 @@ func Apply(cfg map[string]any, logger *public.Logger) (map[string]any, error) {
 		"issuerCert": issuerCertStr,
 	}
 
-	_, err = cert.SaveCert("workflow", keyStr, certStr, issuerCertStr, runId)
+	saveCert := cert.SaveCert
+	if keySource == "csr" {
+		saveCert = cert.SaveCSRCert
+	}
+	sha256, err := saveCert("workflow", keyStr, certStr, issuerCertStr, opt.runId)
 	if err != nil {
 		return nil, err
 	}
+	// 记录签发账号（用于后续吊销等操作）及证书链
+	err = cert.UpdateCert(sha256, map[string]any{
+		"key_source":    keySource,
+		"chain_issuers": joinChainIssuers(issuers),
+		"acme_email":    opt.email,
+		"acme_ca":       opt.acmeCA,
+	})
+	if err != nil {
+		logger.Debug("记录证书签发信息失败:", err)
+	}
 	return data, nil
 }
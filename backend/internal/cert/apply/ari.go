@@ -0,0 +1,107 @@
+package apply
+
+import (
+	"ALLinSSL/backend/internal/cert"
+	"ALLinSSL/backend/public"
+	"errors"
+	"fmt"
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/lego"
+	"time"
+)
+
+// ARI 响应未携带 Retry-After 时的默认查询间隔
+const defaultARIRetryAfter = 6 * time.Hour
+
+const timeLayout = "2006-01-02 15:04:05"
+
+// getStoredARI 读取证书记录中缓存的 ARI 续期窗口，缓存未过期时才返回
+func getStoredARI(certRow map[string]any, now time.Time) (*certificate.RenewalInfoResponse, bool) {
+	startStr, _ := certRow["ari_start"].(string)
+	endStr, _ := certRow["ari_end"].(string)
+	retryStr, _ := certRow["ari_retry_after"].(string)
+	if startStr == "" || endStr == "" || retryStr == "" {
+		return nil, false
+	}
+	retryAfter, err := time.ParseInLocation(timeLayout, retryStr, time.Local)
+	if err != nil || !now.Before(retryAfter) {
+		return nil, false
+	}
+	start, err := time.ParseInLocation(timeLayout, startStr, time.Local)
+	if err != nil {
+		return nil, false
+	}
+	end, err := time.ParseInLocation(timeLayout, endStr, time.Local)
+	if err != nil {
+		return nil, false
+	}
+	return &certificate.RenewalInfoResponse{
+		RenewalInfoResponse: acme.RenewalInfoResponse{
+			SuggestedWindow: acme.Window{Start: start, End: end},
+		},
+	}, true
+}
+
+// checkARIRenewal 根据 CA 的 ARI 建议续期窗口判断上次的证书是否需要续期
+//
+// checked 为 false 表示 CA 不支持 ARI 或查询失败，调用方应回退到 end_day 判断；
+// 需要续期时同时返回旧证书的 ARI 标识，用于在新订单中声明替换关系。
+func checkARIRenewal(certRow map[string]any, getClient func() (*lego.Client, error), logger *public.Logger) (renew bool, replacesCertID string, checked bool) {
+	certStr, ok := certRow["cert"].(string)
+	if !ok || certStr == "" {
+		return false, "", false
+	}
+	leaf, err := public.ParseCertificate([]byte(certStr))
+	if err != nil {
+		logger.Debug("解析上次证书失败，跳过ARI检查:", err)
+		return false, "", false
+	}
+	replacesCertID, err = certificate.MakeARICertID(leaf)
+	if err != nil {
+		logger.Debug("生成证书ARI标识失败，跳过ARI检查:", err)
+		return false, "", false
+	}
+
+	now := time.Now()
+	info, ok := getStoredARI(certRow, now)
+	if ok {
+		logger.Debug("使用缓存的ARI续期窗口")
+	} else {
+		client, err := getClient()
+		if err != nil {
+			logger.Debug("创建ACME客户端失败，跳过ARI检查:", err)
+			return false, "", false
+		}
+		info, err = client.Certificate.GetRenewalInfo(certificate.RenewalInfoRequest{Cert: leaf})
+		if err != nil {
+			if errors.Is(err, api.ErrNoARI) {
+				logger.Debug("当前CA不支持ARI，使用end_day判断是否续期")
+			} else {
+				logger.Debug("查询ARI续期窗口失败，使用end_day判断是否续期:", err)
+			}
+			return false, "", false
+		}
+		retryAfter := info.RetryAfter
+		if retryAfter <= 0 {
+			retryAfter = defaultARIRetryAfter
+		}
+		err = cert.UpdateCertARI(certRow["id"],
+			info.SuggestedWindow.Start.Local().Format(timeLayout),
+			info.SuggestedWindow.End.Local().Format(timeLayout),
+			now.Add(retryAfter).Format(timeLayout),
+		)
+		if err != nil {
+			logger.Debug("保存ARI续期窗口失败:", err)
+		}
+		if info.ExplanationURL != "" {
+			logger.Info("CA提供了续期说明：" + info.ExplanationURL)
+		}
+	}
+
+	logger.Debug(fmt.Sprintf("ARI建议续期窗口：%s ~ %s",
+		info.SuggestedWindow.Start.Local().Format(timeLayout),
+		info.SuggestedWindow.End.Local().Format(timeLayout)))
+	return info.ShouldRenewAt(now, 0) != nil, replacesCertID, true
+}
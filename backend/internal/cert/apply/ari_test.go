@@ -0,0 +1,31 @@
+package apply
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetStoredARI(t *testing.T) {
+	now := time.Now()
+	row := map[string]any{
+		"ari_start":       now.Add(-time.Hour).Format(timeLayout),
+		"ari_end":         now.Add(time.Hour).Format(timeLayout),
+		"ari_retry_after": now.Add(time.Hour).Format(timeLayout),
+	}
+	info, ok := getStoredARI(row, now)
+	if !ok {
+		t.Fatal("expected stored ARI window to be used")
+	}
+	if info.ShouldRenewAt(now.Add(2*time.Hour), 0) == nil {
+		t.Fatal("expected renewal after the window end")
+	}
+
+	row["ari_retry_after"] = now.Add(-time.Minute).Format(timeLayout)
+	if _, ok := getStoredARI(row, now); ok {
+		t.Fatal("expected expired ARI cache to be ignored")
+	}
+
+	if _, ok := getStoredARI(map[string]any{}, now); ok {
+		t.Fatal("expected empty ARI cache to be ignored")
+	}
+}
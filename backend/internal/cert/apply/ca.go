@@ -0,0 +1,274 @@
+package apply
+
+import (
+	"ALLinSSL/backend/public"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// builtinCAs 内置 CA，CA 注册表中不允许使用这些名称
+var builtinCAs = []string{"Let's Encrypt", "letsencrypt", "zerossl", "google", "sslcom", "buypass", "custom"}
+
+func GetCASqlite() (*public.Sqlite, error) {
+	s, err := public.NewSqlite("data/accounts.db", "")
+	if err != nil {
+		return nil, err
+	}
+	s.TableName = "ca"
+	return s, nil
+}
+
+// checkCAInfo 校验 CA 配置，key_types 为逗号分隔的算法（如 RSA2048,EC256），为空表示不限制
+func checkCAInfo(name, dirURL, rootPEM, keyTypes string) error {
+	if name == "" {
+		return fmt.Errorf("CA名称不能为空")
+	}
+	for _, v := range builtinCAs {
+		if strings.EqualFold(v, name) {
+			return fmt.Errorf("CA名称【%s】与内置CA重复", name)
+		}
+	}
+	u, err := url.Parse(dirURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("ACME目录地址格式错误")
+	}
+	if strings.TrimSpace(rootPEM) != "" {
+		if _, err := parseRootBundle(rootPEM); err != nil {
+			return err
+		}
+	}
+	for _, keyType := range splitKeyTypes(keyTypes) {
+		if _, ok := AlgorithmMap[keyType]; !ok {
+			return fmt.Errorf("不支持的证书算法: %s", keyType)
+		}
+	}
+	return nil
+}
+
+func splitKeyTypes(keyTypes string) []string {
+	var result []string
+	for _, v := range strings.Split(keyTypes, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// parseRootBundle 解析 PEM 格式的根证书，可包含多个证书
+func parseRootBundle(rootPEM string) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(rootPEM)) {
+		return nil, fmt.Errorf("根证书解析失败，请检查是否为PEM格式")
+	}
+	return pool, nil
+}
+
+func AddCA(name, dirURL, rootPEM string, eabRequired bool, keyTypes string) error {
+	name, dirURL = strings.TrimSpace(name), strings.TrimSpace(dirURL)
+	if err := checkCAInfo(name, dirURL, rootPEM, keyTypes); err != nil {
+		return err
+	}
+	db, err := GetCASqlite()
+	if err != nil {
+		return fmt.Errorf("failed to get sqlite: %w", err)
+	}
+	defer db.Close()
+	data, err := db.Where("name=?", []any{name}).Select()
+	if err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		return fmt.Errorf("CA【%s】已存在", name)
+	}
+	now := time.Now().Format("2006-01-02 15:04:05")
+	_, err = db.Insert(map[string]any{
+		"name":         name,
+		"dir_url":      dirURL,
+		"root_pem":     strings.TrimSpace(rootPEM),
+		"eab_required": boolToInt(eabRequired),
+		"key_types":    strings.Join(splitKeyTypes(keyTypes), ","),
+		"create_time":  now,
+		"update_time":  now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert ca: %w", err)
+	}
+	return nil
+}
+
+func UpdateCA(id, name, dirURL, rootPEM string, eabRequired bool, keyTypes string) error {
+	name, dirURL = strings.TrimSpace(name), strings.TrimSpace(dirURL)
+	if err := checkCAInfo(name, dirURL, rootPEM, keyTypes); err != nil {
+		return err
+	}
+	db, err := GetCASqlite()
+	if err != nil {
+		return fmt.Errorf("failed to get sqlite: %w", err)
+	}
+	defer db.Close()
+	data, err := db.Where("name=? and id!=?", []any{name, id}).Select()
+	if err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		return fmt.Errorf("CA【%s】已存在", name)
+	}
+	_, err = db.Where("id=?", []any{id}).Update(map[string]any{
+		"name":         name,
+		"dir_url":      dirURL,
+		"root_pem":     strings.TrimSpace(rootPEM),
+		"eab_required": boolToInt(eabRequired),
+		"key_types":    strings.Join(splitKeyTypes(keyTypes), ","),
+		"update_time":  time.Now().Format("2006-01-02 15:04:05"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update ca: %w", err)
+	}
+	return nil
+}
+
+func DelCA(id string) error {
+	db, err := GetCASqlite()
+	if err != nil {
+		return fmt.Errorf("failed to get sqlite: %w", err)
+	}
+	defer db.Close()
+	_, err = db.Where("id=?", []any{id}).Delete()
+	if err != nil {
+		return fmt.Errorf("failed to delete ca: %w", err)
+	}
+	return nil
+}
+
+func GetCARegistryList(search string, p, limit int64) ([]map[string]any, int, error) {
+	db, err := GetCASqlite()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get sqlite: %w", err)
+	}
+	defer db.Close()
+	whereSql := "1=1"
+	var whereArgs []any
+	limits := []int64{0, 100}
+	if p >= 0 && limit >= 0 {
+		limits = []int64{0, limit}
+		if p > 1 {
+			limits[0] = (p - 1) * limit
+			limits[1] = limit
+		}
+	}
+	if search != "" {
+		whereSql += " and (name like ? or dir_url like ?)"
+		whereArgs = append(whereArgs, "%"+search+"%", "%"+search+"%")
+	}
+	count, err := db.Where(whereSql, whereArgs).Count()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get ca list: %w", err)
+	}
+	data, err := db.Where(whereSql, whereArgs).Limit(limits).Select()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get ca list: %w", err)
+	}
+	return data, int(count), nil
+}
+
+// getRegistryCANames 获取 CA 注册表中全部 CA 的名称
+func getRegistryCANames() ([]map[string]any, error) {
+	db, err := GetCASqlite()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sqlite: %w", err)
+	}
+	defer db.Close()
+	data, err := db.Field([]string{"name"}).Select()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ca list: %w", err)
+	}
+	return data, nil
+}
+
+// GetRegistryCA 从 CA 注册表中获取 CA，不存在时返回 nil
+func GetRegistryCA(name string) (map[string]any, error) {
+	db, err := GetCASqlite()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	data, err := db.Where("name=?", []any{name}).Select()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return data[0], nil
+}
+
+// checkCAKeyType 校验证书算法是否在 CA 支持的范围内
+func checkCAKeyType(caInfo map[string]any, algorithm string) error {
+	keyTypes, _ := caInfo["key_types"].(string)
+	supported := splitKeyTypes(keyTypes)
+	if len(supported) == 0 {
+		return nil
+	}
+	for _, v := range supported {
+		if v == algorithm {
+			return nil
+		}
+	}
+	return fmt.Errorf("CA【%v】不支持证书算法 %s，支持的算法：%s", caInfo["name"], algorithm, keyTypes)
+}
+
+// caHTTPClient 为使用私有根证书的 CA 构建 HTTP 客户端，在系统根证书的基础上信任 CA 的根证书，
+// 并保留原客户端的代理等设置
+func caHTTPClient(caInfo map[string]any, httpClient *http.Client) (*http.Client, error) {
+	rootPEM, _ := caInfo["root_pem"].(string)
+	if strings.TrimSpace(rootPEM) == "" {
+		return httpClient, nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM([]byte(rootPEM)) {
+		return nil, fmt.Errorf("CA【%v】根证书解析失败", caInfo["name"])
+	}
+
+	var transport *http.Transport
+	var ctxTransport *contextTransport
+	client := &http.Client{Timeout: 30 * time.Second}
+	if httpClient != nil {
+		*client = *httpClient
+		base := httpClient.Transport
+		// 绑定了工作流 context 的客户端，替换底层 Transport 后保留绑定
+		if t, ok := base.(*contextTransport); ok {
+			ctxTransport, base = t, t.base
+		}
+		if t, ok := base.(*http.Transport); ok {
+			transport = t.Clone()
+		}
+	}
+	if transport == nil {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.RootCAs = pool
+	client.Transport = transport
+	if ctxTransport != nil {
+		client.Transport = &contextTransport{ctx: ctxTransport.ctx, base: transport}
+	}
+	return client, nil
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,49 @@
+package apply
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCAHTTPClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	rootPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	if _, err := (&http.Client{}).Get(server.URL); err == nil {
+		t.Fatal("expected private root to be untrusted by default")
+	}
+	client, err := caHTTPClient(map[string]any{"name": "pebble", "root_pem": rootPEM}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if _, err := caHTTPClient(map[string]any{"name": "pebble", "root_pem": "invalid"}, nil); err == nil {
+		t.Fatal("expected invalid root bundle to fail")
+	}
+}
+
+func TestCheckCAInfo(t *testing.T) {
+	if err := checkCAInfo("step-ca", "https://ca.internal/acme/acme/directory", "", "RSA2048, EC256"); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkCAInfo("zerossl", "https://ca.internal/directory", "", ""); err == nil {
+		t.Fatal("expected builtin CA name to be rejected")
+	}
+	if err := checkCAInfo("step-ca", "ca.internal/directory", "", ""); err == nil {
+		t.Fatal("expected invalid directory URL to be rejected")
+	}
+	if err := checkCAInfo("step-ca", "https://ca.internal/directory", "", "ED25519"); err == nil {
+		t.Fatal("expected unsupported key type to be rejected")
+	}
+	if err := checkCAKeyType(map[string]any{"name": "step-ca", "key_types": "EC256"}, "RSA2048"); err == nil {
+		t.Fatal("expected key type outside the CA's list to be rejected")
+	}
+}
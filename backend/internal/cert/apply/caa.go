@@ -0,0 +1,180 @@
+package apply
+
+import (
+	"ALLinSSL/backend/public"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/miekg/dns"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// caCAAIdentities 内置 CA 在 CAA 记录中使用的签发者标识
+var caCAAIdentities = map[string][]string{
+	"Let's Encrypt": {"letsencrypt.org"},
+	"zerossl":       {"sectigo.com", "zerossl.com"},
+	"google":        {"pki.goog"},
+	"sslcom":        {"ssl.com"},
+	"buypass":       {"buypass.com", "buypass.no"},
+}
+
+var errCAAForbidden = errors.New("CAA记录不允许该CA签发")
+
+// caaRecordSet 某个名称上的 CAA 记录，Name 为实际查到记录的名称（树遍历中的祖先）
+type caaRecordSet struct {
+	Name    string
+	Records []*dns.CAA
+}
+
+// getCAAIdentities 获取 CA 的签发者标识，注册表中的 CA 从 ACME 目录的 meta.caaIdentities 读取
+func getCAAIdentities(ca string, httpClient *http.Client) ([]string, error) {
+	if ids, ok := caCAAIdentities[ca]; ok {
+		return ids, nil
+	}
+	caInfo, err := GetRegistryCA(ca)
+	if err != nil || caInfo == nil {
+		return nil, err
+	}
+	client, err := caHTTPClient(caInfo, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	dirURL, _ := caInfo["dir_url"].(string)
+	resp, err := client.Get(dirURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("获取ACME目录失败，状态码：%d", resp.StatusCode)
+	}
+	var dir struct {
+		Meta struct {
+			CAAIdentities []string `json:"caaIdentities"`
+		} `json:"meta"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&dir); err != nil {
+		return nil, fmt.Errorf("解析ACME目录失败: %v", err)
+	}
+	return dir.Meta.CAAIdentities, nil
+}
+
+// lookupCAA 按 RFC 8659 的树遍历查找名称的相关 CAA 记录：从名称本身开始逐级向上查询父域名，
+// 返回第一个非空的记录集，全部为空时返回 nil
+func lookupCAA(name string, nameServers []string, cache map[string][]*dns.CAA) (*caaRecordSet, error) {
+	labels := dns.SplitDomainName(strings.ToLower(strings.TrimPrefix(name, "*.")))
+	for i := range labels {
+		current := dns.Fqdn(strings.Join(labels[i:], "."))
+		records, ok := cache[current]
+		if !ok {
+			in, err := queryRecursive(current, dns.TypeCAA, nameServers)
+			if err != nil {
+				return nil, err
+			}
+			if in.Rcode != dns.RcodeSuccess && in.Rcode != dns.RcodeNameError {
+				return nil, fmt.Errorf("查询 %s 的CAA记录失败：%s", current, dns.RcodeToString[in.Rcode])
+			}
+			// 递归服务器会跟随 CNAME，应答中只取 CAA 记录
+			for _, rr := range in.Answer {
+				if caa, ok := rr.(*dns.CAA); ok {
+					records = append(records, caa)
+				}
+			}
+			cache[current] = records
+		}
+		if len(records) > 0 {
+			return &caaRecordSet{Name: current, Records: records}, nil
+		}
+	}
+	return nil, nil
+}
+
+// caaIssuer 取 issue/issuewild 记录值中的签发者域名，忽略参数部分
+func caaIssuer(value string) string {
+	issuer, _, _ := strings.Cut(value, ";")
+	return strings.ToLower(strings.TrimSpace(issuer))
+}
+
+// checkCAASet 判断记录集是否允许标识之一签发 name 的证书，不允许时返回拦截的记录
+func checkCAASet(name string, set *caaRecordSet, identities []string) (bool, string) {
+	wildcard := strings.HasPrefix(name, "*.")
+	var issue, issueWild []*dns.CAA
+	for _, r := range set.Records {
+		switch strings.ToLower(r.Tag) {
+		case "issue":
+			issue = append(issue, r)
+		case "issuewild":
+			issueWild = append(issueWild, r)
+		case "iodef", "contactemail", "contactphone", "issuemail", "issuevmc":
+		default:
+			// 带关键标志的未知属性，CA 不得签发
+			if r.Flag&128 != 0 {
+				return false, r.String()
+			}
+		}
+	}
+	relevant := issue
+	if wildcard && len(issueWild) > 0 {
+		relevant = issueWild
+	}
+	if len(relevant) == 0 {
+		return true, ""
+	}
+	for _, r := range relevant {
+		issuer := caaIssuer(r.Value)
+		for _, id := range identities {
+			if issuer != "" && issuer == strings.ToLower(id) {
+				return true, ""
+			}
+		}
+	}
+	var blocking []string
+	for _, r := range relevant {
+		blocking = append(blocking, r.String())
+	}
+	return false, strings.Join(blocking, "；")
+}
+
+// checkCAA 下单前检查每个域名的 CAA 记录是否允许当前 CA 签发，IP 标识不检查。
+// 无法确定 CA 的签发者标识或查询失败时只记录日志，不阻止申请
+func checkCAA(domains []string, ca string, nameServers []string, httpClient *http.Client, logger *public.Logger) error {
+	identities, err := getCAAIdentities(ca, httpClient)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("获取CA【%s】的CAA标识失败，跳过CAA检查: %v", ca, err))
+		return nil
+	}
+	if len(identities) == 0 {
+		logger.Debug(fmt.Sprintf("CA【%s】未提供CAA标识，跳过CAA检查", ca))
+		return nil
+	}
+	cache := map[string][]*dns.CAA{}
+	for _, domain := range domains {
+		if net.ParseIP(strings.Trim(domain, "[]")) != nil {
+			continue
+		}
+		set, err := lookupCAA(domain, nameServers, cache)
+		if err != nil {
+			logger.Debug(fmt.Sprintf("查询 %s 的CAA记录失败，跳过检查: %v", domain, err))
+			continue
+		}
+		if set == nil {
+			continue
+		}
+		if ok, blocking := checkCAASet(domain, set, identities); !ok {
+			tag := "issue"
+			if strings.HasPrefix(domain, "*.") {
+				tag = "issuewild"
+			}
+			return fmt.Errorf("%w：域名 %s 受 %s 的CAA记录限制（%s），请添加记录 %s 0 %s \"%s\" 后重试",
+				errCAAForbidden, domain, dns01.UnFqdn(set.Name), blocking, dns01.UnFqdn(set.Name), tag, identities[0])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package apply
+
+import (
+	"errors"
+	"github.com/miekg/dns"
+	"testing"
+)
+
+func TestLookupAndCheckCAA(t *testing.T) {
+	addr := startTestDNS(t, map[string][]dns.RR{
+		"example.com.": {
+			mustRR(t, `example.com. 60 IN CAA 0 issue "letsencrypt.org; validationmethods=dns-01"`),
+			mustRR(t, `example.com. 60 IN CAA 0 issuewild ";"`),
+			mustRR(t, `example.com. 60 IN CAA 0 iodef "mailto:admin@example.com"`),
+		},
+		"sub.example.com.":   {mustRR(t, `sub.example.com. 60 IN CAA 0 issue "pki.goog"`)},
+		"crit.example.com.":  {mustRR(t, `crit.example.com. 60 IN CAA 128 tbs "unknown"`)},
+		"iodef.example.net.": {mustRR(t, `iodef.example.net. 60 IN CAA 0 iodef "mailto:a@example.net"`)},
+	})
+	servers := []string{addr}
+	cache := map[string][]*dns.CAA{}
+
+	// 树遍历：www.example.com 没有记录，使用 example.com 的记录
+	set, err := lookupCAA("www.example.com", servers, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set == nil || set.Name != "example.com." || len(set.Records) != 3 {
+		t.Fatalf("unexpected record set: %+v", set)
+	}
+	cases := []struct {
+		name       string
+		identities []string
+		want       bool
+	}{
+		{"www.example.com", []string{"letsencrypt.org"}, true},
+		{"www.example.com", []string{"pki.goog"}, false},
+		{"*.example.com", []string{"letsencrypt.org"}, false},
+		{"a.sub.example.com", []string{"pki.goog"}, true},
+		{"a.sub.example.com", []string{"letsencrypt.org"}, false},
+		{"crit.example.com", []string{"letsencrypt.org"}, false},
+		{"iodef.example.net", []string{"letsencrypt.org"}, true},
+	}
+	for _, c := range cases {
+		set, err := lookupCAA(c.name, servers, cache)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if set == nil {
+			t.Fatalf("%s: expected record set", c.name)
+		}
+		if ok, _ := checkCAASet(c.name, set, c.identities); ok != c.want {
+			t.Errorf("checkCAASet(%s, %v) = %v, want %v", c.name, c.identities, ok, c.want)
+		}
+	}
+
+	if set, err = lookupCAA("none.example.org", servers, cache); err != nil || set != nil {
+		t.Fatalf("expected no CAA records: %+v %v", set, err)
+	}
+
+	err = checkCAA([]string{"example.com", "*.example.com"}, "Let's Encrypt", servers, nil, nil)
+	if !errors.Is(err, errCAAForbidden) {
+		t.Fatalf("expected CAA error, got %v", err)
+	}
+}
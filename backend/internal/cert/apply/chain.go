@@ -0,0 +1,34 @@
+package apply
+
+import (
+	"github.com/go-acme/lego/v4/certcrypto"
+	"strings"
+)
+
+// chainIssuers 返回证书链中各级签发者的名称，从中间证书到根证书，如 ["R10", "ISRG Root X1"]
+func chainIssuers(issuerCert []byte) []string {
+	certs, err := certcrypto.ParsePEMBundle(issuerCert)
+	if err != nil || len(certs) == 0 {
+		return nil
+	}
+	var names []string
+	for _, c := range certs {
+		names = append(names, c.Subject.CommonName)
+	}
+	// 链中不含根证书时补充顶级证书的签发者
+	top := certs[len(certs)-1]
+	if top.Issuer.CommonName != top.Subject.CommonName {
+		names = append(names, top.Issuer.CommonName)
+	}
+	return names
+}
+
+// matchPreferredChain 判断证书链是否为首选链，与 lego 一致按顶级签发者的通用名匹配
+func matchPreferredChain(issuers []string, preferredChain string) bool {
+	return len(issuers) > 0 && issuers[len(issuers)-1] == preferredChain
+}
+
+// joinChainIssuers 转换为保存到证书记录中的格式
+func joinChainIssuers(issuers []string) string {
+	return strings.Join(issuers, ",")
+}
@@ -0,0 +1,49 @@
+package apply
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestChainIssuers(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newCert := func(subject, issuer string, serial int64) []byte {
+		tpl := &x509.Certificate{
+			SerialNumber:          big.NewInt(serial),
+			Subject:               pkix.Name{CommonName: subject},
+			NotBefore:             time.Now(),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+		}
+		parent := &x509.Certificate{Subject: pkix.Name{CommonName: issuer}}
+		der, err := x509.CreateCertificate(rand.Reader, tpl, parent, &key.PublicKey, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+
+	bundle := append(newCert("R10", "ISRG Root X1", 1), newCert("ISRG Root X1", "DST Root CA X3", 2)...)
+	issuers := chainIssuers(bundle)
+	if joinChainIssuers(issuers) != "R10,ISRG Root X1,DST Root CA X3" {
+		t.Fatalf("unexpected chain issuers: %v", issuers)
+	}
+	if !matchPreferredChain(issuers, "DST Root CA X3") || matchPreferredChain(issuers, "ISRG Root X1") {
+		t.Fatal("expected preferred chain to match the top issuer only")
+	}
+
+	if issuers := chainIssuers(newCert("R10", "ISRG Root X1", 3)); joinChainIssuers(issuers) != "R10,ISRG Root X1" {
+		t.Fatalf("unexpected chain issuers: %v", issuers)
+	}
+}
@@ -0,0 +1,41 @@
+package apply
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// contextTransport 为请求绑定执行的 context，取消工作流时中断进行中的请求，后续请求直接失败
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.ctx.Err(); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
+
+// ContextHTTPClient 返回绑定 ctx 的 HTTP 客户端，保留原客户端的代理、超时等设置；
+// ctx 不可取消时原样返回 httpClient（可能为 nil，由调用方使用默认客户端）
+func ContextHTTPClient(ctx context.Context, httpClient *http.Client) *http.Client {
+	if ctx == nil || ctx.Done() == nil {
+		return httpClient
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	var base http.RoundTripper = http.DefaultTransport
+	if httpClient != nil {
+		*client = *httpClient
+		if httpClient.Transport != nil {
+			base = httpClient.Transport
+		}
+	}
+	if t, ok := base.(*contextTransport); ok {
+		base = t.base
+	}
+	client.Transport = &contextTransport{ctx: ctx, base: base}
+	return client
+}
@@ -0,0 +1,59 @@
+package apply
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestContextHTTPClient(t *testing.T) {
+	block := make(chan struct{})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(block)
+	rootPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	if ContextHTTPClient(context.Background(), nil) != nil {
+		t.Fatal("expected background context to keep the original client")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := ContextHTTPClient(ctx, &http.Client{Timeout: time.Minute})
+	// 替换根证书后仍绑定 context
+	client, err := caHTTPClient(map[string]any{"root_pem": rootPEM}, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := client.Transport.(*contextTransport); !ok {
+		t.Fatal("expected context binding to survive caHTTPClient")
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Get(server.URL)
+		done <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not interrupted by cancellation")
+	}
+	// 取消后的请求直接失败
+	if _, err = client.Get(server.URL); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+}
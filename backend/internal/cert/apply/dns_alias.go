@@ -0,0 +1,77 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"net"
+	"strings"
+	"time"
+)
+
+// lookupCNAME 通过指定的 DNS 服务器查询 CNAME，未设置 CNAME 时返回空字符串
+func lookupCNAME(fqdn string, nameServers []string) (string, error) {
+	var lastErr error
+	for _, ns := range nameServers {
+		ns := ns
+		resolver := &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+				d := net.Dialer{Timeout: 5 * time.Second}
+				return d.DialContext(ctx, network, ns)
+			},
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		cname, err := resolver.LookupCNAME(ctx, fqdn)
+		cancel()
+		if err != nil {
+			if dnsErr, ok := err.(*net.DNSError); ok && dnsErr.IsNotFound {
+				return "", nil
+			}
+			lastErr = err
+			continue
+		}
+		if strings.EqualFold(dns01.UnFqdn(cname), dns01.UnFqdn(fqdn)) {
+			return "", nil
+		}
+		return dns01.UnFqdn(cname), nil
+	}
+	return "", lastErr
+}
+
+// aliasTarget 建议在验证域中使用的 CNAME 目标记录
+func aliasTarget(domain, aliasZone string) string {
+	return "_acme-challenge." + domain + "." + aliasZone
+}
+
+// checkAliasCNAME 检查每个域名的 _acme-challenge 记录是否已通过 CNAME 委派到验证域，
+// 未设置时返回需要添加的记录，便于用户直接按提示配置
+func checkAliasCNAME(domains []string, aliasZone string, nameServers []string) error {
+	aliasZone = strings.ToLower(dns01.UnFqdn(aliasZone))
+	var missing []string
+	seen := make(map[string]bool)
+	for _, domain := range domains {
+		domain = strings.TrimPrefix(strings.ToLower(domain), "*.")
+		if seen[domain] {
+			continue
+		}
+		seen[domain] = true
+		fqdn := "_acme-challenge." + domain
+		cname, err := lookupCNAME(dns01.ToFqdn(fqdn), nameServers)
+		if err != nil {
+			return fmt.Errorf("查询 %s 的 CNAME 记录失败: %v", fqdn, err)
+		}
+		if cname == "" {
+			missing = append(missing, fmt.Sprintf("%s CNAME %s", fqdn, aliasTarget(domain, aliasZone)))
+			continue
+		}
+		cname = strings.ToLower(cname)
+		if cname != aliasZone && !strings.HasSuffix(cname, "."+aliasZone) {
+			missing = append(missing, fmt.Sprintf("%s 当前指向 %s，不在验证域 %s 内，请修改为 CNAME %s", fqdn, cname, aliasZone, aliasTarget(domain, aliasZone)))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("DNS别名模式需要先添加以下CNAME记录：\n%s", strings.Join(missing, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package apply
+
+import (
+	"github.com/miekg/dns"
+	"strings"
+	"testing"
+)
+
+func TestCheckAliasCNAME(t *testing.T) {
+	addr := startTestDNS(t, map[string][]dns.RR{
+		"_acme-challenge.ok.example.com.":    {mustRR(t, "_acme-challenge.ok.example.com. 60 IN CNAME _acme-challenge.ok.example.com.validation.net.")},
+		"_acme-challenge.wrong.example.com.": {mustRR(t, "_acme-challenge.wrong.example.com. 60 IN CNAME _acme-challenge.wrong.example.com.other.net.")},
+	})
+	servers := []string{addr}
+
+	cname, err := lookupCNAME("_acme-challenge.ok.example.com.", servers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cname != "_acme-challenge.ok.example.com.validation.net" {
+		t.Fatalf("unexpected cname: %q", cname)
+	}
+	if cname, err = lookupCNAME("_acme-challenge.missing.example.com.", servers); err != nil || cname != "" {
+		t.Fatalf("expected no cname, got %q %v", cname, err)
+	}
+
+	// 正确委派（含通配符域名）时通过
+	if err = checkAliasCNAME([]string{"ok.example.com", "*.ok.example.com"}, "validation.net.", servers); err != nil {
+		t.Fatalf("expected delegation to pass: %v", err)
+	}
+
+	// 未设置 CNAME：提示需要添加的记录
+	err = checkAliasCNAME([]string{"missing.example.com"}, "validation.net", servers)
+	if err == nil || !strings.Contains(err.Error(), "_acme-challenge.missing.example.com CNAME _acme-challenge.missing.example.com.validation.net") {
+		t.Fatalf("expected missing CNAME error, got %v", err)
+	}
+
+	// CNAME 指向其他域：提示当前指向及应修改的目标
+	err = checkAliasCNAME([]string{"wrong.example.com"}, "validation.net", servers)
+	if err == nil || !strings.Contains(err.Error(), "当前指向 _acme-challenge.wrong.example.com.other.net") {
+		t.Fatalf("expected wrong zone error, got %v", err)
+	}
+}
@@ -0,0 +1,316 @@
+package apply
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/miekg/dns"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// diagnosePollInterval 测试记录生效情况的检查间隔
+var diagnosePollInterval = 5 * time.Second
+
+// diagnoseMaxWait 诊断在 HTTP 请求中同步执行，等待测试记录生效的最长时间
+const diagnoseMaxWait = 600 * time.Second
+
+// NSResult 单个权威 DNS 服务器上的 TXT 记录
+type NSResult struct {
+	Nameserver string   `json:"nameserver"`
+	Found      bool     `json:"found"`
+	Values     []string `json:"values"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// PropagationRound 一轮检查的结果，Elapsed 为写入测试记录后经过的秒数
+type PropagationRound struct {
+	Elapsed int        `json:"elapsed"`
+	Results []NSResult `json:"results"`
+}
+
+// DNSDiagnosis DNS-01 验证诊断结果
+type DNSDiagnosis struct {
+	Domain        string             `json:"domain"`
+	FQDN          string             `json:"fqdn"`
+	CNAMEChain    []string           `json:"cname_chain"`
+	EffectiveFQDN string             `json:"effective_fqdn"`
+	Zone          string             `json:"zone"`
+	Nameservers   []string           `json:"nameservers"`
+	Existing      []NSResult         `json:"existing"`
+	TestValue     string             `json:"test_value,omitempty"`
+	Propagation   []PropagationRound `json:"propagation,omitempty"`
+	Propagated    bool               `json:"propagated"`
+	Messages      []string           `json:"messages"`
+}
+
+// authNS 权威 DNS 服务器及其地址
+type authNS struct {
+	Name string
+	Addr string
+}
+
+// dnsExchange 向指定服务器发送查询，UDP 响应被截断时改用 TCP
+func dnsExchange(m *dns.Msg, server string) (*dns.Msg, error) {
+	client := &dns.Client{Timeout: 5 * time.Second}
+	in, _, err := client.Exchange(m, server)
+	if err == nil && in.Truncated {
+		client.Net = "tcp"
+		in, _, err = client.Exchange(m, server)
+	}
+	return in, err
+}
+
+// queryRecursive 依次使用递归 DNS 服务器查询，返回第一个成功的响应
+func queryRecursive(name string, qtype uint16, nameServers []string) (*dns.Msg, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(name), qtype)
+	var lastErr error
+	for _, ns := range nameServers {
+		in, err := dnsExchange(m, ns)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return in, nil
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("没有可用的DNS服务器")
+	}
+	return nil, lastErr
+}
+
+// followCNAME 逐级跟随 CNAME，返回经过的全部名称（不含起始名称）
+func followCNAME(fqdn string, nameServers []string) ([]string, error) {
+	var chain []string
+	name := dns.Fqdn(fqdn)
+	for i := 0; i < 10; i++ {
+		in, err := queryRecursive(name, dns.TypeCNAME, nameServers)
+		if err != nil {
+			return chain, err
+		}
+		var target string
+		for _, rr := range in.Answer {
+			if cname, ok := rr.(*dns.CNAME); ok && strings.EqualFold(cname.Hdr.Name, name) {
+				target = cname.Target
+				break
+			}
+		}
+		if target == "" {
+			return chain, nil
+		}
+		for _, n := range chain {
+			if strings.EqualFold(n, target) {
+				return chain, fmt.Errorf("CNAME 存在循环：%s", target)
+			}
+		}
+		chain = append(chain, target)
+		name = target
+	}
+	return chain, fmt.Errorf("CNAME 层级过多")
+}
+
+// findAuthoritativeNS 查找名称所在的区域及其权威 DNS 服务器地址
+func findAuthoritativeNS(fqdn string, nameServers []string) (string, []authNS, error) {
+	zone, err := dns01.FindZoneByFqdnCustom(fqdn, nameServers)
+	if err != nil {
+		return "", nil, fmt.Errorf("查找 %s 所在的区域失败: %v", fqdn, err)
+	}
+	in, err := queryRecursive(zone, dns.TypeNS, nameServers)
+	if err != nil {
+		return zone, nil, fmt.Errorf("查询 %s 的NS记录失败: %v", zone, err)
+	}
+	var result []authNS
+	for _, rr := range in.Answer {
+		ns, ok := rr.(*dns.NS)
+		if !ok {
+			continue
+		}
+		for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+			resp, err := queryRecursive(ns.Ns, qtype, nameServers)
+			if err != nil {
+				continue
+			}
+			for _, a := range resp.Answer {
+				switch v := a.(type) {
+				case *dns.A:
+					result = append(result, authNS{Name: ns.Ns, Addr: net.JoinHostPort(v.A.String(), "53")})
+				case *dns.AAAA:
+					result = append(result, authNS{Name: ns.Ns, Addr: net.JoinHostPort(v.AAAA.String(), "53")})
+				}
+			}
+		}
+	}
+	if len(result) == 0 {
+		return zone, nil, fmt.Errorf("未找到 %s 的权威DNS服务器", zone)
+	}
+	return zone, result, nil
+}
+
+// queryTXT 直接向权威服务器查询 TXT 记录，不使用递归
+func queryTXT(fqdn string, server string) ([]string, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(fqdn), dns.TypeTXT)
+	m.RecursionDesired = false
+	in, err := dnsExchange(m, server)
+	if err != nil {
+		return nil, err
+	}
+	if in.Rcode != dns.RcodeSuccess && in.Rcode != dns.RcodeNameError {
+		return nil, fmt.Errorf("DNS服务器返回 %s", dns.RcodeToString[in.Rcode])
+	}
+	var values []string
+	for _, rr := range in.Answer {
+		if txt, ok := rr.(*dns.TXT); ok {
+			values = append(values, strings.Join(txt.Txt, ""))
+		}
+	}
+	return values, nil
+}
+
+// checkNameservers 在每个权威服务器上检查 TXT 记录，value 不为空时检查是否包含该值
+func checkNameservers(fqdn, value string, servers []authNS) ([]NSResult, bool) {
+	all := true
+	results := make([]NSResult, 0, len(servers))
+	for _, ns := range servers {
+		r := NSResult{Nameserver: fmt.Sprintf("%s(%s)", dns01.UnFqdn(ns.Name), ns.Addr)}
+		values, err := queryTXT(fqdn, ns.Addr)
+		if err != nil {
+			r.Error = err.Error()
+		}
+		r.Values = values
+		for _, v := range values {
+			if value == "" || v == value {
+				r.Found = true
+				break
+			}
+		}
+		if !r.Found {
+			all = false
+		}
+		results = append(results, r)
+	}
+	return results, all
+}
+
+// DiagnoseDNS 诊断域名的 DNS-01 验证：查找权威服务器、跟随 _acme-challenge 的 CNAME，
+// publish 为 true 时通过授权写入测试 TXT 记录，按轮记录各权威服务器的生效情况，最后删除测试记录
+//
+//	cfg 中的 name_server、max_wait、close_cname 与申请节点含义相同，max_wait 最长 600 秒；
+//	ctx 取消（如客户端断开）时停止等待并删除测试记录
+func DiagnoseDNS(ctx context.Context, domain, providerName, providerID string, cfg map[string]any, publish bool) (*DNSDiagnosis, error) {
+	domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "*.")
+	if domain == "" {
+		return nil, fmt.Errorf("域名不能为空")
+	}
+	nameServers, err := parseNameServers(cfg)
+	if err != nil {
+		return nil, err
+	}
+	closeCname, err := parseBoolCfg(cfg, "close_cname")
+	if err != nil {
+		return nil, err
+	}
+	maxWait := parseMaxWait(cfg)
+	if maxWait > diagnoseMaxWait {
+		maxWait = diagnoseMaxWait
+	}
+	buf := make([]byte, 16)
+	if _, err = rand.Read(buf); err != nil {
+		return nil, err
+	}
+	keyAuth := "allinssl-diagnose." + hex.EncodeToString(buf)
+	token := hex.EncodeToString(buf[:8])
+
+	// 与申请节点一致地设置是否跟随 CNAME，provider 会把记录写入 GetChallengeInfo 给出的名称
+	os.Setenv("LEGO_DISABLE_CNAME_SUPPORT", strconv.FormatBool(closeCname))
+	info := dns01.GetChallengeInfo(domain, keyAuth)
+	result := &DNSDiagnosis{
+		Domain:        domain,
+		FQDN:          info.FQDN,
+		EffectiveFQDN: info.EffectiveFQDN,
+		Messages:      []string{},
+	}
+
+	if !closeCname {
+		chain, err := followCNAME(result.FQDN, nameServers)
+		result.CNAMEChain = chain
+		if err != nil {
+			result.Messages = append(result.Messages, "跟随CNAME失败："+err.Error())
+		} else if len(chain) > 0 && !strings.EqualFold(chain[len(chain)-1], result.EffectiveFQDN) {
+			result.Messages = append(result.Messages, fmt.Sprintf("指定DNS服务器解析的CNAME结果 %s 与系统DNS不一致，验证记录将写入 %s", chain[len(chain)-1], result.EffectiveFQDN))
+		}
+	}
+	if !strings.EqualFold(result.EffectiveFQDN, result.FQDN) {
+		result.Messages = append(result.Messages, fmt.Sprintf("%s 通过CNAME指向 %s，验证记录需写入该名称所在的区域", result.FQDN, result.EffectiveFQDN))
+	}
+
+	zone, servers, err := findAuthoritativeNS(result.EffectiveFQDN, nameServers)
+	result.Zone = zone
+	for _, ns := range servers {
+		result.Nameservers = append(result.Nameservers, fmt.Sprintf("%s(%s)", dns01.UnFqdn(ns.Name), ns.Addr))
+	}
+	if err != nil {
+		result.Messages = append(result.Messages, err.Error())
+		return result, nil
+	}
+	result.Existing, _ = checkNameservers(result.EffectiveFQDN, "", servers)
+
+	if !publish {
+		return result, nil
+	}
+	if providerID == "" {
+		return nil, fmt.Errorf("写入测试记录需要指定DNS授权")
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	provider, err := GetDNSProviderByAccess(providerName, providerID, nil, maxWait)
+	if err != nil {
+		return nil, fmt.Errorf("创建 DNS provider 失败: %v", err)
+	}
+	result.TestValue = info.Value
+
+	if err = provider.Present(domain, token, keyAuth); err != nil {
+		result.Messages = append(result.Messages, "写入测试记录失败："+err.Error())
+		return result, nil
+	}
+	defer func() {
+		if err := provider.CleanUp(domain, token, keyAuth); err != nil {
+			result.Messages = append(result.Messages, "删除测试记录失败，请手动删除："+err.Error())
+		}
+	}()
+
+	waitPropagation(ctx, result, servers, maxWait)
+	return result, nil
+}
+
+// waitPropagation 按轮检查测试记录在各权威服务器的生效情况，直到全部生效、超过 maxWait 或 ctx 取消
+func waitPropagation(ctx context.Context, result *DNSDiagnosis, servers []authNS, maxWait time.Duration) {
+	start := time.Now()
+	for {
+		results, all := checkNameservers(result.EffectiveFQDN, result.TestValue, servers)
+		elapsed := time.Since(start)
+		result.Propagation = append(result.Propagation, PropagationRound{Elapsed: int(elapsed.Seconds()), Results: results})
+		if all {
+			result.Propagated = true
+			result.Messages = append(result.Messages, fmt.Sprintf("测试记录在 %d 秒内已在全部权威DNS服务器生效", int(elapsed.Seconds())))
+			return
+		}
+		if elapsed >= maxWait {
+			result.Messages = append(result.Messages, fmt.Sprintf("等待 %d 秒后测试记录仍未在全部权威DNS服务器生效，请检查授权对应的域名或适当增加 max_wait", int(maxWait.Seconds())))
+			return
+		}
+		select {
+		case <-ctx.Done():
+			result.Messages = append(result.Messages, "诊断已取消，停止等待测试记录生效")
+			return
+		case <-time.After(diagnosePollInterval):
+		}
+	}
+}
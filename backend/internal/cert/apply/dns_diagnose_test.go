@@ -0,0 +1,126 @@
+package apply
+
+import (
+	"context"
+	"github.com/miekg/dns"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func startTestDNS(t *testing.T, records map[string][]dns.RR) string {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &dns.Server{PacketConn: pc, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		// 模拟递归解析器，否则 Go 的解析器会把空应答视为 lame referral
+		m.RecursionAvailable = true
+		q := r.Question[0]
+		for _, rr := range records[dns.CanonicalName(q.Name)] {
+			if rr.Header().Rrtype == q.Qtype {
+				m.Answer = append(m.Answer, rr)
+			}
+		}
+		w.WriteMsg(m)
+	})}
+	go server.ActivateAndServe()
+	t.Cleanup(func() { server.Shutdown() })
+	return pc.LocalAddr().String()
+}
+
+func mustRR(t *testing.T, s string) dns.RR {
+	rr, err := dns.NewRR(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rr
+}
+
+func TestFollowCNAMEAndQueryTXT(t *testing.T) {
+	addr := startTestDNS(t, map[string][]dns.RR{
+		"_acme-challenge.example.com.": {mustRR(t, "_acme-challenge.example.com. 60 IN CNAME a.delegate.net.")},
+		"a.delegate.net.":              {mustRR(t, "a.delegate.net. 60 IN CNAME b.delegate.net.")},
+		"b.delegate.net.": {
+			mustRR(t, `b.delegate.net. 60 IN TXT "value-1"`),
+			mustRR(t, `b.delegate.net. 60 IN TXT "value-" "2"`),
+		},
+		"loop.example.com.":  {mustRR(t, "loop.example.com. 60 IN CNAME loop2.example.com.")},
+		"loop2.example.com.": {mustRR(t, "loop2.example.com. 60 IN CNAME loop.example.com.")},
+	})
+	servers := []string{addr}
+
+	chain, err := followCNAME("_acme-challenge.example.com", servers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(chain, []string{"a.delegate.net.", "b.delegate.net."}) {
+		t.Fatalf("unexpected chain: %v", chain)
+	}
+	if _, err = followCNAME("loop.example.com.", servers); err == nil {
+		t.Fatal("expected CNAME loop to fail")
+	}
+
+	values, err := queryTXT("b.delegate.net.", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(values, []string{"value-1", "value-2"}) {
+		t.Fatalf("unexpected values: %v", values)
+	}
+
+	results, all := checkNameservers("b.delegate.net.", "value-2", []authNS{{Name: "ns1.delegate.net.", Addr: addr}})
+	if !all || !results[0].Found {
+		t.Fatalf("expected value to be found: %+v", results)
+	}
+	if _, all = checkNameservers("b.delegate.net.", "value-3", []authNS{{Name: "ns1.delegate.net.", Addr: addr}}); all {
+		t.Fatal("expected missing value")
+	}
+}
+
+func TestWaitPropagationCancel(t *testing.T) {
+	addr := startTestDNS(t, map[string][]dns.RR{})
+	servers := []authNS{{Name: "ns1.example.com.", Addr: addr}}
+	old := diagnosePollInterval
+	diagnosePollInterval = 10 * time.Millisecond
+	defer func() { diagnosePollInterval = old }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	result := &DNSDiagnosis{EffectiveFQDN: "_acme-challenge.example.com.", TestValue: "missing"}
+	start := time.Now()
+	waitPropagation(ctx, result, servers, diagnoseMaxWait)
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("expected polling to stop when the context is cancelled")
+	}
+	if result.Propagated || len(result.Propagation) == 0 || len(result.Messages) != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestDiagnoseDNSCloseCname(t *testing.T) {
+	addr := startTestDNS(t, map[string][]dns.RR{
+		"_acme-challenge.example.com.": {mustRR(t, "_acme-challenge.example.com. 60 IN CNAME a.delegate.net.")},
+		"example.com.": {
+			mustRR(t, "example.com. 60 IN SOA ns1.example.com. admin.example.com. 1 7200 3600 1209600 60"),
+			mustRR(t, "example.com. 60 IN NS ns1.example.com."),
+		},
+		"ns1.example.com.": {mustRR(t, "ns1.example.com. 60 IN A 127.0.0.1")},
+	})
+	t.Setenv("LEGO_DISABLE_CNAME_SUPPORT", "false")
+
+	result, err := DiagnoseDNS(context.Background(), "example.com", "", "", map[string]any{"name_server": addr, "close_cname": true}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 关闭 CNAME 跟随后 provider 直接写入 _acme-challenge 名称，诊断也应检查该名称
+	if result.EffectiveFQDN != "_acme-challenge.example.com." || result.FQDN != result.EffectiveFQDN {
+		t.Fatalf("unexpected effective fqdn: %+v", result)
+	}
+	if len(result.CNAMEChain) != 0 || result.Zone != "example.com." {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
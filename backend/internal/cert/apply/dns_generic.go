@@ -0,0 +1,225 @@
+package apply
+
+import (
+	"ALLinSSL/backend/internal/access"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:generate go run gen_lego_generic.go
+
+// envKeyRegexp lego 环境变量名格式，如 HETZNER_API_KEY
+var envKeyRegexp = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// legoGenericProvider 描述一个 lego DNS provider 可配置的环境变量及构建函数。
+// lego 的 NewDNSProvider 只能从进程环境变量读取凭据，dns_generic_gen.go 中按 lego 源码生成了等价的构建函数，
+// 通过 legoEnv 读取授权配置，凭据不会写入进程环境。
+type legoGenericProvider struct {
+	keys  []string // provider 读取的全部环境变量
+	build func(e *legoEnv) (challenge.Provider, error)
+}
+
+// legoEnv 提供与 lego env 包同名的读取方法，值只来自授权配置
+type legoEnv struct {
+	values  map[string]string
+	maxWait time.Duration
+	err     error // 第一个格式错误的值，lego 会静默使用默认值，这里改为报错
+}
+
+func (e *legoEnv) GetOrFile(name string) string {
+	return e.values[name]
+}
+
+func (e *legoEnv) Get(names ...string) (map[string]string, error) {
+	values := map[string]string{}
+	var missing []string
+	for _, name := range names {
+		value := e.GetOrFile(name)
+		if value == "" {
+			missing = append(missing, name)
+		}
+		values[name] = value
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("缺少必填环境变量: %s", strings.Join(missing, ","))
+	}
+	return values, nil
+}
+
+// GetWithFallback 每组的第一个名称作为结果的键，其值为空时依次使用同组其他名称的值
+func (e *legoEnv) GetWithFallback(groups ...[]string) (map[string]string, error) {
+	values := map[string]string{}
+	var missing []string
+	for _, names := range groups {
+		if len(names) == 0 {
+			return nil, errors.New("undefined environment variable names")
+		}
+		value := e.getOneWithFallback(names[0], names[1:]...)
+		if value == "" {
+			missing = append(missing, names[0])
+			continue
+		}
+		values[names[0]] = value
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("缺少必填环境变量: %s", strings.Join(missing, ","))
+	}
+	return values, nil
+}
+
+func (e *legoEnv) getOneWithFallback(main string, names ...string) string {
+	if value := e.GetOrFile(main); value != "" {
+		return value
+	}
+	for _, name := range names {
+		if value := e.GetOrFile(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func (e *legoEnv) GetOrDefaultString(name string, defaultValue string) string {
+	return legoGetOrDefault(e, name, defaultValue, env.ParseString)
+}
+
+func (e *legoEnv) GetOrDefaultBool(name string, defaultValue bool) bool {
+	return legoGetOrDefault(e, name, defaultValue, strconv.ParseBool)
+}
+
+func (e *legoEnv) GetOrDefaultInt(name string, defaultValue int) int {
+	return legoGetOrDefault(e, name, defaultValue, strconv.Atoi)
+}
+
+func (e *legoEnv) GetOrDefaultSecond(name string, defaultValue time.Duration) time.Duration {
+	return legoGetOrDefault(e, name, defaultValue, env.ParseSecond)
+}
+
+// propagationTimeout 节点设置了 max_wait 时与其他 DNS provider 一致，以 max_wait 作为等待解析生效的超时时间
+func (e *legoEnv) propagationTimeout(d time.Duration) time.Duration {
+	if e.maxWait > 0 {
+		return e.maxWait
+	}
+	return d
+}
+
+func legoGetOrDefault[T any](e *legoEnv, name string, defaultValue T, fn func(string) (T, error)) T {
+	s := e.GetOrFile(name)
+	if s == "" {
+		return defaultValue
+	}
+	v, err := fn(s)
+	if err != nil {
+		if e.err == nil {
+			e.err = fmt.Errorf("%s 格式错误: %s", name, s)
+		}
+		return defaultValue
+	}
+	return v
+}
+
+func legoGetOneWithFallback[T any](e *legoEnv, main string, defaultValue T, fn func(string) (T, error), names ...string) T {
+	s := e.getOneWithFallback(main, names...)
+	if s == "" {
+		return defaultValue
+	}
+	v, err := fn(s)
+	if err != nil {
+		if e.err == nil {
+			e.err = fmt.Errorf("%s 格式错误: %s", main, s)
+		}
+		return defaultValue
+	}
+	return v
+}
+
+// legoGenericCodes 支持的 provider 代码，用于错误提示
+func legoGenericCodes() string {
+	codes := make([]string, 0, len(legoGenericProviders))
+	for code := range legoGenericProviders {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return strings.Join(codes, ", ")
+}
+
+// parseLegoGenericConfig 解析 lego-generic 授权配置
+//
+//	{"provider_code": "hetzner", "HETZNER_API_KEY": "xxx", ...}
+//
+// provider_code 为 lego 的 DNS provider 代码，其余键值为该 provider 文档中的环境变量。
+func parseLegoGenericConfig(creds map[string]string) (legoGenericProvider, map[string]string, error) {
+	code := strings.ToLower(strings.TrimSpace(creds["provider_code"]))
+	if code == "" {
+		return legoGenericProvider{}, nil, fmt.Errorf("参数错误：provider_code")
+	}
+	p, ok := legoGenericProviders[code]
+	if !ok {
+		return legoGenericProvider{}, nil, fmt.Errorf("不支持的 lego DNS provider: %s，当前支持：%s", code, legoGenericCodes())
+	}
+	allowed := make(map[string]bool)
+	for _, k := range p.keys {
+		allowed[k] = true
+	}
+
+	values := make(map[string]string)
+	for k, v := range creds {
+		if k == "provider_code" {
+			continue
+		}
+		k = strings.ToUpper(strings.TrimSpace(k))
+		if !envKeyRegexp.MatchString(k) {
+			return legoGenericProvider{}, nil, fmt.Errorf("环境变量名格式错误: %s", k)
+		}
+		if !allowed[k] {
+			return legoGenericProvider{}, nil, fmt.Errorf("provider【%s】不支持环境变量: %s", code, k)
+		}
+		values[k] = strings.TrimSpace(v)
+	}
+	return p, values, nil
+}
+
+// NewLegoGenericProvider 按授权中的键值构建 lego DNS provider，凭据不经过进程环境变量，
+// maxWait 大于 0 时作为等待解析生效的超时时间
+func NewLegoGenericProvider(creds map[string]string, maxWait time.Duration) (challenge.Provider, error) {
+	p, values, err := parseLegoGenericConfig(creds)
+	if err != nil {
+		return nil, err
+	}
+	e := &legoEnv{values: values, maxWait: maxWait}
+	provider, err := p.build(e)
+	if err == nil {
+		err = e.err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("创建 lego DNS provider【%s】失败: %v", creds["provider_code"], err)
+	}
+	return provider, nil
+}
+
+// LegoGenericAPITest 测试 lego-generic 授权，校验 provider 代码及必填环境变量
+func LegoGenericAPITest(providerID string) error {
+	providerData, err := access.GetAccess(providerID)
+	if err != nil {
+		return err
+	}
+	providerConfigStr, ok := providerData["config"].(string)
+	if !ok {
+		return fmt.Errorf("api配置错误")
+	}
+	var providerConfig map[string]string
+	err = json.Unmarshal([]byte(providerConfigStr), &providerConfig)
+	if err != nil {
+		return err
+	}
+	_, err = NewLegoGenericProvider(providerConfig, 0)
+	return err
+}
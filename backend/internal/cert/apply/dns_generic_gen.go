@@ -0,0 +1,3012 @@
+// Code generated by gen_lego_generic.go; DO NOT EDIT.
+
+package apply
+
+import (
+	"fmt"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/providers/dns/acmedns"
+	"github.com/go-acme/lego/v4/providers/dns/active24"
+	"github.com/go-acme/lego/v4/providers/dns/alidns"
+	"github.com/go-acme/lego/v4/providers/dns/allinkl"
+	"github.com/go-acme/lego/v4/providers/dns/arvancloud"
+	"github.com/go-acme/lego/v4/providers/dns/auroradns"
+	"github.com/go-acme/lego/v4/providers/dns/axelname"
+	"github.com/go-acme/lego/v4/providers/dns/baiducloud"
+	"github.com/go-acme/lego/v4/providers/dns/bindman"
+	"github.com/go-acme/lego/v4/providers/dns/bluecat"
+	"github.com/go-acme/lego/v4/providers/dns/bookmyname"
+	"github.com/go-acme/lego/v4/providers/dns/brandit"
+	"github.com/go-acme/lego/v4/providers/dns/bunny"
+	"github.com/go-acme/lego/v4/providers/dns/civo"
+	"github.com/go-acme/lego/v4/providers/dns/clouddns"
+	"github.com/go-acme/lego/v4/providers/dns/cloudns"
+	"github.com/go-acme/lego/v4/providers/dns/cloudru"
+	"github.com/go-acme/lego/v4/providers/dns/cloudxns"
+	"github.com/go-acme/lego/v4/providers/dns/conoha"
+	"github.com/go-acme/lego/v4/providers/dns/constellix"
+	"github.com/go-acme/lego/v4/providers/dns/corenetworks"
+	"github.com/go-acme/lego/v4/providers/dns/cpanel"
+	"github.com/go-acme/lego/v4/providers/dns/derak"
+	"github.com/go-acme/lego/v4/providers/dns/desec"
+	"github.com/go-acme/lego/v4/providers/dns/digitalocean"
+	"github.com/go-acme/lego/v4/providers/dns/directadmin"
+	"github.com/go-acme/lego/v4/providers/dns/dnsimple"
+	"github.com/go-acme/lego/v4/providers/dns/dnspod"
+	"github.com/go-acme/lego/v4/providers/dns/dode"
+	"github.com/go-acme/lego/v4/providers/dns/domeneshop"
+	"github.com/go-acme/lego/v4/providers/dns/dreamhost"
+	"github.com/go-acme/lego/v4/providers/dns/duckdns"
+	"github.com/go-acme/lego/v4/providers/dns/dyn"
+	"github.com/go-acme/lego/v4/providers/dns/dynu"
+	"github.com/go-acme/lego/v4/providers/dns/efficientip"
+	"github.com/go-acme/lego/v4/providers/dns/epik"
+	"github.com/go-acme/lego/v4/providers/dns/f5xc"
+	"github.com/go-acme/lego/v4/providers/dns/freemyip"
+	"github.com/go-acme/lego/v4/providers/dns/gandi"
+	"github.com/go-acme/lego/v4/providers/dns/gandiv5"
+	"github.com/go-acme/lego/v4/providers/dns/gcore"
+	"github.com/go-acme/lego/v4/providers/dns/glesys"
+	"github.com/go-acme/lego/v4/providers/dns/godaddy"
+	"github.com/go-acme/lego/v4/providers/dns/googledomains"
+	"github.com/go-acme/lego/v4/providers/dns/hetzner"
+	"github.com/go-acme/lego/v4/providers/dns/hostingde"
+	"github.com/go-acme/lego/v4/providers/dns/hosttech"
+	"github.com/go-acme/lego/v4/providers/dns/httpnet"
+	"github.com/go-acme/lego/v4/providers/dns/httpreq"
+	"github.com/go-acme/lego/v4/providers/dns/hyperone"
+	"github.com/go-acme/lego/v4/providers/dns/ibmcloud"
+	"github.com/go-acme/lego/v4/providers/dns/iij"
+	"github.com/go-acme/lego/v4/providers/dns/iijdpf"
+	"github.com/go-acme/lego/v4/providers/dns/infoblox"
+	"github.com/go-acme/lego/v4/providers/dns/infomaniak"
+	"github.com/go-acme/lego/v4/providers/dns/internetbs"
+	"github.com/go-acme/lego/v4/providers/dns/inwx"
+	"github.com/go-acme/lego/v4/providers/dns/ionos"
+	"github.com/go-acme/lego/v4/providers/dns/ipv64"
+	"github.com/go-acme/lego/v4/providers/dns/iwantmyname"
+	"github.com/go-acme/lego/v4/providers/dns/liara"
+	"github.com/go-acme/lego/v4/providers/dns/limacity"
+	"github.com/go-acme/lego/v4/providers/dns/linode"
+	"github.com/go-acme/lego/v4/providers/dns/luadns"
+	"github.com/go-acme/lego/v4/providers/dns/mailinabox"
+	"github.com/go-acme/lego/v4/providers/dns/manageengine"
+	"github.com/go-acme/lego/v4/providers/dns/metaname"
+	"github.com/go-acme/lego/v4/providers/dns/metaregistrar"
+	"github.com/go-acme/lego/v4/providers/dns/mijnhost"
+	"github.com/go-acme/lego/v4/providers/dns/mittwald"
+	"github.com/go-acme/lego/v4/providers/dns/mydnsjp"
+	"github.com/go-acme/lego/v4/providers/dns/namedotcom"
+	"github.com/go-acme/lego/v4/providers/dns/namesilo"
+	"github.com/go-acme/lego/v4/providers/dns/nearlyfreespeech"
+	"github.com/go-acme/lego/v4/providers/dns/netcup"
+	"github.com/go-acme/lego/v4/providers/dns/netlify"
+	"github.com/go-acme/lego/v4/providers/dns/nifcloud"
+	"github.com/go-acme/lego/v4/providers/dns/njalla"
+	"github.com/go-acme/lego/v4/providers/dns/nodion"
+	"github.com/go-acme/lego/v4/providers/dns/ns1"
+	"github.com/go-acme/lego/v4/providers/dns/ovh"
+	"github.com/go-acme/lego/v4/providers/dns/pdns"
+	"github.com/go-acme/lego/v4/providers/dns/porkbun"
+	"github.com/go-acme/lego/v4/providers/dns/rackspace"
+	"github.com/go-acme/lego/v4/providers/dns/rainyun"
+	"github.com/go-acme/lego/v4/providers/dns/rcodezero"
+	"github.com/go-acme/lego/v4/providers/dns/regfish"
+	"github.com/go-acme/lego/v4/providers/dns/regru"
+	"github.com/go-acme/lego/v4/providers/dns/rfc2136"
+	"github.com/go-acme/lego/v4/providers/dns/rimuhosting"
+	"github.com/go-acme/lego/v4/providers/dns/safedns"
+	"github.com/go-acme/lego/v4/providers/dns/sakuracloud"
+	"github.com/go-acme/lego/v4/providers/dns/selectel"
+	"github.com/go-acme/lego/v4/providers/dns/selectelv2"
+	"github.com/go-acme/lego/v4/providers/dns/servercow"
+	"github.com/go-acme/lego/v4/providers/dns/shellrent"
+	"github.com/go-acme/lego/v4/providers/dns/simply"
+	"github.com/go-acme/lego/v4/providers/dns/sonic"
+	"github.com/go-acme/lego/v4/providers/dns/spaceship"
+	"github.com/go-acme/lego/v4/providers/dns/stackpath"
+	"github.com/go-acme/lego/v4/providers/dns/technitium"
+	"github.com/go-acme/lego/v4/providers/dns/tencentcloud"
+	"github.com/go-acme/lego/v4/providers/dns/timewebcloud"
+	"github.com/go-acme/lego/v4/providers/dns/ultradns"
+	"github.com/go-acme/lego/v4/providers/dns/variomedia"
+	"github.com/go-acme/lego/v4/providers/dns/vegadns"
+	"github.com/go-acme/lego/v4/providers/dns/vercel"
+	"github.com/go-acme/lego/v4/providers/dns/vinyldns"
+	"github.com/go-acme/lego/v4/providers/dns/volcengine"
+	"github.com/go-acme/lego/v4/providers/dns/vscale"
+	"github.com/go-acme/lego/v4/providers/dns/vultr"
+	"github.com/go-acme/lego/v4/providers/dns/webnames"
+	"github.com/go-acme/lego/v4/providers/dns/websupport"
+	"github.com/go-acme/lego/v4/providers/dns/wedos"
+	"github.com/go-acme/lego/v4/providers/dns/westcn"
+	"github.com/go-acme/lego/v4/providers/dns/yandex"
+	"github.com/go-acme/lego/v4/providers/dns/yandex360"
+	"github.com/go-acme/lego/v4/providers/dns/yandexcloud"
+	"github.com/go-acme/lego/v4/providers/dns/zonomi"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// legoGenericProviders 可通过 lego-generic 授权使用的 lego DNS provider，键为 lego 的 provider 代码
+var legoGenericProviders = map[string]legoGenericProvider{
+	"acme-dns":         {keys: []string{acmedns.EnvAPIBase, acmedns.EnvStoragePath, acmedns.EnvStorageBaseURL, acmedns.EnvAllowList}, build: newLegoAcmedns},
+	"acmedns":          {keys: []string{acmedns.EnvAPIBase, acmedns.EnvStoragePath, acmedns.EnvStorageBaseURL, acmedns.EnvAllowList}, build: newLegoAcmedns},
+	"active24":         {keys: []string{active24.EnvTTL, active24.EnvPropagationTimeout, active24.EnvPollingInterval, active24.EnvHTTPTimeout, active24.EnvAPIKey, active24.EnvSecret}, build: newLegoActive24},
+	"alidns":           {keys: []string{alidns.EnvTTL, alidns.EnvPropagationTimeout, alidns.EnvPollingInterval, alidns.EnvHTTPTimeout, alidns.EnvRegionID, alidns.EnvRAMRole, alidns.EnvAccessKey, alidns.EnvSecretKey, alidns.EnvSecurityToken}, build: newLegoAlidns},
+	"allinkl":          {keys: []string{allinkl.EnvPropagationTimeout, allinkl.EnvPollingInterval, allinkl.EnvHTTPTimeout, allinkl.EnvLogin, allinkl.EnvPassword}, build: newLegoAllinkl},
+	"arvancloud":       {keys: []string{arvancloud.EnvTTL, arvancloud.EnvPropagationTimeout, arvancloud.EnvPollingInterval, arvancloud.EnvHTTPTimeout, arvancloud.EnvAPIKey}, build: newLegoArvancloud},
+	"auroradns":        {keys: []string{auroradns.EnvTTL, auroradns.EnvPropagationTimeout, auroradns.EnvPollingInterval, auroradns.EnvAPIKey, auroradns.EnvSecret, auroradns.EnvEndpoint}, build: newLegoAuroradns},
+	"axelname":         {keys: []string{axelname.EnvTTL, axelname.EnvPropagationTimeout, axelname.EnvPollingInterval, axelname.EnvHTTPTimeout, axelname.EnvNickname, axelname.EnvToken}, build: newLegoAxelname},
+	"baiducloud":       {keys: []string{baiducloud.EnvTTL, baiducloud.EnvPropagationTimeout, baiducloud.EnvPollingInterval, baiducloud.EnvAccessKeyID, baiducloud.EnvSecretAccessKey}, build: newLegoBaiducloud},
+	"bindman":          {keys: []string{bindman.EnvPropagationTimeout, bindman.EnvPollingInterval, bindman.EnvHTTPTimeout, bindman.EnvManagerAddress}, build: newLegoBindman},
+	"bluecat":          {keys: []string{bluecat.EnvTTL, bluecat.EnvPropagationTimeout, bluecat.EnvPollingInterval, bluecat.EnvHTTPTimeout, bluecat.EnvDebug, bluecat.EnvSkipDeploy, bluecat.EnvServerURL, bluecat.EnvUserName, bluecat.EnvPassword, bluecat.EnvConfigName, bluecat.EnvDNSView}, build: newLegoBluecat},
+	"bookmyname":       {keys: []string{bookmyname.EnvTTL, bookmyname.EnvPropagationTimeout, bookmyname.EnvPollingInterval, bookmyname.EnvHTTPTimeout, bookmyname.EnvUsername, bookmyname.EnvPassword}, build: newLegoBookmyname},
+	"brandit":          {keys: []string{brandit.EnvTTL, brandit.EnvPropagationTimeout, brandit.EnvPollingInterval, brandit.EnvHTTPTimeout, brandit.EnvAPIKey, brandit.EnvAPIUsername}, build: newLegoBrandit},
+	"bunny":            {keys: []string{bunny.EnvTTL, bunny.EnvPropagationTimeout, bunny.EnvPollingInterval, bunny.EnvAPIKey}, build: newLegoBunny},
+	"civo":             {keys: []string{civo.EnvTTL, civo.EnvPropagationTimeout, civo.EnvPollingInterval, civo.EnvAPIToken}, build: newLegoCivo},
+	"clouddns":         {keys: []string{clouddns.EnvTTL, clouddns.EnvPropagationTimeout, clouddns.EnvPollingInterval, clouddns.EnvHTTPTimeout, clouddns.EnvClientID, clouddns.EnvEmail, clouddns.EnvPassword}, build: newLegoClouddns},
+	"cloudns":          {keys: []string{cloudns.EnvTTL, cloudns.EnvPropagationTimeout, cloudns.EnvPollingInterval, cloudns.EnvHTTPTimeout, cloudns.EnvAuthID, cloudns.EnvSubAuthID, cloudns.EnvAuthPassword}, build: newLegoCloudns},
+	"cloudru":          {keys: []string{cloudru.EnvTTL, cloudru.EnvPropagationTimeout, cloudru.EnvPollingInterval, cloudru.EnvSequenceInterval, cloudru.EnvHTTPTimeout, cloudru.EnvServiceInstanceID, cloudru.EnvKeyID, cloudru.EnvSecret}, build: newLegoCloudru},
+	"cloudxns":         {keys: []string{}, build: newLegoCloudxns},
+	"conoha":           {keys: []string{conoha.EnvRegion, conoha.EnvTTL, conoha.EnvPropagationTimeout, conoha.EnvPollingInterval, conoha.EnvHTTPTimeout, conoha.EnvTenantID, conoha.EnvAPIUsername, conoha.EnvAPIPassword}, build: newLegoConoha},
+	"constellix":       {keys: []string{constellix.EnvTTL, constellix.EnvPropagationTimeout, constellix.EnvPollingInterval, constellix.EnvHTTPTimeout, constellix.EnvAPIKey, constellix.EnvSecretKey}, build: newLegoConstellix},
+	"corenetworks":     {keys: []string{corenetworks.EnvTTL, corenetworks.EnvPropagationTimeout, corenetworks.EnvPollingInterval, corenetworks.EnvSequenceInterval, corenetworks.EnvHTTPTimeout, corenetworks.EnvLogin, corenetworks.EnvPassword}, build: newLegoCorenetworks},
+	"cpanel":           {keys: []string{cpanel.EnvMode, cpanel.EnvTTL, cpanel.EnvPropagationTimeout, cpanel.EnvPollingInterval, cpanel.EnvHTTPTimeout, cpanel.EnvUsername, cpanel.EnvToken, cpanel.EnvBaseURL}, build: newLegoCpanel},
+	"derak":            {keys: []string{derak.EnvTTL, derak.EnvPropagationTimeout, derak.EnvPollingInterval, derak.EnvHTTPTimeout, derak.EnvAPIKey, derak.EnvWebsiteID}, build: newLegoDerak},
+	"desec":            {keys: []string{desec.EnvTTL, desec.EnvPropagationTimeout, desec.EnvPollingInterval, desec.EnvHTTPTimeout, desec.EnvToken}, build: newLegoDesec},
+	"digitalocean":     {keys: []string{digitalocean.EnvAPIUrl, digitalocean.EnvTTL, digitalocean.EnvPropagationTimeout, digitalocean.EnvPollingInterval, digitalocean.EnvHTTPTimeout, digitalocean.EnvAuthToken}, build: newLegoDigitalocean},
+	"directadmin":      {keys: []string{directadmin.EnvZoneName, directadmin.EnvTTL, directadmin.EnvPropagationTimeout, directadmin.EnvPollingInterval, directadmin.EnvHTTPTimeout, directadmin.EnvAPIURL, directadmin.EnvUsername, directadmin.EnvPassword}, build: newLegoDirectadmin},
+	"dnsimple":         {keys: []string{dnsimple.EnvTTL, dnsimple.EnvDebug, dnsimple.EnvPropagationTimeout, dnsimple.EnvPollingInterval, dnsimple.EnvOAuthToken, dnsimple.EnvBaseURL}, build: newLegoDnsimple},
+	"dnspod":           {keys: []string{dnspod.EnvTTL, dnspod.EnvPropagationTimeout, dnspod.EnvPollingInterval, dnspod.EnvHTTPTimeout, dnspod.EnvAPIKey}, build: newLegoDnspod},
+	"dode":             {keys: []string{dode.EnvPropagationTimeout, dode.EnvPollingInterval, dode.EnvSequenceInterval, dode.EnvHTTPTimeout, dode.EnvToken}, build: newLegoDode},
+	"domainnameshop":   {keys: []string{domeneshop.EnvPropagationTimeout, domeneshop.EnvPollingInterval, domeneshop.EnvHTTPTimeout, domeneshop.EnvAPIToken, domeneshop.EnvAPISecret}, build: newLegoDomeneshop},
+	"domeneshop":       {keys: []string{domeneshop.EnvPropagationTimeout, domeneshop.EnvPollingInterval, domeneshop.EnvHTTPTimeout, domeneshop.EnvAPIToken, domeneshop.EnvAPISecret}, build: newLegoDomeneshop},
+	"dreamhost":        {keys: []string{dreamhost.EnvPropagationTimeout, dreamhost.EnvPollingInterval, dreamhost.EnvHTTPTimeout, dreamhost.EnvAPIKey}, build: newLegoDreamhost},
+	"duckdns":          {keys: []string{duckdns.EnvPropagationTimeout, duckdns.EnvPollingInterval, duckdns.EnvSequenceInterval, duckdns.EnvHTTPTimeout, duckdns.EnvToken}, build: newLegoDuckdns},
+	"dyn":              {keys: []string{dyn.EnvTTL, dyn.EnvPropagationTimeout, dyn.EnvPollingInterval, dyn.EnvHTTPTimeout, dyn.EnvCustomerName, dyn.EnvUserName, dyn.EnvPassword}, build: newLegoDyn},
+	"dynu":             {keys: []string{dynu.EnvTTL, dynu.EnvPropagationTimeout, dynu.EnvPollingInterval, dynu.EnvHTTPTimeout, dynu.EnvAPIKey}, build: newLegoDynu},
+	"efficientip":      {keys: []string{efficientip.EnvPropagationTimeout, efficientip.EnvPollingInterval, efficientip.EnvHTTPTimeout, efficientip.EnvUsername, efficientip.EnvPassword, efficientip.EnvHostname, efficientip.EnvDNSName, efficientip.EnvViewName, efficientip.EnvInsecureSkipVerify}, build: newLegoEfficientip},
+	"epik":             {keys: []string{epik.EnvTTL, epik.EnvPropagationTimeout, epik.EnvPollingInterval, epik.EnvHTTPTimeout, epik.EnvSignature}, build: newLegoEpik},
+	"f5xc":             {keys: []string{f5xc.EnvTTL, f5xc.EnvPropagationTimeout, f5xc.EnvPollingInterval, f5xc.EnvHTTPTimeout, f5xc.EnvToken, f5xc.EnvTenantName, f5xc.EnvGroupName}, build: newLegoF5xc},
+	"freemyip":         {keys: []string{freemyip.EnvTTL, freemyip.EnvPropagationTimeout, freemyip.EnvPollingInterval, freemyip.EnvSequenceInterval, freemyip.EnvHTTPTimeout, freemyip.EnvToken}, build: newLegoFreemyip},
+	"gandi":            {keys: []string{gandi.EnvTTL, gandi.EnvPropagationTimeout, gandi.EnvPollingInterval, gandi.EnvHTTPTimeout, gandi.EnvAPIKey}, build: newLegoGandi},
+	"gandiv5":          {keys: []string{gandiv5.EnvTTL, gandiv5.EnvPropagationTimeout, gandiv5.EnvPollingInterval, gandiv5.EnvHTTPTimeout, gandiv5.EnvAPIKey, gandiv5.EnvPersonalAccessToken}, build: newLegoGandiv5},
+	"gcore":            {keys: []string{gcore.EnvTTL, gcore.EnvPropagationTimeout, gcore.EnvPollingInterval, gcore.EnvHTTPTimeout, gcore.EnvPermanentAPIToken}, build: newLegoGcore},
+	"glesys":           {keys: []string{glesys.EnvTTL, glesys.EnvPropagationTimeout, glesys.EnvPollingInterval, glesys.EnvHTTPTimeout, glesys.EnvAPIUser, glesys.EnvAPIKey}, build: newLegoGlesys},
+	"godaddy":          {keys: []string{godaddy.EnvTTL, godaddy.EnvPropagationTimeout, godaddy.EnvPollingInterval, godaddy.EnvHTTPTimeout, godaddy.EnvAPIKey, godaddy.EnvAPISecret}, build: newLegoGodaddy},
+	"googledomains":    {keys: []string{googledomains.EnvPropagationTimeout, googledomains.EnvPollingInterval, googledomains.EnvHTTPTimeout, googledomains.EnvAccessToken}, build: newLegoGoogledomains},
+	"hetzner":          {keys: []string{hetzner.EnvTTL, hetzner.EnvPropagationTimeout, hetzner.EnvPollingInterval, hetzner.EnvHTTPTimeout, hetzner.EnvAPIKey}, build: newLegoHetzner},
+	"hostingde":        {keys: []string{hostingde.EnvZoneName, hostingde.EnvTTL, hostingde.EnvPropagationTimeout, hostingde.EnvPollingInterval, hostingde.EnvHTTPTimeout, hostingde.EnvAPIKey}, build: newLegoHostingde},
+	"hosttech":         {keys: []string{hosttech.EnvTTL, hosttech.EnvPropagationTimeout, hosttech.EnvPollingInterval, hosttech.EnvHTTPTimeout, hosttech.EnvAPIKey}, build: newLegoHosttech},
+	"httpnet":          {keys: []string{httpnet.EnvZoneName, httpnet.EnvTTL, httpnet.EnvPropagationTimeout, httpnet.EnvPollingInterval, httpnet.EnvHTTPTimeout, httpnet.EnvAPIKey}, build: newLegoHttpnet},
+	"httpreq":          {keys: []string{httpreq.EnvPropagationTimeout, httpreq.EnvPollingInterval, httpreq.EnvHTTPTimeout, httpreq.EnvEndpoint, httpreq.EnvMode, httpreq.EnvUsername, httpreq.EnvPassword}, build: newLegoHttpreq},
+	"hyperone":         {keys: []string{hyperone.EnvTTL, hyperone.EnvPropagationTimeout, hyperone.EnvPollingInterval, hyperone.EnvHTTPTimeout, hyperone.EnvPassportLocation, hyperone.EnvLocationID, hyperone.EnvAPIUrl}, build: newLegoHyperone},
+	"ibmcloud":         {keys: []string{ibmcloud.EnvTTL, ibmcloud.EnvPropagationTimeout, ibmcloud.EnvPollingInterval, ibmcloud.EnvHTTPTimeout, ibmcloud.EnvUsername, ibmcloud.EnvAPIKey, ibmcloud.EnvDebug}, build: newLegoIbmcloud},
+	"iij":              {keys: []string{iij.EnvTTL, iij.EnvPropagationTimeout, iij.EnvPollingInterval, iij.EnvAPIAccessKey, iij.EnvAPISecretKey, iij.EnvDoServiceCode}, build: newLegoIij},
+	"iijdpf":           {keys: []string{iijdpf.EnvAPIEndpoint, iijdpf.EnvPropagationTimeout, iijdpf.EnvPollingInterval, iijdpf.EnvTTL, iijdpf.EnvAPIToken, iijdpf.EnvServiceCode}, build: newLegoIijdpf},
+	"infoblox":         {keys: []string{infoblox.EnvDNSView, infoblox.EnvWApiVersion, infoblox.EnvPort, infoblox.EnvSSLVerify, infoblox.EnvCACertificate, infoblox.EnvTTL, infoblox.EnvPropagationTimeout, infoblox.EnvPollingInterval, infoblox.EnvHTTPTimeout, infoblox.EnvHost, infoblox.EnvUsername, infoblox.EnvPassword}, build: newLegoInfoblox},
+	"infomaniak":       {keys: []string{infomaniak.EnvEndpoint, infomaniak.EnvTTL, infomaniak.EnvPropagationTimeout, infomaniak.EnvPollingInterval, infomaniak.EnvHTTPTimeout, infomaniak.EnvAccessToken}, build: newLegoInfomaniak},
+	"internetbs":       {keys: []string{internetbs.EnvTTL, internetbs.EnvPropagationTimeout, internetbs.EnvPollingInterval, internetbs.EnvHTTPTimeout, internetbs.EnvAPIKey, internetbs.EnvPassword}, build: newLegoInternetbs},
+	"inwx":             {keys: []string{inwx.EnvTTL, inwx.EnvPropagationTimeout, inwx.EnvPollingInterval, inwx.EnvSandbox, inwx.EnvUsername, inwx.EnvPassword, inwx.EnvSharedSecret}, build: newLegoInwx},
+	"ionos":            {keys: []string{ionos.EnvTTL, ionos.EnvPropagationTimeout, ionos.EnvPollingInterval, ionos.EnvHTTPTimeout, ionos.EnvAPIKey}, build: newLegoIonos},
+	"ipv64":            {keys: []string{ipv64.EnvPropagationTimeout, ipv64.EnvPollingInterval, ipv64.EnvHTTPTimeout, ipv64.EnvAPIKey}, build: newLegoIpv64},
+	"iwantmyname":      {keys: []string{iwantmyname.EnvTTL, iwantmyname.EnvPropagationTimeout, iwantmyname.EnvPollingInterval, iwantmyname.EnvHTTPTimeout, iwantmyname.EnvUsername, iwantmyname.EnvPassword}, build: newLegoIwantmyname},
+	"liara":            {keys: []string{liara.EnvTTL, liara.EnvPropagationTimeout, liara.EnvPollingInterval, liara.EnvHTTPTimeout, liara.EnvAPIKey}, build: newLegoLiara},
+	"limacity":         {keys: []string{limacity.EnvTTL, limacity.EnvPropagationTimeout, limacity.EnvPollingInterval, limacity.EnvSequenceInterval, limacity.EnvHTTPTimeout, limacity.EnvAPIKey}, build: newLegoLimacity},
+	"linode":           {keys: []string{linode.EnvTTL, linode.EnvPropagationTimeout, linode.EnvPollingInterval, linode.EnvHTTPTimeout, linode.EnvToken}, build: newLegoLinode},
+	"linodev4":         {keys: []string{linode.EnvTTL, linode.EnvPropagationTimeout, linode.EnvPollingInterval, linode.EnvHTTPTimeout, linode.EnvToken}, build: newLegoLinode},
+	"luadns":           {keys: []string{luadns.EnvTTL, luadns.EnvPropagationTimeout, luadns.EnvPollingInterval, luadns.EnvHTTPTimeout, luadns.EnvAPIUsername, luadns.EnvAPIToken}, build: newLegoLuadns},
+	"mailinabox":       {keys: []string{mailinabox.EnvPropagationTimeout, mailinabox.EnvPollingInterval, mailinabox.EnvBaseURL, mailinabox.EnvEmail, mailinabox.EnvPassword}, build: newLegoMailinabox},
+	"manageengine":     {keys: []string{manageengine.EnvTTL, manageengine.EnvPropagationTimeout, manageengine.EnvPollingInterval, manageengine.EnvClientID, manageengine.EnvClientSecret}, build: newLegoManageengine},
+	"metaname":         {keys: []string{metaname.EnvPropagationTimeout, metaname.EnvPollingInterval, metaname.EnvTTL, metaname.EnvAccountReference, metaname.EnvAPIKey}, build: newLegoMetaname},
+	"metaregistrar":    {keys: []string{metaregistrar.EnvTTL, metaregistrar.EnvPropagationTimeout, metaregistrar.EnvPollingInterval, metaregistrar.EnvHTTPTimeout, metaregistrar.EnvToken}, build: newLegoMetaregistrar},
+	"mijnhost":         {keys: []string{mijnhost.EnvTTL, mijnhost.EnvPropagationTimeout, mijnhost.EnvPollingInterval, mijnhost.EnvSequenceInterval, mijnhost.EnvHTTPTimeout, mijnhost.EnvAPIKey}, build: newLegoMijnhost},
+	"mittwald":         {keys: []string{mittwald.EnvTTL, mittwald.EnvPropagationTimeout, mittwald.EnvPollingInterval, mittwald.EnvSequenceInterval, mittwald.EnvHTTPTimeout, mittwald.EnvToken}, build: newLegoMittwald},
+	"mydnsjp":          {keys: []string{mydnsjp.EnvPropagationTimeout, mydnsjp.EnvPollingInterval, mydnsjp.EnvHTTPTimeout, mydnsjp.EnvMasterID, mydnsjp.EnvPassword}, build: newLegoMydnsjp},
+	"namedotcom":       {keys: []string{namedotcom.EnvTTL, namedotcom.EnvPropagationTimeout, namedotcom.EnvPollingInterval, namedotcom.EnvHTTPTimeout, namedotcom.EnvUsername, namedotcom.EnvAPIToken, namedotcom.EnvServer}, build: newLegoNamedotcom},
+	"namesilo":         {keys: []string{namesilo.EnvTTL, namesilo.EnvPropagationTimeout, namesilo.EnvPollingInterval, namesilo.EnvAPIKey}, build: newLegoNamesilo},
+	"nearlyfreespeech": {keys: []string{nearlyfreespeech.EnvTTL, nearlyfreespeech.EnvPropagationTimeout, nearlyfreespeech.EnvPollingInterval, nearlyfreespeech.EnvSequenceInterval, nearlyfreespeech.EnvHTTPTimeout, nearlyfreespeech.EnvAPIKey, nearlyfreespeech.EnvLogin}, build: newLegoNearlyfreespeech},
+	"netcup":           {keys: []string{netcup.EnvPropagationTimeout, netcup.EnvPollingInterval, netcup.EnvHTTPTimeout, netcup.EnvCustomerNumber, netcup.EnvAPIKey, netcup.EnvAPIPassword}, build: newLegoNetcup},
+	"netlify":          {keys: []string{netlify.EnvTTL, netlify.EnvPropagationTimeout, netlify.EnvPollingInterval, netlify.EnvHTTPTimeout, netlify.EnvToken}, build: newLegoNetlify},
+	"nifcloud":         {keys: []string{nifcloud.EnvTTL, nifcloud.EnvPropagationTimeout, nifcloud.EnvPollingInterval, nifcloud.EnvHTTPTimeout, nifcloud.EnvAccessKeyID, nifcloud.EnvSecretAccessKey, nifcloud.EnvDNSEndpoint}, build: newLegoNifcloud},
+	"njalla":           {keys: []string{njalla.EnvTTL, njalla.EnvPropagationTimeout, njalla.EnvPollingInterval, njalla.EnvHTTPTimeout, njalla.EnvToken}, build: newLegoNjalla},
+	"nodion":           {keys: []string{nodion.EnvTTL, nodion.EnvPropagationTimeout, nodion.EnvPollingInterval, nodion.EnvHTTPTimeout, nodion.EnvAPIToken}, build: newLegoNodion},
+	"ns1":              {keys: []string{ns1.EnvTTL, ns1.EnvPropagationTimeout, ns1.EnvPollingInterval, ns1.EnvHTTPTimeout, ns1.EnvAPIKey}, build: newLegoNs1},
+	"ovh":              {keys: []string{ovh.EnvTTL, ovh.EnvPropagationTimeout, ovh.EnvPollingInterval, ovh.EnvHTTPTimeout, ovh.EnvEndpoint, ovh.EnvApplicationKey, ovh.EnvApplicationSecret, ovh.EnvConsumerKey, ovh.EnvAccessToken, ovh.EnvClientID, ovh.EnvClientSecret}, build: newLegoOvh},
+	"pdns":             {keys: []string{pdns.EnvServerName, pdns.EnvAPIVersion, pdns.EnvTTL, pdns.EnvPropagationTimeout, pdns.EnvPollingInterval, pdns.EnvHTTPTimeout, pdns.EnvAPIKey, pdns.EnvAPIURL}, build: newLegoPdns},
+	"porkbun":          {keys: []string{porkbun.EnvPropagationTimeout, porkbun.EnvPollingInterval, porkbun.EnvTTL, porkbun.EnvHTTPTimeout, porkbun.EnvSecretAPIKey, porkbun.EnvAPIKey}, build: newLegoPorkbun},
+	"rackspace":        {keys: []string{rackspace.EnvTTL, rackspace.EnvPropagationTimeout, rackspace.EnvPollingInterval, rackspace.EnvHTTPTimeout, rackspace.EnvUser, rackspace.EnvAPIKey}, build: newLegoRackspace},
+	"rainyun":          {keys: []string{rainyun.EnvTTL, rainyun.EnvPropagationTimeout, rainyun.EnvPollingInterval, rainyun.EnvHTTPTimeout, rainyun.EnvAPIKey}, build: newLegoRainyun},
+	"rcodezero":        {keys: []string{rcodezero.EnvTTL, rcodezero.EnvPropagationTimeout, rcodezero.EnvPollingInterval, rcodezero.EnvHTTPTimeout, rcodezero.EnvAPIToken}, build: newLegoRcodezero},
+	"regfish":          {keys: []string{regfish.EnvTTL, regfish.EnvPropagationTimeout, regfish.EnvPollingInterval, regfish.EnvHTTPTimeout, regfish.EnvAPIKey}, build: newLegoRegfish},
+	"regru":            {keys: []string{regru.EnvTTL, regru.EnvPropagationTimeout, regru.EnvPollingInterval, regru.EnvHTTPTimeout, regru.EnvUsername, regru.EnvPassword, regru.EnvTLSCert, regru.EnvTLSKey}, build: newLegoRegru},
+	"rfc2136":          {keys: []string{rfc2136.EnvTSIGAlgorithm, rfc2136.EnvTTL, rfc2136.EnvPropagationTimeout, rfc2136.EnvPollingInterval, rfc2136.EnvSequenceInterval, rfc2136.EnvDNSTimeout, rfc2136.EnvNameserver, rfc2136.EnvTSIGFile, rfc2136.EnvTSIGKey, rfc2136.EnvTSIGSecret}, build: newLegoRfc2136},
+	"rimuhosting":      {keys: []string{rimuhosting.EnvTTL, rimuhosting.EnvPropagationTimeout, rimuhosting.EnvPollingInterval, rimuhosting.EnvHTTPTimeout, rimuhosting.EnvAPIKey}, build: newLegoRimuhosting},
+	"safedns":          {keys: []string{safedns.EnvTTL, safedns.EnvPropagationTimeout, safedns.EnvPollingInterval, safedns.EnvHTTPTimeout, safedns.EnvAuthToken}, build: newLegoSafedns},
+	"sakuracloud":      {keys: []string{sakuracloud.EnvTTL, sakuracloud.EnvPropagationTimeout, sakuracloud.EnvPollingInterval, sakuracloud.EnvHTTPTimeout, sakuracloud.EnvAccessToken, sakuracloud.EnvAccessTokenSecret}, build: newLegoSakuracloud},
+	"selectel":         {keys: []string{selectel.EnvBaseURL, selectel.EnvTTL, selectel.EnvPropagationTimeout, selectel.EnvPollingInterval, selectel.EnvHTTPTimeout, selectel.EnvAPIToken}, build: newLegoSelectel},
+	"selectelv2":       {keys: []string{selectelv2.EnvBaseURL, selectelv2.EnvTTL, selectelv2.EnvPropagationTimeout, selectelv2.EnvPollingInterval, selectelv2.EnvHTTPTimeout, selectelv2.EnvUsernameOS, selectelv2.EnvPasswordOS, selectelv2.EnvAccount, selectelv2.EnvProjectID}, build: newLegoSelectelv2},
+	"servercow":        {keys: []string{servercow.EnvTTL, servercow.EnvPropagationTimeout, servercow.EnvPollingInterval, servercow.EnvHTTPTimeout, servercow.EnvUsername, servercow.EnvPassword}, build: newLegoServercow},
+	"shellrent":        {keys: []string{shellrent.EnvTTL, shellrent.EnvPropagationTimeout, shellrent.EnvPollingInterval, shellrent.EnvHTTPTimeout, shellrent.EnvUsername, shellrent.EnvToken}, build: newLegoShellrent},
+	"simply":           {keys: []string{simply.EnvTTL, simply.EnvPropagationTimeout, simply.EnvPollingInterval, simply.EnvHTTPTimeout, simply.EnvAccountName, simply.EnvAPIKey}, build: newLegoSimply},
+	"sonic":            {keys: []string{sonic.EnvTTL, sonic.EnvPropagationTimeout, sonic.EnvSequenceInterval, sonic.EnvPollingInterval, sonic.EnvHTTPTimeout, sonic.EnvUserID, sonic.EnvAPIKey}, build: newLegoSonic},
+	"spaceship":        {keys: []string{spaceship.EnvTTL, spaceship.EnvPropagationTimeout, spaceship.EnvPollingInterval, spaceship.EnvHTTPTimeout, spaceship.EnvAPIKey, spaceship.EnvAPISecret}, build: newLegoSpaceship},
+	"stackpath":        {keys: []string{stackpath.EnvTTL, stackpath.EnvPropagationTimeout, stackpath.EnvPollingInterval, stackpath.EnvClientID, stackpath.EnvClientSecret, stackpath.EnvStackID}, build: newLegoStackpath},
+	"technitium":       {keys: []string{technitium.EnvTTL, technitium.EnvPropagationTimeout, technitium.EnvPollingInterval, technitium.EnvHTTPTimeout, technitium.EnvServerBaseURL, technitium.EnvAPIToken}, build: newLegoTechnitium},
+	"tencentcloud":     {keys: []string{tencentcloud.EnvTTL, tencentcloud.EnvPropagationTimeout, tencentcloud.EnvPollingInterval, tencentcloud.EnvHTTPTimeout, tencentcloud.EnvSecretID, tencentcloud.EnvSecretKey, tencentcloud.EnvRegion, tencentcloud.EnvSessionToken}, build: newLegoTencentcloud},
+	"timewebcloud":     {keys: []string{timewebcloud.EnvPropagationTimeout, timewebcloud.EnvPollingInterval, timewebcloud.EnvHTTPTimeout, timewebcloud.EnvAuthToken}, build: newLegoTimewebcloud},
+	"ultradns":         {keys: []string{ultradns.EnvEndpoint, ultradns.EnvTTL, ultradns.EnvPropagationTimeout, ultradns.EnvPollingInterval, ultradns.EnvUsername, ultradns.EnvPassword}, build: newLegoUltradns},
+	"variomedia":       {keys: []string{variomedia.EnvTTL, variomedia.EnvPropagationTimeout, variomedia.EnvPollingInterval, variomedia.EnvSequenceInterval, variomedia.EnvHTTPTimeout, variomedia.EnvAPIToken}, build: newLegoVariomedia},
+	"vegadns":          {keys: []string{vegadns.EnvTTL, vegadns.EnvPropagationTimeout, vegadns.EnvPollingInterval, vegadns.EnvURL, vegadns.EnvKey, vegadns.EnvSecret}, build: newLegoVegadns},
+	"vercel":           {keys: []string{vercel.EnvTTL, vercel.EnvPropagationTimeout, vercel.EnvPollingInterval, vercel.EnvHTTPTimeout, vercel.EnvAuthToken, vercel.EnvTeamID}, build: newLegoVercel},
+	"vinyldns":         {keys: []string{vinyldns.EnvTTL, vinyldns.EnvPropagationTimeout, vinyldns.EnvPollingInterval, vinyldns.EnvAccessKey, vinyldns.EnvSecretKey, vinyldns.EnvHost}, build: newLegoVinyldns},
+	"volcengine":       {keys: []string{volcengine.EnvScheme, volcengine.EnvHost, volcengine.EnvRegion, volcengine.EnvTTL, volcengine.EnvPropagationTimeout, volcengine.EnvPollingInterval, volcengine.EnvHTTPTimeout, volcengine.EnvAccessKey, volcengine.EnvSecretKey}, build: newLegoVolcengine},
+	"vscale":           {keys: []string{vscale.EnvBaseURL, vscale.EnvTTL, vscale.EnvPropagationTimeout, vscale.EnvPollingInterval, vscale.EnvHTTPTimeout, vscale.EnvAPIToken}, build: newLegoVscale},
+	"vultr":            {keys: []string{vultr.EnvTTL, vultr.EnvPropagationTimeout, vultr.EnvPollingInterval, vultr.EnvHTTPTimeout, vultr.EnvAPIKey}, build: newLegoVultr},
+	"webnames":         {keys: []string{webnames.EnvPropagationTimeout, webnames.EnvPollingInterval, webnames.EnvHTTPTimeout, webnames.EnvAPIKey}, build: newLegoWebnames},
+	"websupport":       {keys: []string{websupport.EnvTTL, websupport.EnvPropagationTimeout, websupport.EnvPollingInterval, websupport.EnvHTTPTimeout, websupport.EnvAPIKey, websupport.EnvSecret}, build: newLegoWebsupport},
+	"wedos":            {keys: []string{wedos.EnvPropagationTimeout, wedos.EnvPollingInterval, wedos.EnvTTL, wedos.EnvHTTPTimeout, wedos.EnvUsername, wedos.EnvPassword}, build: newLegoWedos},
+	"westcn":           {keys: []string{westcn.EnvTTL, westcn.EnvPropagationTimeout, westcn.EnvPollingInterval, westcn.EnvHTTPTimeout, westcn.EnvUsername, westcn.EnvPassword}, build: newLegoWestcn},
+	"yandex":           {keys: []string{yandex.EnvTTL, yandex.EnvPropagationTimeout, yandex.EnvPollingInterval, yandex.EnvHTTPTimeout, yandex.EnvPddToken}, build: newLegoYandex},
+	"yandex360":        {keys: []string{yandex360.EnvTTL, yandex360.EnvPropagationTimeout, yandex360.EnvPollingInterval, yandex360.EnvHTTPTimeout, yandex360.EnvOAuthToken, yandex360.EnvOrgID}, build: newLegoYandex360},
+	"yandexcloud":      {keys: []string{yandexcloud.EnvTTL, yandexcloud.EnvPropagationTimeout, yandexcloud.EnvPollingInterval, yandexcloud.EnvIamToken, yandexcloud.EnvFolderID}, build: newLegoYandexcloud},
+	"zonomi":           {keys: []string{zonomi.EnvTTL, zonomi.EnvPropagationTimeout, zonomi.EnvPollingInterval, zonomi.EnvHTTPTimeout, zonomi.EnvAPIKey}, build: newLegoZonomi},
+}
+
+func newLegoAcmednsConfig(e *legoEnv) *acmedns.Config {
+	config := acmedns.NewDefaultConfig()
+	return config
+}
+
+func newLegoAcmedns(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(acmedns.EnvAPIBase)
+	if err != nil {
+		return nil, fmt.Errorf("acme-dns: %w", err)
+	}
+
+	config := newLegoAcmednsConfig(e)
+	config.APIBase = values[acmedns.EnvAPIBase]
+	config.StoragePath = e.GetOrFile(acmedns.EnvStoragePath)
+	config.StorageBaseURL = e.GetOrFile(acmedns.EnvStorageBaseURL)
+
+	allowList := e.GetOrFile(acmedns.EnvAllowList)
+	if allowList != "" {
+		config.AllowList = strings.Split(allowList, ",")
+	}
+
+	return acmedns.NewDNSProviderConfig(config)
+}
+
+func newLegoActive24Config(e *legoEnv) *active24.Config {
+	config := active24.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(active24.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(active24.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(active24.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(active24.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoActive24(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(active24.EnvAPIKey, active24.EnvSecret)
+	if err != nil {
+		return nil, fmt.Errorf("active24: %w", err)
+	}
+
+	config := newLegoActive24Config(e)
+	config.APIKey = values[active24.EnvAPIKey]
+	config.Secret = values[active24.EnvSecret]
+
+	return active24.NewDNSProviderConfig(config)
+}
+
+func newLegoAlidnsConfig(e *legoEnv) *alidns.Config {
+	config := alidns.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(alidns.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(alidns.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(alidns.EnvPollingInterval, config.PollingInterval)
+	config.HTTPTimeout = e.GetOrDefaultSecond(alidns.EnvHTTPTimeout, config.HTTPTimeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoAlidns(e *legoEnv) (challenge.Provider, error) {
+	config := newLegoAlidnsConfig(e)
+	config.RegionID = e.GetOrFile(alidns.EnvRegionID)
+
+	values, err := e.Get(alidns.EnvRAMRole)
+	if err == nil {
+		config.RAMRole = values[alidns.EnvRAMRole]
+		return alidns.NewDNSProviderConfig(config)
+	}
+
+	values, err = e.Get(alidns.EnvAccessKey, alidns.EnvSecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("alicloud: %w", err)
+	}
+
+	config.APIKey = values[alidns.EnvAccessKey]
+	config.SecretKey = values[alidns.EnvSecretKey]
+	config.SecurityToken = e.GetOrFile(alidns.EnvSecurityToken)
+
+	return alidns.NewDNSProviderConfig(config)
+}
+
+func newLegoAllinklConfig(e *legoEnv) *allinkl.Config {
+	config := allinkl.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(allinkl.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(allinkl.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(allinkl.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoAllinkl(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(allinkl.EnvLogin, allinkl.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("allinkl: %w", err)
+	}
+
+	config := newLegoAllinklConfig(e)
+	config.Login = values[allinkl.EnvLogin]
+	config.Password = values[allinkl.EnvPassword]
+
+	return allinkl.NewDNSProviderConfig(config)
+}
+
+func newLegoArvancloudConfig(e *legoEnv) *arvancloud.Config {
+	config := arvancloud.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(arvancloud.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(arvancloud.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(arvancloud.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(arvancloud.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoArvancloud(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(arvancloud.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("arvancloud: %w", err)
+	}
+
+	config := newLegoArvancloudConfig(e)
+	config.APIKey = values[arvancloud.EnvAPIKey]
+
+	return arvancloud.NewDNSProviderConfig(config)
+}
+
+func newLegoAuroradnsConfig(e *legoEnv) *auroradns.Config {
+	config := auroradns.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(auroradns.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(auroradns.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(auroradns.EnvPollingInterval, config.PollingInterval)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoAuroradns(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(auroradns.EnvAPIKey, auroradns.EnvSecret)
+	if err != nil {
+		return nil, fmt.Errorf("aurora: %w", err)
+	}
+
+	config := newLegoAuroradnsConfig(e)
+	config.BaseURL = e.GetOrFile(auroradns.EnvEndpoint)
+	config.APIKey = values[auroradns.EnvAPIKey]
+	config.Secret = values[auroradns.EnvSecret]
+
+	return auroradns.NewDNSProviderConfig(config)
+}
+
+func newLegoAxelnameConfig(e *legoEnv) *axelname.Config {
+	config := axelname.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(axelname.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(axelname.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(axelname.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(axelname.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoAxelname(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(axelname.EnvNickname, axelname.EnvToken)
+	if err != nil {
+		return nil, fmt.Errorf("axelname: %w", err)
+	}
+
+	config := newLegoAxelnameConfig(e)
+	config.Nickname = values[axelname.EnvNickname]
+	config.Token = values[axelname.EnvToken]
+
+	return axelname.NewDNSProviderConfig(config)
+}
+
+func newLegoBaiducloudConfig(e *legoEnv) *baiducloud.Config {
+	config := baiducloud.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(baiducloud.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(baiducloud.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(baiducloud.EnvPollingInterval, config.PollingInterval)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoBaiducloud(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(baiducloud.EnvAccessKeyID, baiducloud.EnvSecretAccessKey)
+	if err != nil {
+		return nil, fmt.Errorf("baiducloud: %w", err)
+	}
+
+	config := newLegoBaiducloudConfig(e)
+	config.AccessKeyID = values[baiducloud.EnvAccessKeyID]
+	config.SecretAccessKey = values[baiducloud.EnvSecretAccessKey]
+
+	return baiducloud.NewDNSProviderConfig(config)
+}
+
+func newLegoBindmanConfig(e *legoEnv) *bindman.Config {
+	config := bindman.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(bindman.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(bindman.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(bindman.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoBindman(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(bindman.EnvManagerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("bindman: %w", err)
+	}
+
+	config := newLegoBindmanConfig(e)
+	config.BaseURL = values[bindman.EnvManagerAddress]
+
+	return bindman.NewDNSProviderConfig(config)
+}
+
+func newLegoBluecatConfig(e *legoEnv) *bluecat.Config {
+	config := bluecat.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(bluecat.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(bluecat.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(bluecat.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(bluecat.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.Debug = e.GetOrDefaultBool(bluecat.EnvDebug, config.Debug)
+	config.SkipDeploy = e.GetOrDefaultBool(bluecat.EnvSkipDeploy, config.SkipDeploy)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoBluecat(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(bluecat.EnvServerURL, bluecat.EnvUserName, bluecat.EnvPassword, bluecat.EnvConfigName, bluecat.EnvDNSView)
+	if err != nil {
+		return nil, fmt.Errorf("bluecat: %w", err)
+	}
+
+	config := newLegoBluecatConfig(e)
+	config.BaseURL = values[bluecat.EnvServerURL]
+	config.UserName = values[bluecat.EnvUserName]
+	config.Password = values[bluecat.EnvPassword]
+	config.ConfigName = values[bluecat.EnvConfigName]
+	config.DNSView = values[bluecat.EnvDNSView]
+
+	return bluecat.NewDNSProviderConfig(config)
+}
+
+func newLegoBookmynameConfig(e *legoEnv) *bookmyname.Config {
+	config := bookmyname.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(bookmyname.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(bookmyname.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(bookmyname.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(bookmyname.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoBookmyname(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(bookmyname.EnvUsername, bookmyname.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("bookmyname: %w", err)
+	}
+
+	config := newLegoBookmynameConfig(e)
+	config.Username = values[bookmyname.EnvUsername]
+	config.Password = values[bookmyname.EnvPassword]
+
+	return bookmyname.NewDNSProviderConfig(config)
+}
+
+func newLegoBranditConfig(e *legoEnv) *brandit.Config {
+	config := brandit.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(brandit.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(brandit.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(brandit.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(brandit.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoBrandit(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(brandit.EnvAPIKey, brandit.EnvAPIUsername)
+	if err != nil {
+		return nil, fmt.Errorf("brandit: %w", err)
+	}
+
+	config := newLegoBranditConfig(e)
+	config.APIKey = values[brandit.EnvAPIKey]
+	config.APIUsername = values[brandit.EnvAPIUsername]
+
+	return brandit.NewDNSProviderConfig(config)
+}
+
+func newLegoBunnyConfig(e *legoEnv) *bunny.Config {
+	config := bunny.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(bunny.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(bunny.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(bunny.EnvPollingInterval, config.PollingInterval)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoBunny(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(bunny.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("bunny: %w", err)
+	}
+
+	config := newLegoBunnyConfig(e)
+	config.APIKey = values[bunny.EnvAPIKey]
+
+	return bunny.NewDNSProviderConfig(config)
+}
+
+func newLegoCivoConfig(e *legoEnv) *civo.Config {
+	config := civo.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(civo.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(civo.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(civo.EnvPollingInterval, config.PollingInterval)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoCivo(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(civo.EnvAPIToken)
+	if err != nil {
+		return nil, fmt.Errorf("civo: %w", err)
+	}
+
+	config := newLegoCivoConfig(e)
+	config.Token = values[civo.EnvAPIToken]
+
+	return civo.NewDNSProviderConfig(config)
+}
+
+func newLegoClouddnsConfig(e *legoEnv) *clouddns.Config {
+	config := clouddns.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(clouddns.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(clouddns.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(clouddns.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(clouddns.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoClouddns(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(clouddns.EnvClientID, clouddns.EnvEmail, clouddns.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("clouddns: %w", err)
+	}
+
+	config := newLegoClouddnsConfig(e)
+	config.ClientID = values[clouddns.EnvClientID]
+	config.Email = values[clouddns.EnvEmail]
+	config.Password = values[clouddns.EnvPassword]
+
+	return clouddns.NewDNSProviderConfig(config)
+}
+
+func newLegoCloudnsConfig(e *legoEnv) *cloudns.Config {
+	config := cloudns.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(cloudns.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(cloudns.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(cloudns.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(cloudns.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoCloudns(e *legoEnv) (challenge.Provider, error) {
+	var subAuthID string
+	authID := e.GetOrFile(cloudns.EnvAuthID)
+	if authID == "" {
+		subAuthID = e.GetOrFile(cloudns.EnvSubAuthID)
+	}
+
+	if authID == "" && subAuthID == "" {
+		return nil, fmt.Errorf("ClouDNS: some credentials information are missing: %s or %s", cloudns.EnvAuthID, cloudns.EnvSubAuthID)
+	}
+
+	values, err := e.Get(cloudns.EnvAuthPassword)
+	if err != nil {
+		return nil, fmt.Errorf("ClouDNS: %w", err)
+	}
+
+	config := newLegoCloudnsConfig(e)
+	config.AuthID = authID
+	config.SubAuthID = subAuthID
+	config.AuthPassword = values[cloudns.EnvAuthPassword]
+
+	return cloudns.NewDNSProviderConfig(config)
+}
+
+func newLegoCloudruConfig(e *legoEnv) *cloudru.Config {
+	config := cloudru.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(cloudru.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(cloudru.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(cloudru.EnvPollingInterval, config.PollingInterval)
+	config.SequenceInterval = e.GetOrDefaultSecond(cloudru.EnvSequenceInterval, config.SequenceInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(cloudru.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoCloudru(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(cloudru.EnvServiceInstanceID, cloudru.EnvKeyID, cloudru.EnvSecret)
+	if err != nil {
+		return nil, fmt.Errorf("cloudru: %w", err)
+	}
+
+	config := newLegoCloudruConfig(e)
+	config.ServiceInstanceID = values[cloudru.EnvServiceInstanceID]
+	config.KeyID = values[cloudru.EnvKeyID]
+	config.Secret = values[cloudru.EnvSecret]
+
+	return cloudru.NewDNSProviderConfig(config)
+}
+
+func newLegoCloudxnsConfig(e *legoEnv) *cloudxns.Config {
+	config := cloudxns.NewDefaultConfig()
+	return config
+}
+
+func newLegoCloudxns(e *legoEnv) (challenge.Provider, error) {
+	return cloudxns.NewDNSProviderConfig(&cloudxns.Config{})
+}
+
+func newLegoConohaConfig(e *legoEnv) *conoha.Config {
+	config := conoha.NewDefaultConfig()
+	config.Region = e.GetOrDefaultString(conoha.EnvRegion, config.Region)
+	config.TTL = e.GetOrDefaultInt(conoha.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(conoha.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(conoha.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(conoha.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoConoha(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(conoha.EnvTenantID, conoha.EnvAPIUsername, conoha.EnvAPIPassword)
+	if err != nil {
+		return nil, fmt.Errorf("conoha: %w", err)
+	}
+
+	config := newLegoConohaConfig(e)
+	config.TenantID = values[conoha.EnvTenantID]
+	config.Username = values[conoha.EnvAPIUsername]
+	config.Password = values[conoha.EnvAPIPassword]
+
+	return conoha.NewDNSProviderConfig(config)
+}
+
+func newLegoConstellixConfig(e *legoEnv) *constellix.Config {
+	config := constellix.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(constellix.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(constellix.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(constellix.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(constellix.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoConstellix(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(constellix.EnvAPIKey, constellix.EnvSecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("constellix: %w", err)
+	}
+
+	config := newLegoConstellixConfig(e)
+	config.APIKey = values[constellix.EnvAPIKey]
+	config.SecretKey = values[constellix.EnvSecretKey]
+
+	return constellix.NewDNSProviderConfig(config)
+}
+
+func newLegoCorenetworksConfig(e *legoEnv) *corenetworks.Config {
+	config := corenetworks.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(corenetworks.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(corenetworks.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(corenetworks.EnvPollingInterval, config.PollingInterval)
+	config.SequenceInterval = e.GetOrDefaultSecond(corenetworks.EnvSequenceInterval, config.SequenceInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(corenetworks.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoCorenetworks(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(corenetworks.EnvLogin, corenetworks.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("corenetworks: %w", err)
+	}
+
+	config := newLegoCorenetworksConfig(e)
+	config.Login = values[corenetworks.EnvLogin]
+	config.Password = values[corenetworks.EnvPassword]
+
+	return corenetworks.NewDNSProviderConfig(config)
+}
+
+func newLegoCpanelConfig(e *legoEnv) *cpanel.Config {
+	config := cpanel.NewDefaultConfig()
+	config.Mode = e.GetOrDefaultString(cpanel.EnvMode, config.Mode)
+	config.TTL = e.GetOrDefaultInt(cpanel.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(cpanel.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(cpanel.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(cpanel.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoCpanel(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(cpanel.EnvUsername, cpanel.EnvToken, cpanel.EnvBaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("cpanel: %w", err)
+	}
+
+	config := newLegoCpanelConfig(e)
+	config.Username = values[cpanel.EnvUsername]
+	config.Token = values[cpanel.EnvToken]
+	config.BaseURL = values[cpanel.EnvBaseURL]
+
+	return cpanel.NewDNSProviderConfig(config)
+}
+
+func newLegoDerakConfig(e *legoEnv) *derak.Config {
+	config := derak.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(derak.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(derak.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(derak.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(derak.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoDerak(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(derak.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("derak: %w", err)
+	}
+
+	config := newLegoDerakConfig(e)
+	config.APIKey = values[derak.EnvAPIKey]
+	config.WebsiteID = e.GetOrDefaultString(derak.EnvWebsiteID, "")
+
+	return derak.NewDNSProviderConfig(config)
+}
+
+func newLegoDesecConfig(e *legoEnv) *desec.Config {
+	config := desec.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(desec.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(desec.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(desec.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(desec.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoDesec(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(desec.EnvToken)
+	if err != nil {
+		return nil, fmt.Errorf("desec: %w", err)
+	}
+
+	config := newLegoDesecConfig(e)
+	config.Token = values[desec.EnvToken]
+
+	return desec.NewDNSProviderConfig(config)
+}
+
+func newLegoDigitaloceanConfig(e *legoEnv) *digitalocean.Config {
+	config := digitalocean.NewDefaultConfig()
+	config.BaseURL = e.GetOrDefaultString(digitalocean.EnvAPIUrl, config.BaseURL)
+	config.TTL = e.GetOrDefaultInt(digitalocean.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(digitalocean.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(digitalocean.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(digitalocean.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoDigitalocean(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(digitalocean.EnvAuthToken)
+	if err != nil {
+		return nil, fmt.Errorf("digitalocean: %w", err)
+	}
+
+	config := newLegoDigitaloceanConfig(e)
+	config.AuthToken = values[digitalocean.EnvAuthToken]
+
+	return digitalocean.NewDNSProviderConfig(config)
+}
+
+func newLegoDirectadminConfig(e *legoEnv) *directadmin.Config {
+	config := directadmin.NewDefaultConfig()
+	config.ZoneName = e.GetOrFile(directadmin.EnvZoneName)
+	config.TTL = e.GetOrDefaultInt(directadmin.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(directadmin.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(directadmin.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(directadmin.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoDirectadmin(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(directadmin.EnvAPIURL, directadmin.EnvUsername, directadmin.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("directadmin: %w", err)
+	}
+
+	config := newLegoDirectadminConfig(e)
+	config.BaseURL = values[directadmin.EnvAPIURL]
+	config.Username = values[directadmin.EnvUsername]
+	config.Password = values[directadmin.EnvPassword]
+
+	return directadmin.NewDNSProviderConfig(config)
+}
+
+func newLegoDnsimpleConfig(e *legoEnv) *dnsimple.Config {
+	config := dnsimple.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(dnsimple.EnvTTL, config.TTL)
+	config.Debug = e.GetOrDefaultBool(dnsimple.EnvDebug, config.Debug)
+	config.PropagationTimeout = e.GetOrDefaultSecond(dnsimple.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(dnsimple.EnvPollingInterval, config.PollingInterval)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoDnsimple(e *legoEnv) (challenge.Provider, error) {
+	config := newLegoDnsimpleConfig(e)
+	config.AccessToken = e.GetOrFile(dnsimple.EnvOAuthToken)
+	config.BaseURL = e.GetOrFile(dnsimple.EnvBaseURL)
+
+	return dnsimple.NewDNSProviderConfig(config)
+}
+
+func newLegoDnspodConfig(e *legoEnv) *dnspod.Config {
+	config := dnspod.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(dnspod.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(dnspod.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(dnspod.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(dnspod.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoDnspod(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(dnspod.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("dnspod: %w", err)
+	}
+
+	config := newLegoDnspodConfig(e)
+	config.LoginToken = values[dnspod.EnvAPIKey]
+
+	return dnspod.NewDNSProviderConfig(config)
+}
+
+func newLegoDodeConfig(e *legoEnv) *dode.Config {
+	config := dode.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(dode.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(dode.EnvPollingInterval, config.PollingInterval)
+	config.SequenceInterval = e.GetOrDefaultSecond(dode.EnvSequenceInterval, config.SequenceInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(dode.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoDode(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(dode.EnvToken)
+	if err != nil {
+		return nil, fmt.Errorf("do.de: %w", err)
+	}
+
+	config := newLegoDodeConfig(e)
+	config.Token = values[dode.EnvToken]
+
+	return dode.NewDNSProviderConfig(config)
+}
+
+func newLegoDomeneshopConfig(e *legoEnv) *domeneshop.Config {
+	config := domeneshop.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(domeneshop.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(domeneshop.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(domeneshop.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoDomeneshop(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(domeneshop.EnvAPIToken, domeneshop.EnvAPISecret)
+	if err != nil {
+		return nil, fmt.Errorf("domeneshop: %w", err)
+	}
+
+	config := newLegoDomeneshopConfig(e)
+	config.APIToken = values[domeneshop.EnvAPIToken]
+	config.APISecret = values[domeneshop.EnvAPISecret]
+
+	return domeneshop.NewDNSProviderConfig(config)
+}
+
+func newLegoDreamhostConfig(e *legoEnv) *dreamhost.Config {
+	config := dreamhost.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(dreamhost.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(dreamhost.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(dreamhost.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoDreamhost(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(dreamhost.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("dreamhost: %w", err)
+	}
+
+	config := newLegoDreamhostConfig(e)
+	config.APIKey = values[dreamhost.EnvAPIKey]
+
+	return dreamhost.NewDNSProviderConfig(config)
+}
+
+func newLegoDuckdnsConfig(e *legoEnv) *duckdns.Config {
+	config := duckdns.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(duckdns.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(duckdns.EnvPollingInterval, config.PollingInterval)
+	config.SequenceInterval = e.GetOrDefaultSecond(duckdns.EnvSequenceInterval, config.SequenceInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(duckdns.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoDuckdns(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(duckdns.EnvToken)
+	if err != nil {
+		return nil, fmt.Errorf("duckdns: %w", err)
+	}
+
+	config := newLegoDuckdnsConfig(e)
+	config.Token = values[duckdns.EnvToken]
+
+	return duckdns.NewDNSProviderConfig(config)
+}
+
+func newLegoDynConfig(e *legoEnv) *dyn.Config {
+	config := dyn.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(dyn.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(dyn.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(dyn.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(dyn.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoDyn(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(dyn.EnvCustomerName, dyn.EnvUserName, dyn.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("dyn: %w", err)
+	}
+
+	config := newLegoDynConfig(e)
+	config.CustomerName = values[dyn.EnvCustomerName]
+	config.UserName = values[dyn.EnvUserName]
+	config.Password = values[dyn.EnvPassword]
+
+	return dyn.NewDNSProviderConfig(config)
+}
+
+func newLegoDynuConfig(e *legoEnv) *dynu.Config {
+	config := dynu.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(dynu.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(dynu.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(dynu.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(dynu.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoDynu(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(dynu.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("dynu: %w", err)
+	}
+
+	config := newLegoDynuConfig(e)
+	config.APIKey = values[dynu.EnvAPIKey]
+
+	return dynu.NewDNSProviderConfig(config)
+}
+
+func newLegoEfficientipConfig(e *legoEnv) *efficientip.Config {
+	config := efficientip.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(efficientip.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(efficientip.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(efficientip.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoEfficientip(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(efficientip.EnvUsername, efficientip.EnvPassword, efficientip.EnvHostname, efficientip.EnvDNSName)
+	if err != nil {
+		return nil, fmt.Errorf("efficientip: %w", err)
+	}
+
+	config := newLegoEfficientipConfig(e)
+	config.Username = values[efficientip.EnvUsername]
+	config.Password = values[efficientip.EnvPassword]
+	config.Hostname = values[efficientip.EnvHostname]
+	config.DNSName = values[efficientip.EnvDNSName]
+	config.ViewName = e.GetOrDefaultString(efficientip.EnvViewName, "")
+	config.InsecureSkipVerify = e.GetOrDefaultBool(efficientip.EnvInsecureSkipVerify, false)
+
+	return efficientip.NewDNSProviderConfig(config)
+}
+
+func newLegoEpikConfig(e *legoEnv) *epik.Config {
+	config := epik.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(epik.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(epik.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(epik.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(epik.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoEpik(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(epik.EnvSignature)
+	if err != nil {
+		return nil, fmt.Errorf("epik: %w", err)
+	}
+
+	config := newLegoEpikConfig(e)
+	config.Signature = values[epik.EnvSignature]
+
+	return epik.NewDNSProviderConfig(config)
+}
+
+func newLegoF5xcConfig(e *legoEnv) *f5xc.Config {
+	config := f5xc.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(f5xc.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(f5xc.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(f5xc.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(f5xc.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoF5xc(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(f5xc.EnvToken, f5xc.EnvTenantName, f5xc.EnvGroupName)
+	if err != nil {
+		return nil, fmt.Errorf("f5xc: %w", err)
+	}
+
+	config := newLegoF5xcConfig(e)
+	config.APIToken = values[f5xc.EnvToken]
+	config.TenantName = values[f5xc.EnvTenantName]
+	config.GroupName = values[f5xc.EnvGroupName]
+
+	return f5xc.NewDNSProviderConfig(config)
+}
+
+func newLegoFreemyipConfig(e *legoEnv) *freemyip.Config {
+	config := freemyip.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(freemyip.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(freemyip.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(freemyip.EnvPollingInterval, config.PollingInterval)
+	config.SequenceInterval = e.GetOrDefaultSecond(freemyip.EnvSequenceInterval, config.SequenceInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(freemyip.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoFreemyip(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(freemyip.EnvToken)
+	if err != nil {
+		return nil, fmt.Errorf("freemyip: %w", err)
+	}
+
+	config := newLegoFreemyipConfig(e)
+	config.Token = values[freemyip.EnvToken]
+
+	return freemyip.NewDNSProviderConfig(config)
+}
+
+func newLegoGandiConfig(e *legoEnv) *gandi.Config {
+	config := gandi.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(gandi.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(gandi.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(gandi.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(gandi.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoGandi(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(gandi.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("gandi: %w", err)
+	}
+
+	config := newLegoGandiConfig(e)
+	config.APIKey = values[gandi.EnvAPIKey]
+
+	return gandi.NewDNSProviderConfig(config)
+}
+
+func newLegoGandiv5Config(e *legoEnv) *gandiv5.Config {
+	config := gandiv5.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(gandiv5.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(gandiv5.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(gandiv5.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(gandiv5.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoGandiv5(e *legoEnv) (challenge.Provider, error) {
+
+	config := newLegoGandiv5Config(e)
+	config.APIKey = e.GetOrFile(gandiv5.EnvAPIKey)
+	config.PersonalAccessToken = e.GetOrFile(gandiv5.EnvPersonalAccessToken)
+
+	return gandiv5.NewDNSProviderConfig(config)
+}
+
+func newLegoGcoreConfig(e *legoEnv) *gcore.Config {
+	config := gcore.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(gcore.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(gcore.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(gcore.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(gcore.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoGcore(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(gcore.EnvPermanentAPIToken)
+	if err != nil {
+		return nil, fmt.Errorf("gcore: %w", err)
+	}
+
+	config := newLegoGcoreConfig(e)
+	config.APIToken = values[gcore.EnvPermanentAPIToken]
+
+	return gcore.NewDNSProviderConfig(config)
+}
+
+func newLegoGlesysConfig(e *legoEnv) *glesys.Config {
+	config := glesys.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(glesys.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(glesys.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(glesys.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(glesys.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoGlesys(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(glesys.EnvAPIUser, glesys.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("glesys: %w", err)
+	}
+
+	config := newLegoGlesysConfig(e)
+	config.APIUser = values[glesys.EnvAPIUser]
+	config.APIKey = values[glesys.EnvAPIKey]
+
+	return glesys.NewDNSProviderConfig(config)
+}
+
+func newLegoGodaddyConfig(e *legoEnv) *godaddy.Config {
+	config := godaddy.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(godaddy.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(godaddy.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(godaddy.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(godaddy.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoGodaddy(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(godaddy.EnvAPIKey, godaddy.EnvAPISecret)
+	if err != nil {
+		return nil, fmt.Errorf("godaddy: %w", err)
+	}
+
+	config := newLegoGodaddyConfig(e)
+	config.APIKey = values[godaddy.EnvAPIKey]
+	config.APISecret = values[godaddy.EnvAPISecret]
+
+	return godaddy.NewDNSProviderConfig(config)
+}
+
+func newLegoGoogledomainsConfig(e *legoEnv) *googledomains.Config {
+	config := googledomains.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(googledomains.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(googledomains.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(googledomains.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoGoogledomains(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(googledomains.EnvAccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("googledomains: %w", err)
+	}
+
+	config := newLegoGoogledomainsConfig(e)
+	config.AccessToken = values[googledomains.EnvAccessToken]
+
+	return googledomains.NewDNSProviderConfig(config)
+}
+
+func newLegoHetznerConfig(e *legoEnv) *hetzner.Config {
+	config := hetzner.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(hetzner.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(hetzner.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(hetzner.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(hetzner.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoHetzner(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(hetzner.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("hetzner: %w", err)
+	}
+
+	config := newLegoHetznerConfig(e)
+	config.APIKey = values[hetzner.EnvAPIKey]
+
+	return hetzner.NewDNSProviderConfig(config)
+}
+
+func newLegoHostingdeConfig(e *legoEnv) *hostingde.Config {
+	config := hostingde.NewDefaultConfig()
+	config.ZoneName = e.GetOrFile(hostingde.EnvZoneName)
+	config.TTL = e.GetOrDefaultInt(hostingde.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(hostingde.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(hostingde.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(hostingde.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoHostingde(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(hostingde.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("hostingde: %w", err)
+	}
+
+	config := newLegoHostingdeConfig(e)
+	config.APIKey = values[hostingde.EnvAPIKey]
+
+	return hostingde.NewDNSProviderConfig(config)
+}
+
+func newLegoHosttechConfig(e *legoEnv) *hosttech.Config {
+	config := hosttech.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(hosttech.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(hosttech.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(hosttech.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(hosttech.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoHosttech(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(hosttech.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("hosttech: %w", err)
+	}
+
+	config := newLegoHosttechConfig(e)
+	config.APIKey = values[hosttech.EnvAPIKey]
+
+	return hosttech.NewDNSProviderConfig(config)
+}
+
+func newLegoHttpnetConfig(e *legoEnv) *httpnet.Config {
+	config := httpnet.NewDefaultConfig()
+	config.ZoneName = e.GetOrFile(httpnet.EnvZoneName)
+	config.TTL = e.GetOrDefaultInt(httpnet.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(httpnet.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(httpnet.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(httpnet.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoHttpnet(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(httpnet.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("httpnet: %w", err)
+	}
+
+	config := newLegoHttpnetConfig(e)
+	config.APIKey = values[httpnet.EnvAPIKey]
+
+	return httpnet.NewDNSProviderConfig(config)
+}
+
+func newLegoHttpreqConfig(e *legoEnv) *httpreq.Config {
+	config := httpreq.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(httpreq.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(httpreq.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(httpreq.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoHttpreq(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(httpreq.EnvEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("httpreq: %w", err)
+	}
+
+	endpoint, err := url.Parse(values[httpreq.EnvEndpoint])
+	if err != nil {
+		return nil, fmt.Errorf("httpreq: %w", err)
+	}
+
+	config := newLegoHttpreqConfig(e)
+	config.Mode = e.GetOrFile(httpreq.EnvMode)
+	config.Username = e.GetOrFile(httpreq.EnvUsername)
+	config.Password = e.GetOrFile(httpreq.EnvPassword)
+	config.Endpoint = endpoint
+	return httpreq.NewDNSProviderConfig(config)
+}
+
+func newLegoHyperoneConfig(e *legoEnv) *hyperone.Config {
+	config := hyperone.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(hyperone.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(hyperone.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(hyperone.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(hyperone.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoHyperone(e *legoEnv) (challenge.Provider, error) {
+	config := newLegoHyperoneConfig(e)
+
+	config.PassportLocation = e.GetOrFile(hyperone.EnvPassportLocation)
+	config.LocationID = e.GetOrFile(hyperone.EnvLocationID)
+	config.APIEndpoint = e.GetOrFile(hyperone.EnvAPIUrl)
+
+	return hyperone.NewDNSProviderConfig(config)
+}
+
+func newLegoIbmcloudConfig(e *legoEnv) *ibmcloud.Config {
+	config := ibmcloud.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(ibmcloud.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(ibmcloud.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(ibmcloud.EnvPollingInterval, config.PollingInterval)
+	config.HTTPTimeout = e.GetOrDefaultSecond(ibmcloud.EnvHTTPTimeout, config.HTTPTimeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoIbmcloud(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(ibmcloud.EnvUsername, ibmcloud.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("ibmcloud: %w", err)
+	}
+
+	config := newLegoIbmcloudConfig(e)
+	config.Username = values[ibmcloud.EnvUsername]
+	config.APIKey = values[ibmcloud.EnvAPIKey]
+	config.Debug = e.GetOrDefaultBool(ibmcloud.EnvDebug, false)
+
+	return ibmcloud.NewDNSProviderConfig(config)
+}
+
+func newLegoIijConfig(e *legoEnv) *iij.Config {
+	config := iij.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(iij.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(iij.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(iij.EnvPollingInterval, config.PollingInterval)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoIij(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(iij.EnvAPIAccessKey, iij.EnvAPISecretKey, iij.EnvDoServiceCode)
+	if err != nil {
+		return nil, fmt.Errorf("iij: %w", err)
+	}
+
+	config := newLegoIijConfig(e)
+	config.AccessKey = values[iij.EnvAPIAccessKey]
+	config.SecretKey = values[iij.EnvAPISecretKey]
+	config.DoServiceCode = values[iij.EnvDoServiceCode]
+
+	return iij.NewDNSProviderConfig(config)
+}
+
+func newLegoIijdpfConfig(e *legoEnv) *iijdpf.Config {
+	config := iijdpf.NewDefaultConfig()
+	config.Endpoint = e.GetOrDefaultString(iijdpf.EnvAPIEndpoint, config.Endpoint)
+	config.PropagationTimeout = e.GetOrDefaultSecond(iijdpf.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(iijdpf.EnvPollingInterval, config.PollingInterval)
+	config.TTL = e.GetOrDefaultInt(iijdpf.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoIijdpf(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(iijdpf.EnvAPIToken, iijdpf.EnvServiceCode)
+	if err != nil {
+		return nil, fmt.Errorf("iijdpf: %w", err)
+	}
+
+	config := newLegoIijdpfConfig(e)
+	config.Token = values[iijdpf.EnvAPIToken]
+	config.ServiceCode = values[iijdpf.EnvServiceCode]
+
+	return iijdpf.NewDNSProviderConfig(config)
+}
+
+func newLegoInfobloxConfig(e *legoEnv) *infoblox.Config {
+	config := infoblox.NewDefaultConfig()
+	config.DNSView = e.GetOrDefaultString(infoblox.EnvDNSView, config.DNSView)
+	config.WapiVersion = e.GetOrDefaultString(infoblox.EnvWApiVersion, config.WapiVersion)
+	config.Port = e.GetOrDefaultString(infoblox.EnvPort, config.Port)
+	config.SSLVerify = e.GetOrDefaultBool(infoblox.EnvSSLVerify, config.SSLVerify)
+	config.CACertificate = e.GetOrDefaultString(infoblox.EnvCACertificate, config.CACertificate)
+	config.TTL = e.GetOrDefaultInt(infoblox.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(infoblox.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(infoblox.EnvPollingInterval, config.PollingInterval)
+	config.HTTPTimeout = e.GetOrDefaultInt(infoblox.EnvHTTPTimeout, config.HTTPTimeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoInfoblox(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(infoblox.EnvHost, infoblox.EnvUsername, infoblox.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("infoblox: %w", err)
+	}
+
+	config := newLegoInfobloxConfig(e)
+	config.Host = values[infoblox.EnvHost]
+	config.Username = values[infoblox.EnvUsername]
+	config.Password = values[infoblox.EnvPassword]
+
+	return infoblox.NewDNSProviderConfig(config)
+}
+
+func newLegoInfomaniakConfig(e *legoEnv) *infomaniak.Config {
+	config := infomaniak.NewDefaultConfig()
+	config.APIEndpoint = e.GetOrDefaultString(infomaniak.EnvEndpoint, config.APIEndpoint)
+	config.TTL = e.GetOrDefaultInt(infomaniak.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(infomaniak.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(infomaniak.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(infomaniak.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoInfomaniak(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(infomaniak.EnvAccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("infomaniak: %w", err)
+	}
+
+	config := newLegoInfomaniakConfig(e)
+	config.AccessToken = values[infomaniak.EnvAccessToken]
+
+	return infomaniak.NewDNSProviderConfig(config)
+}
+
+func newLegoInternetbsConfig(e *legoEnv) *internetbs.Config {
+	config := internetbs.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(internetbs.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(internetbs.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(internetbs.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(internetbs.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoInternetbs(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(internetbs.EnvAPIKey, internetbs.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("internetbs: %w", err)
+	}
+
+	config := newLegoInternetbsConfig(e)
+	config.APIKey = values[internetbs.EnvAPIKey]
+	config.Password = values[internetbs.EnvPassword]
+
+	return internetbs.NewDNSProviderConfig(config)
+}
+
+func newLegoInwxConfig(e *legoEnv) *inwx.Config {
+	config := inwx.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(inwx.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(inwx.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(inwx.EnvPollingInterval, config.PollingInterval)
+	config.Sandbox = e.GetOrDefaultBool(inwx.EnvSandbox, config.Sandbox)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoInwx(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(inwx.EnvUsername, inwx.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("inwx: %w", err)
+	}
+
+	config := newLegoInwxConfig(e)
+	config.Username = values[inwx.EnvUsername]
+	config.Password = values[inwx.EnvPassword]
+	config.SharedSecret = e.GetOrFile(inwx.EnvSharedSecret)
+
+	return inwx.NewDNSProviderConfig(config)
+}
+
+func newLegoIonosConfig(e *legoEnv) *ionos.Config {
+	config := ionos.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(ionos.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(ionos.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(ionos.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(ionos.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoIonos(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(ionos.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("ionos: %w", err)
+	}
+
+	config := newLegoIonosConfig(e)
+	config.APIKey = values[ionos.EnvAPIKey]
+
+	return ionos.NewDNSProviderConfig(config)
+}
+
+func newLegoIpv64Config(e *legoEnv) *ipv64.Config {
+	config := ipv64.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(ipv64.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(ipv64.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(ipv64.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoIpv64(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(ipv64.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("ipv64: %w", err)
+	}
+
+	config := newLegoIpv64Config(e)
+	config.APIKey = values[ipv64.EnvAPIKey]
+
+	return ipv64.NewDNSProviderConfig(config)
+}
+
+func newLegoIwantmynameConfig(e *legoEnv) *iwantmyname.Config {
+	config := iwantmyname.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(iwantmyname.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(iwantmyname.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(iwantmyname.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(iwantmyname.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoIwantmyname(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(iwantmyname.EnvUsername, iwantmyname.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("iwantmyname: %w", err)
+	}
+
+	config := newLegoIwantmynameConfig(e)
+	config.Username = values[iwantmyname.EnvUsername]
+	config.Password = values[iwantmyname.EnvPassword]
+
+	return iwantmyname.NewDNSProviderConfig(config)
+}
+
+func newLegoLiaraConfig(e *legoEnv) *liara.Config {
+	config := liara.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(liara.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(liara.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(liara.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(liara.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoLiara(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(liara.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("liara: %w", err)
+	}
+
+	config := newLegoLiaraConfig(e)
+	config.APIKey = values[liara.EnvAPIKey]
+
+	return liara.NewDNSProviderConfig(config)
+}
+
+func newLegoLimacityConfig(e *legoEnv) *limacity.Config {
+	config := limacity.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(limacity.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(limacity.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(limacity.EnvPollingInterval, config.PollingInterval)
+	config.SequenceInterval = e.GetOrDefaultSecond(limacity.EnvSequenceInterval, config.SequenceInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(limacity.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoLimacity(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(limacity.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("limacity: %w", err)
+	}
+
+	config := newLegoLimacityConfig(e)
+	config.APIKey = values[limacity.EnvAPIKey]
+
+	return limacity.NewDNSProviderConfig(config)
+}
+
+func newLegoLinodeConfig(e *legoEnv) *linode.Config {
+	config := linode.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(linode.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(linode.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(linode.EnvPollingInterval, config.PollingInterval)
+	config.HTTPTimeout = e.GetOrDefaultSecond(linode.EnvHTTPTimeout, config.HTTPTimeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoLinode(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(linode.EnvToken)
+	if err != nil {
+		return nil, fmt.Errorf("linode: %w", err)
+	}
+
+	config := newLegoLinodeConfig(e)
+	config.Token = values[linode.EnvToken]
+
+	return linode.NewDNSProviderConfig(config)
+}
+
+func newLegoLuadnsConfig(e *legoEnv) *luadns.Config {
+	config := luadns.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(luadns.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(luadns.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(luadns.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(luadns.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoLuadns(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(luadns.EnvAPIUsername, luadns.EnvAPIToken)
+	if err != nil {
+		return nil, fmt.Errorf("luadns: %w", err)
+	}
+
+	config := newLegoLuadnsConfig(e)
+	config.APIUsername = values[luadns.EnvAPIUsername]
+	config.APIToken = values[luadns.EnvAPIToken]
+
+	return luadns.NewDNSProviderConfig(config)
+}
+
+func newLegoMailinaboxConfig(e *legoEnv) *mailinabox.Config {
+	config := mailinabox.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(mailinabox.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(mailinabox.EnvPollingInterval, config.PollingInterval)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoMailinabox(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(mailinabox.EnvBaseURL, mailinabox.EnvEmail, mailinabox.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("mailinabox: %w", err)
+	}
+
+	config := newLegoMailinaboxConfig(e)
+	config.BaseURL = values[mailinabox.EnvBaseURL]
+	config.Email = values[mailinabox.EnvEmail]
+	config.Password = values[mailinabox.EnvPassword]
+
+	return mailinabox.NewDNSProviderConfig(config)
+}
+
+func newLegoManageengineConfig(e *legoEnv) *manageengine.Config {
+	config := manageengine.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(manageengine.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(manageengine.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(manageengine.EnvPollingInterval, config.PollingInterval)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoManageengine(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(manageengine.EnvClientID, manageengine.EnvClientSecret)
+	if err != nil {
+		return nil, fmt.Errorf("manageengine: %w", err)
+	}
+
+	config := newLegoManageengineConfig(e)
+	config.ClientID = values[manageengine.EnvClientID]
+	config.ClientSecret = values[manageengine.EnvClientSecret]
+
+	return manageengine.NewDNSProviderConfig(config)
+}
+
+func newLegoMetanameConfig(e *legoEnv) *metaname.Config {
+	config := metaname.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(metaname.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(metaname.EnvPollingInterval, config.PollingInterval)
+	config.TTL = e.GetOrDefaultInt(metaname.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoMetaname(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(metaname.EnvAccountReference, metaname.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("metaname: %w", err)
+	}
+
+	config := newLegoMetanameConfig(e)
+	config.AccountReference = values[metaname.EnvAccountReference]
+	config.APIKey = values[metaname.EnvAPIKey]
+
+	return metaname.NewDNSProviderConfig(config)
+}
+
+func newLegoMetaregistrarConfig(e *legoEnv) *metaregistrar.Config {
+	config := metaregistrar.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(metaregistrar.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(metaregistrar.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(metaregistrar.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(metaregistrar.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoMetaregistrar(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(metaregistrar.EnvToken)
+	if err != nil {
+		return nil, fmt.Errorf("metaregistrar: %w", err)
+	}
+
+	config := newLegoMetaregistrarConfig(e)
+	config.APIToken = values[metaregistrar.EnvToken]
+
+	return metaregistrar.NewDNSProviderConfig(config)
+}
+
+func newLegoMijnhostConfig(e *legoEnv) *mijnhost.Config {
+	config := mijnhost.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(mijnhost.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(mijnhost.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(mijnhost.EnvPollingInterval, config.PollingInterval)
+	config.SequenceInterval = e.GetOrDefaultSecond(mijnhost.EnvSequenceInterval, config.SequenceInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(mijnhost.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoMijnhost(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(mijnhost.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("mijnhost: %w", err)
+	}
+
+	config := newLegoMijnhostConfig(e)
+	config.APIKey = values[mijnhost.EnvAPIKey]
+
+	return mijnhost.NewDNSProviderConfig(config)
+}
+
+func newLegoMittwaldConfig(e *legoEnv) *mittwald.Config {
+	config := mittwald.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(mittwald.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(mittwald.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(mittwald.EnvPollingInterval, config.PollingInterval)
+	config.SequenceInterval = e.GetOrDefaultSecond(mittwald.EnvSequenceInterval, config.SequenceInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(mittwald.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoMittwald(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(mittwald.EnvToken)
+	if err != nil {
+		return nil, fmt.Errorf("mittwald: %w", err)
+	}
+
+	config := newLegoMittwaldConfig(e)
+	config.Token = values[mittwald.EnvToken]
+
+	return mittwald.NewDNSProviderConfig(config)
+}
+
+func newLegoMydnsjpConfig(e *legoEnv) *mydnsjp.Config {
+	config := mydnsjp.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(mydnsjp.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(mydnsjp.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(mydnsjp.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoMydnsjp(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(mydnsjp.EnvMasterID, mydnsjp.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("mydnsjp: %w", err)
+	}
+
+	config := newLegoMydnsjpConfig(e)
+	config.MasterID = values[mydnsjp.EnvMasterID]
+	config.Password = values[mydnsjp.EnvPassword]
+
+	return mydnsjp.NewDNSProviderConfig(config)
+}
+
+func newLegoNamedotcomConfig(e *legoEnv) *namedotcom.Config {
+	config := namedotcom.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(namedotcom.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(namedotcom.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(namedotcom.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(namedotcom.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoNamedotcom(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(namedotcom.EnvUsername, namedotcom.EnvAPIToken)
+	if err != nil {
+		return nil, fmt.Errorf("namedotcom: %w", err)
+	}
+
+	config := newLegoNamedotcomConfig(e)
+	config.Username = values[namedotcom.EnvUsername]
+	config.APIToken = values[namedotcom.EnvAPIToken]
+	config.Server = e.GetOrFile(namedotcom.EnvServer)
+
+	return namedotcom.NewDNSProviderConfig(config)
+}
+
+func newLegoNamesiloConfig(e *legoEnv) *namesilo.Config {
+	config := namesilo.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(namesilo.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(namesilo.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(namesilo.EnvPollingInterval, config.PollingInterval)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoNamesilo(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(namesilo.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("namesilo: %w", err)
+	}
+
+	config := newLegoNamesiloConfig(e)
+	config.APIKey = values[namesilo.EnvAPIKey]
+
+	return namesilo.NewDNSProviderConfig(config)
+}
+
+func newLegoNearlyfreespeechConfig(e *legoEnv) *nearlyfreespeech.Config {
+	config := nearlyfreespeech.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(nearlyfreespeech.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(nearlyfreespeech.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(nearlyfreespeech.EnvPollingInterval, config.PollingInterval)
+	config.SequenceInterval = e.GetOrDefaultSecond(nearlyfreespeech.EnvSequenceInterval, config.SequenceInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(nearlyfreespeech.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoNearlyfreespeech(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(nearlyfreespeech.EnvAPIKey, nearlyfreespeech.EnvLogin)
+	if err != nil {
+		return nil, fmt.Errorf("nearlyfreespeech: %w", err)
+	}
+
+	config := newLegoNearlyfreespeechConfig(e)
+	config.APIKey = values[nearlyfreespeech.EnvAPIKey]
+	config.Login = values[nearlyfreespeech.EnvLogin]
+
+	return nearlyfreespeech.NewDNSProviderConfig(config)
+}
+
+func newLegoNetcupConfig(e *legoEnv) *netcup.Config {
+	config := netcup.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(netcup.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(netcup.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(netcup.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoNetcup(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(netcup.EnvCustomerNumber, netcup.EnvAPIKey, netcup.EnvAPIPassword)
+	if err != nil {
+		return nil, fmt.Errorf("netcup: %w", err)
+	}
+
+	config := newLegoNetcupConfig(e)
+	config.Customer = values[netcup.EnvCustomerNumber]
+	config.Key = values[netcup.EnvAPIKey]
+	config.Password = values[netcup.EnvAPIPassword]
+
+	return netcup.NewDNSProviderConfig(config)
+}
+
+func newLegoNetlifyConfig(e *legoEnv) *netlify.Config {
+	config := netlify.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(netlify.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(netlify.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(netlify.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(netlify.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoNetlify(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(netlify.EnvToken)
+	if err != nil {
+		return nil, fmt.Errorf("netlify: %w", err)
+	}
+
+	config := newLegoNetlifyConfig(e)
+	config.Token = values[netlify.EnvToken]
+
+	return netlify.NewDNSProviderConfig(config)
+}
+
+func newLegoNifcloudConfig(e *legoEnv) *nifcloud.Config {
+	config := nifcloud.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(nifcloud.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(nifcloud.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(nifcloud.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(nifcloud.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoNifcloud(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(nifcloud.EnvAccessKeyID, nifcloud.EnvSecretAccessKey)
+	if err != nil {
+		return nil, fmt.Errorf("nifcloud: %w", err)
+	}
+
+	config := newLegoNifcloudConfig(e)
+	config.BaseURL = e.GetOrFile(nifcloud.EnvDNSEndpoint)
+	config.AccessKey = values[nifcloud.EnvAccessKeyID]
+	config.SecretKey = values[nifcloud.EnvSecretAccessKey]
+
+	return nifcloud.NewDNSProviderConfig(config)
+}
+
+func newLegoNjallaConfig(e *legoEnv) *njalla.Config {
+	config := njalla.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(njalla.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(njalla.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(njalla.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(njalla.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoNjalla(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(njalla.EnvToken)
+	if err != nil {
+		return nil, fmt.Errorf("njalla: %w", err)
+	}
+
+	config := newLegoNjallaConfig(e)
+	config.Token = values[njalla.EnvToken]
+
+	return njalla.NewDNSProviderConfig(config)
+}
+
+func newLegoNodionConfig(e *legoEnv) *nodion.Config {
+	config := nodion.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(nodion.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(nodion.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(nodion.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(nodion.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoNodion(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(nodion.EnvAPIToken)
+	if err != nil {
+		return nil, fmt.Errorf("nodion: %w", err)
+	}
+
+	config := newLegoNodionConfig(e)
+	config.APIToken = values[nodion.EnvAPIToken]
+
+	return nodion.NewDNSProviderConfig(config)
+}
+
+func newLegoNs1Config(e *legoEnv) *ns1.Config {
+	config := ns1.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(ns1.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(ns1.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(ns1.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(ns1.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoNs1(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(ns1.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("ns1: %w", err)
+	}
+
+	config := newLegoNs1Config(e)
+	config.APIKey = values[ns1.EnvAPIKey]
+
+	return ns1.NewDNSProviderConfig(config)
+}
+
+func newLegoOvhConfig(e *legoEnv) *ovh.Config {
+	config := ovh.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(ovh.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(ovh.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(ovh.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(ovh.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoOvh(e *legoEnv) (challenge.Provider, error) {
+	config := newLegoOvhConfig(e)
+
+	config.APIEndpoint = e.GetOrDefaultString(ovh.EnvEndpoint, "ovh-eu")
+
+	config.ApplicationKey = e.GetOrFile(ovh.EnvApplicationKey)
+	config.ApplicationSecret = e.GetOrFile(ovh.EnvApplicationSecret)
+	config.ConsumerKey = e.GetOrFile(ovh.EnvConsumerKey)
+
+	config.AccessToken = e.GetOrFile(ovh.EnvAccessToken)
+
+	clientID := e.GetOrFile(ovh.EnvClientID)
+	clientSecret := e.GetOrFile(ovh.EnvClientSecret)
+
+	if clientID != "" || clientSecret != "" {
+		config.OAuth2Config = &ovh.OAuth2Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+		}
+	}
+
+	return ovh.NewDNSProviderConfig(config)
+}
+
+func newLegoPdnsConfig(e *legoEnv) *pdns.Config {
+	config := pdns.NewDefaultConfig()
+	config.ServerName = e.GetOrDefaultString(pdns.EnvServerName, config.ServerName)
+	config.APIVersion = e.GetOrDefaultInt(pdns.EnvAPIVersion, config.APIVersion)
+	config.TTL = e.GetOrDefaultInt(pdns.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(pdns.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(pdns.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(pdns.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoPdns(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(pdns.EnvAPIKey, pdns.EnvAPIURL)
+	if err != nil {
+		return nil, fmt.Errorf("pdns: %w", err)
+	}
+
+	hostURL, err := url.Parse(values[pdns.EnvAPIURL])
+	if err != nil {
+		return nil, fmt.Errorf("pdns: %w", err)
+	}
+
+	config := newLegoPdnsConfig(e)
+	config.Host = hostURL
+	config.APIKey = values[pdns.EnvAPIKey]
+
+	return pdns.NewDNSProviderConfig(config)
+}
+
+func newLegoPorkbunConfig(e *legoEnv) *porkbun.Config {
+	config := porkbun.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(porkbun.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(porkbun.EnvPollingInterval, config.PollingInterval)
+	config.TTL = e.GetOrDefaultInt(porkbun.EnvTTL, config.TTL)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(porkbun.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoPorkbun(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(porkbun.EnvSecretAPIKey, porkbun.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("porkbun: %w", err)
+	}
+
+	config := newLegoPorkbunConfig(e)
+	config.SecretAPIKey = values[porkbun.EnvSecretAPIKey]
+	config.APIKey = values[porkbun.EnvAPIKey]
+
+	return porkbun.NewDNSProviderConfig(config)
+}
+
+func newLegoRackspaceConfig(e *legoEnv) *rackspace.Config {
+	config := rackspace.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(rackspace.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(rackspace.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(rackspace.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(rackspace.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoRackspace(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(rackspace.EnvUser, rackspace.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("rackspace: %w", err)
+	}
+
+	config := newLegoRackspaceConfig(e)
+	config.APIUser = values[rackspace.EnvUser]
+	config.APIKey = values[rackspace.EnvAPIKey]
+
+	return rackspace.NewDNSProviderConfig(config)
+}
+
+func newLegoRainyunConfig(e *legoEnv) *rainyun.Config {
+	config := rainyun.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(rainyun.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(rainyun.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(rainyun.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(rainyun.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoRainyun(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(rainyun.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("rainyun: %w", err)
+	}
+
+	config := newLegoRainyunConfig(e)
+	config.APIKey = values[rainyun.EnvAPIKey]
+
+	return rainyun.NewDNSProviderConfig(config)
+}
+
+func newLegoRcodezeroConfig(e *legoEnv) *rcodezero.Config {
+	config := rcodezero.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(rcodezero.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(rcodezero.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(rcodezero.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(rcodezero.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoRcodezero(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(rcodezero.EnvAPIToken)
+	if err != nil {
+		return nil, fmt.Errorf("rcodezero: %w", err)
+	}
+
+	config := newLegoRcodezeroConfig(e)
+	config.APIToken = values[rcodezero.EnvAPIToken]
+
+	return rcodezero.NewDNSProviderConfig(config)
+}
+
+func newLegoRegfishConfig(e *legoEnv) *regfish.Config {
+	config := regfish.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(regfish.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(regfish.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(regfish.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(regfish.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoRegfish(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(regfish.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("regfish: %w", err)
+	}
+
+	config := newLegoRegfishConfig(e)
+	config.APIKey = values[regfish.EnvAPIKey]
+
+	return regfish.NewDNSProviderConfig(config)
+}
+
+func newLegoRegruConfig(e *legoEnv) *regru.Config {
+	config := regru.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(regru.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(regru.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(regru.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(regru.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoRegru(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(regru.EnvUsername, regru.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("regru: %w", err)
+	}
+
+	config := newLegoRegruConfig(e)
+	config.Username = values[regru.EnvUsername]
+	config.Password = values[regru.EnvPassword]
+	config.TLSCert = e.GetOrDefaultString(regru.EnvTLSCert, "")
+	config.TLSKey = e.GetOrDefaultString(regru.EnvTLSKey, "")
+
+	return regru.NewDNSProviderConfig(config)
+}
+
+func newLegoRfc2136Config(e *legoEnv) *rfc2136.Config {
+	config := rfc2136.NewDefaultConfig()
+	config.TSIGAlgorithm = e.GetOrDefaultString(rfc2136.EnvTSIGAlgorithm, config.TSIGAlgorithm)
+	config.TTL = e.GetOrDefaultInt(rfc2136.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(rfc2136.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(rfc2136.EnvPollingInterval, config.PollingInterval)
+	config.SequenceInterval = e.GetOrDefaultSecond(rfc2136.EnvSequenceInterval, config.SequenceInterval)
+	config.DNSTimeout = e.GetOrDefaultSecond(rfc2136.EnvDNSTimeout, config.DNSTimeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoRfc2136(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(rfc2136.EnvNameserver)
+	if err != nil {
+		return nil, fmt.Errorf("rfc2136: %w", err)
+	}
+
+	config := newLegoRfc2136Config(e)
+	config.Nameserver = values[rfc2136.EnvNameserver]
+
+	config.TSIGFile = e.GetOrDefaultString(rfc2136.EnvTSIGFile, "")
+
+	config.TSIGKey = e.GetOrFile(rfc2136.EnvTSIGKey)
+	config.TSIGSecret = e.GetOrFile(rfc2136.EnvTSIGSecret)
+
+	return rfc2136.NewDNSProviderConfig(config)
+}
+
+func newLegoRimuhostingConfig(e *legoEnv) *rimuhosting.Config {
+	config := rimuhosting.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(rimuhosting.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(rimuhosting.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(rimuhosting.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(rimuhosting.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoRimuhosting(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(rimuhosting.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("rimuhosting: %w", err)
+	}
+
+	config := newLegoRimuhostingConfig(e)
+	config.APIKey = values[rimuhosting.EnvAPIKey]
+
+	return rimuhosting.NewDNSProviderConfig(config)
+}
+
+func newLegoSafednsConfig(e *legoEnv) *safedns.Config {
+	config := safedns.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(safedns.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(safedns.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(safedns.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(safedns.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoSafedns(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(safedns.EnvAuthToken)
+	if err != nil {
+		return nil, fmt.Errorf("safedns: %w", err)
+	}
+
+	config := newLegoSafednsConfig(e)
+	config.AuthToken = values[safedns.EnvAuthToken]
+
+	return safedns.NewDNSProviderConfig(config)
+}
+
+func newLegoSakuracloudConfig(e *legoEnv) *sakuracloud.Config {
+	config := sakuracloud.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(sakuracloud.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(sakuracloud.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(sakuracloud.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(sakuracloud.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoSakuracloud(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(sakuracloud.EnvAccessToken, sakuracloud.EnvAccessTokenSecret)
+	if err != nil {
+		return nil, fmt.Errorf("sakuracloud: %w", err)
+	}
+
+	config := newLegoSakuracloudConfig(e)
+	config.Token = values[sakuracloud.EnvAccessToken]
+	config.Secret = values[sakuracloud.EnvAccessTokenSecret]
+
+	return sakuracloud.NewDNSProviderConfig(config)
+}
+
+func newLegoSelectelConfig(e *legoEnv) *selectel.Config {
+	config := selectel.NewDefaultConfig()
+	config.BaseURL = e.GetOrDefaultString(selectel.EnvBaseURL, config.BaseURL)
+	config.TTL = e.GetOrDefaultInt(selectel.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(selectel.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(selectel.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(selectel.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoSelectel(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(selectel.EnvAPIToken)
+	if err != nil {
+		return nil, fmt.Errorf("selectel: %w", err)
+	}
+
+	config := newLegoSelectelConfig(e)
+	config.Token = values[selectel.EnvAPIToken]
+
+	return selectel.NewDNSProviderConfig(config)
+}
+
+func newLegoSelectelv2Config(e *legoEnv) *selectelv2.Config {
+	config := selectelv2.NewDefaultConfig()
+	config.BaseURL = e.GetOrDefaultString(selectelv2.EnvBaseURL, config.BaseURL)
+	config.TTL = e.GetOrDefaultInt(selectelv2.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(selectelv2.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(selectelv2.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(selectelv2.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoSelectelv2(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(selectelv2.EnvUsernameOS, selectelv2.EnvPasswordOS, selectelv2.EnvAccount, selectelv2.EnvProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("selectelv2: %w", err)
+	}
+
+	config := newLegoSelectelv2Config(e)
+	config.Username = values[selectelv2.EnvUsernameOS]
+	config.Password = values[selectelv2.EnvPasswordOS]
+	config.Account = values[selectelv2.EnvAccount]
+	config.ProjectID = values[selectelv2.EnvProjectID]
+
+	return selectelv2.NewDNSProviderConfig(config)
+}
+
+func newLegoServercowConfig(e *legoEnv) *servercow.Config {
+	config := servercow.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(servercow.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(servercow.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(servercow.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(servercow.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoServercow(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(servercow.EnvUsername, servercow.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("servercow: %w", err)
+	}
+
+	config := newLegoServercowConfig(e)
+	config.Username = values[servercow.EnvUsername]
+	config.Password = values[servercow.EnvPassword]
+
+	return servercow.NewDNSProviderConfig(config)
+}
+
+func newLegoShellrentConfig(e *legoEnv) *shellrent.Config {
+	config := shellrent.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(shellrent.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(shellrent.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(shellrent.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(shellrent.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoShellrent(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(shellrent.EnvUsername, shellrent.EnvToken)
+	if err != nil {
+		return nil, fmt.Errorf("shellrent: %w", err)
+	}
+
+	config := newLegoShellrentConfig(e)
+	config.Username = values[shellrent.EnvUsername]
+	config.Token = values[shellrent.EnvToken]
+
+	return shellrent.NewDNSProviderConfig(config)
+}
+
+func newLegoSimplyConfig(e *legoEnv) *simply.Config {
+	config := simply.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(simply.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(simply.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(simply.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(simply.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoSimply(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(simply.EnvAccountName, simply.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("simply: %w", err)
+	}
+
+	config := newLegoSimplyConfig(e)
+	config.AccountName = values[simply.EnvAccountName]
+	config.APIKey = values[simply.EnvAPIKey]
+
+	return simply.NewDNSProviderConfig(config)
+}
+
+func newLegoSonicConfig(e *legoEnv) *sonic.Config {
+	config := sonic.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(sonic.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(sonic.EnvPropagationTimeout, config.PropagationTimeout)
+	config.SequenceInterval = e.GetOrDefaultSecond(sonic.EnvSequenceInterval, config.SequenceInterval)
+	config.PollingInterval = e.GetOrDefaultSecond(sonic.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(sonic.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoSonic(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(sonic.EnvUserID, sonic.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("sonic: %w", err)
+	}
+
+	config := newLegoSonicConfig(e)
+	config.UserID = values[sonic.EnvUserID]
+	config.APIKey = values[sonic.EnvAPIKey]
+
+	return sonic.NewDNSProviderConfig(config)
+}
+
+func newLegoSpaceshipConfig(e *legoEnv) *spaceship.Config {
+	config := spaceship.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(spaceship.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(spaceship.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(spaceship.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(spaceship.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoSpaceship(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(spaceship.EnvAPIKey, spaceship.EnvAPISecret)
+	if err != nil {
+		return nil, fmt.Errorf("spaceship: %w", err)
+	}
+
+	config := newLegoSpaceshipConfig(e)
+	config.APIKey = values[spaceship.EnvAPIKey]
+	config.APISecret = values[spaceship.EnvAPISecret]
+
+	return spaceship.NewDNSProviderConfig(config)
+}
+
+func newLegoStackpathConfig(e *legoEnv) *stackpath.Config {
+	config := stackpath.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(stackpath.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(stackpath.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(stackpath.EnvPollingInterval, config.PollingInterval)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoStackpath(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(stackpath.EnvClientID, stackpath.EnvClientSecret, stackpath.EnvStackID)
+	if err != nil {
+		return nil, fmt.Errorf("stackpath: %w", err)
+	}
+
+	config := newLegoStackpathConfig(e)
+	config.ClientID = values[stackpath.EnvClientID]
+	config.ClientSecret = values[stackpath.EnvClientSecret]
+	config.StackID = values[stackpath.EnvStackID]
+
+	return stackpath.NewDNSProviderConfig(config)
+}
+
+func newLegoTechnitiumConfig(e *legoEnv) *technitium.Config {
+	config := technitium.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(technitium.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(technitium.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(technitium.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(technitium.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoTechnitium(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(technitium.EnvServerBaseURL, technitium.EnvAPIToken)
+	if err != nil {
+		return nil, fmt.Errorf("technitium: %w", err)
+	}
+
+	config := newLegoTechnitiumConfig(e)
+	config.BaseURL = values[technitium.EnvServerBaseURL]
+	config.APIToken = values[technitium.EnvAPIToken]
+
+	return technitium.NewDNSProviderConfig(config)
+}
+
+func newLegoTencentcloudConfig(e *legoEnv) *tencentcloud.Config {
+	config := tencentcloud.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(tencentcloud.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(tencentcloud.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(tencentcloud.EnvPollingInterval, config.PollingInterval)
+	config.HTTPTimeout = e.GetOrDefaultSecond(tencentcloud.EnvHTTPTimeout, config.HTTPTimeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoTencentcloud(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(tencentcloud.EnvSecretID, tencentcloud.EnvSecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("tencentcloud: %w", err)
+	}
+
+	config := newLegoTencentcloudConfig(e)
+	config.SecretID = values[tencentcloud.EnvSecretID]
+	config.SecretKey = values[tencentcloud.EnvSecretKey]
+	config.Region = e.GetOrDefaultString(tencentcloud.EnvRegion, "")
+	config.SessionToken = e.GetOrDefaultString(tencentcloud.EnvSessionToken, "")
+
+	return tencentcloud.NewDNSProviderConfig(config)
+}
+
+func newLegoTimewebcloudConfig(e *legoEnv) *timewebcloud.Config {
+	config := timewebcloud.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(timewebcloud.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(timewebcloud.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(timewebcloud.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoTimewebcloud(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(timewebcloud.EnvAuthToken)
+	if err != nil {
+		return nil, fmt.Errorf("timewebcloud: %w", err)
+	}
+
+	config := newLegoTimewebcloudConfig(e)
+	config.AuthToken = values[timewebcloud.EnvAuthToken]
+
+	return timewebcloud.NewDNSProviderConfig(config)
+}
+
+func newLegoUltradnsConfig(e *legoEnv) *ultradns.Config {
+	config := ultradns.NewDefaultConfig()
+	config.Endpoint = e.GetOrDefaultString(ultradns.EnvEndpoint, config.Endpoint)
+	config.TTL = e.GetOrDefaultInt(ultradns.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(ultradns.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(ultradns.EnvPollingInterval, config.PollingInterval)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoUltradns(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(ultradns.EnvUsername, ultradns.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("ultradns: %w", err)
+	}
+
+	config := newLegoUltradnsConfig(e)
+	config.Username = values[ultradns.EnvUsername]
+	config.Password = values[ultradns.EnvPassword]
+
+	return ultradns.NewDNSProviderConfig(config)
+}
+
+func newLegoVariomediaConfig(e *legoEnv) *variomedia.Config {
+	config := variomedia.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(variomedia.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(variomedia.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(variomedia.EnvPollingInterval, config.PollingInterval)
+	config.SequenceInterval = e.GetOrDefaultSecond(variomedia.EnvSequenceInterval, config.SequenceInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(variomedia.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoVariomedia(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(variomedia.EnvAPIToken)
+	if err != nil {
+		return nil, fmt.Errorf("variomedia: %w", err)
+	}
+
+	config := newLegoVariomediaConfig(e)
+	config.APIToken = values[variomedia.EnvAPIToken]
+
+	return variomedia.NewDNSProviderConfig(config)
+}
+
+func newLegoVegadnsConfig(e *legoEnv) *vegadns.Config {
+	config := vegadns.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(vegadns.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(vegadns.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(vegadns.EnvPollingInterval, config.PollingInterval)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoVegadns(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(vegadns.EnvURL)
+	if err != nil {
+		return nil, fmt.Errorf("vegadns: %w", err)
+	}
+
+	config := newLegoVegadnsConfig(e)
+	config.BaseURL = values[vegadns.EnvURL]
+	config.APIKey = e.GetOrFile(vegadns.EnvKey)
+	config.APISecret = e.GetOrFile(vegadns.EnvSecret)
+
+	return vegadns.NewDNSProviderConfig(config)
+}
+
+func newLegoVercelConfig(e *legoEnv) *vercel.Config {
+	config := vercel.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(vercel.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(vercel.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(vercel.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(vercel.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoVercel(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(vercel.EnvAuthToken)
+	if err != nil {
+		return nil, fmt.Errorf("vercel: %w", err)
+	}
+
+	config := newLegoVercelConfig(e)
+	config.AuthToken = values[vercel.EnvAuthToken]
+	config.TeamID = e.GetOrDefaultString(vercel.EnvTeamID, "")
+
+	return vercel.NewDNSProviderConfig(config)
+}
+
+func newLegoVinyldnsConfig(e *legoEnv) *vinyldns.Config {
+	config := vinyldns.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(vinyldns.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(vinyldns.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(vinyldns.EnvPollingInterval, config.PollingInterval)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoVinyldns(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(vinyldns.EnvAccessKey, vinyldns.EnvSecretKey, vinyldns.EnvHost)
+	if err != nil {
+		return nil, fmt.Errorf("vinyldns: %w", err)
+	}
+
+	config := newLegoVinyldnsConfig(e)
+	config.AccessKey = values[vinyldns.EnvAccessKey]
+	config.SecretKey = values[vinyldns.EnvSecretKey]
+	config.Host = values[vinyldns.EnvHost]
+
+	return vinyldns.NewDNSProviderConfig(config)
+}
+
+func newLegoVolcengineConfig(e *legoEnv) *volcengine.Config {
+	config := volcengine.NewDefaultConfig()
+	config.Scheme = e.GetOrDefaultString(volcengine.EnvScheme, config.Scheme)
+	config.Host = e.GetOrDefaultString(volcengine.EnvHost, config.Host)
+	config.Region = e.GetOrDefaultString(volcengine.EnvRegion, config.Region)
+	config.TTL = e.GetOrDefaultInt(volcengine.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(volcengine.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(volcengine.EnvPollingInterval, config.PollingInterval)
+	config.HTTPTimeout = e.GetOrDefaultSecond(volcengine.EnvHTTPTimeout, config.HTTPTimeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoVolcengine(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(volcengine.EnvAccessKey, volcengine.EnvSecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("volcengine: %w", err)
+	}
+
+	config := newLegoVolcengineConfig(e)
+	config.AccessKey = values[volcengine.EnvAccessKey]
+	config.SecretKey = values[volcengine.EnvSecretKey]
+
+	return volcengine.NewDNSProviderConfig(config)
+}
+
+func newLegoVscaleConfig(e *legoEnv) *vscale.Config {
+	config := vscale.NewDefaultConfig()
+	config.BaseURL = e.GetOrDefaultString(vscale.EnvBaseURL, config.BaseURL)
+	config.TTL = e.GetOrDefaultInt(vscale.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(vscale.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(vscale.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(vscale.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoVscale(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(vscale.EnvAPIToken)
+	if err != nil {
+		return nil, fmt.Errorf("vscale: %w", err)
+	}
+
+	config := newLegoVscaleConfig(e)
+	config.Token = values[vscale.EnvAPIToken]
+
+	return vscale.NewDNSProviderConfig(config)
+}
+
+func newLegoVultrConfig(e *legoEnv) *vultr.Config {
+	config := vultr.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(vultr.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(vultr.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(vultr.EnvPollingInterval, config.PollingInterval)
+	config.HTTPTimeout = e.GetOrDefaultSecond(vultr.EnvHTTPTimeout, config.HTTPTimeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoVultr(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(vultr.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("vultr: %w", err)
+	}
+
+	config := newLegoVultrConfig(e)
+	config.APIKey = values[vultr.EnvAPIKey]
+
+	return vultr.NewDNSProviderConfig(config)
+}
+
+func newLegoWebnamesConfig(e *legoEnv) *webnames.Config {
+	config := webnames.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(webnames.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(webnames.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(webnames.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoWebnames(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(webnames.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("webnames: %w", err)
+	}
+
+	config := newLegoWebnamesConfig(e)
+	config.APIKey = values[webnames.EnvAPIKey]
+
+	return webnames.NewDNSProviderConfig(config)
+}
+
+func newLegoWebsupportConfig(e *legoEnv) *websupport.Config {
+	config := websupport.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(websupport.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(websupport.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(websupport.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(websupport.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoWebsupport(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(websupport.EnvAPIKey, websupport.EnvSecret)
+	if err != nil {
+		return nil, fmt.Errorf("websupport: %w", err)
+	}
+
+	config := newLegoWebsupportConfig(e)
+	config.APIKey = values[websupport.EnvAPIKey]
+	config.Secret = values[websupport.EnvSecret]
+
+	return websupport.NewDNSProviderConfig(config)
+}
+
+func newLegoWedosConfig(e *legoEnv) *wedos.Config {
+	config := wedos.NewDefaultConfig()
+	config.PropagationTimeout = e.GetOrDefaultSecond(wedos.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(wedos.EnvPollingInterval, config.PollingInterval)
+	config.TTL = e.GetOrDefaultInt(wedos.EnvTTL, config.TTL)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(wedos.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoWedos(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(wedos.EnvUsername, wedos.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("wedos: %w", err)
+	}
+
+	config := newLegoWedosConfig(e)
+	config.Username = values[wedos.EnvUsername]
+	config.Password = values[wedos.EnvPassword]
+
+	return wedos.NewDNSProviderConfig(config)
+}
+
+func newLegoWestcnConfig(e *legoEnv) *westcn.Config {
+	config := westcn.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(westcn.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(westcn.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(westcn.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(westcn.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoWestcn(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(westcn.EnvUsername, westcn.EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("westcn: %w", err)
+	}
+
+	config := newLegoWestcnConfig(e)
+	config.Username = values[westcn.EnvUsername]
+	config.Password = values[westcn.EnvPassword]
+
+	return westcn.NewDNSProviderConfig(config)
+}
+
+func newLegoYandexConfig(e *legoEnv) *yandex.Config {
+	config := yandex.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(yandex.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(yandex.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(yandex.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(yandex.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoYandex(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(yandex.EnvPddToken)
+	if err != nil {
+		return nil, fmt.Errorf("yandex: %w", err)
+	}
+
+	config := newLegoYandexConfig(e)
+	config.PddToken = values[yandex.EnvPddToken]
+
+	return yandex.NewDNSProviderConfig(config)
+}
+
+func newLegoYandex360Config(e *legoEnv) *yandex360.Config {
+	config := yandex360.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(yandex360.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(yandex360.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(yandex360.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(yandex360.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoYandex360(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(yandex360.EnvOAuthToken, yandex360.EnvOrgID)
+	if err != nil {
+		return nil, fmt.Errorf("yandex360: %w", err)
+	}
+
+	config := newLegoYandex360Config(e)
+	config.OAuthToken = values[yandex360.EnvOAuthToken]
+
+	orgID, err := strconv.ParseInt(values[yandex360.EnvOrgID], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("yandex360: %w", err)
+	}
+
+	config.OrgID = orgID
+
+	return yandex360.NewDNSProviderConfig(config)
+}
+
+func newLegoYandexcloudConfig(e *legoEnv) *yandexcloud.Config {
+	config := yandexcloud.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(yandexcloud.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(yandexcloud.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(yandexcloud.EnvPollingInterval, config.PollingInterval)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoYandexcloud(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(yandexcloud.EnvIamToken, yandexcloud.EnvFolderID)
+	if err != nil {
+		return nil, fmt.Errorf("yandexcloud: %w", err)
+	}
+
+	config := newLegoYandexcloudConfig(e)
+	config.IamToken = values[yandexcloud.EnvIamToken]
+	config.FolderID = values[yandexcloud.EnvFolderID]
+
+	return yandexcloud.NewDNSProviderConfig(config)
+}
+
+func newLegoZonomiConfig(e *legoEnv) *zonomi.Config {
+	config := zonomi.NewDefaultConfig()
+	config.TTL = e.GetOrDefaultInt(zonomi.EnvTTL, config.TTL)
+	config.PropagationTimeout = e.GetOrDefaultSecond(zonomi.EnvPropagationTimeout, config.PropagationTimeout)
+	config.PollingInterval = e.GetOrDefaultSecond(zonomi.EnvPollingInterval, config.PollingInterval)
+	config.HTTPClient.Timeout = e.GetOrDefaultSecond(zonomi.EnvHTTPTimeout, config.HTTPClient.Timeout)
+	config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)
+	return config
+}
+
+func newLegoZonomi(e *legoEnv) (challenge.Provider, error) {
+	values, err := e.Get(zonomi.EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("zonomi: %w", err)
+	}
+
+	config := newLegoZonomiConfig(e)
+	config.APIKey = values[zonomi.EnvAPIKey]
+
+	return zonomi.NewDNSProviderConfig(config)
+}
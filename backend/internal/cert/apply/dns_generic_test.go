@@ -0,0 +1,90 @@
+package apply
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestNewLegoGenericProvider(t *testing.T) {
+	os.Unsetenv("HETZNER_API_KEY")
+	provider, err := NewLegoGenericProvider(map[string]string{
+		"provider_code":               "hetzner",
+		"HETZNER_API_KEY":             "test-key",
+		"HETZNER_PROPAGATION_TIMEOUT": "300",
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if provider == nil {
+		t.Fatal("expected provider")
+	}
+	if timeout, _ := provider.(interface {
+		Timeout() (time.Duration, time.Duration)
+	}).Timeout(); timeout != 300*time.Second {
+		t.Fatalf("unexpected propagation timeout: %v", timeout)
+	}
+	// 节点的 max_wait 与其他 DNS provider 一致作为等待解析生效的超时时间
+	provider, err = NewLegoGenericProvider(map[string]string{
+		"provider_code":   "hetzner",
+		"HETZNER_API_KEY": "test-key",
+	}, 90*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timeout, _ := provider.(interface {
+		Timeout() (time.Duration, time.Duration)
+	}).Timeout(); timeout != 90*time.Second {
+		t.Fatalf("expected max_wait as propagation timeout, got %v", timeout)
+	}
+	if _, ok := os.LookupEnv("HETZNER_API_KEY"); ok {
+		t.Fatal("expected credentials not to touch the environment")
+	}
+
+	// 凭据只来自授权配置，进程环境变量中的值不会被使用
+	t.Setenv("HETZNER_API_KEY", "env-key")
+	if _, err := NewLegoGenericProvider(map[string]string{"provider_code": "hetzner"}, 0); err == nil {
+		t.Fatal("expected missing credentials to fail")
+	}
+	if _, err := NewLegoGenericProvider(map[string]string{"provider_code": "not-a-provider"}, 0); err == nil {
+		t.Fatal("expected unknown provider to fail")
+	}
+	if _, err := NewLegoGenericProvider(map[string]string{
+		"provider_code":              "hetzner",
+		"HETZNER_API_KEY":            "test-key",
+		"LEGO_DISABLE_CNAME_SUPPORT": "true",
+	}, 0); err == nil {
+		t.Fatal("expected global lego variables to be rejected")
+	}
+	if _, err := NewLegoGenericProvider(map[string]string{
+		"provider_code":   "hetzner",
+		"HETZNER_API_KEY": "test-key",
+		"HETZNER_TTL":     "abc",
+	}, 0); err == nil {
+		t.Fatal("expected invalid TTL to fail")
+	}
+	// exec 会执行授权中配置的命令，不开放
+	if _, err := NewLegoGenericProvider(map[string]string{"provider_code": "exec", "EXEC_PATH": "/bin/true"}, 0); err == nil {
+		t.Fatal("expected exec provider to be rejected")
+	}
+}
+
+func TestLegoGenericProviderFallback(t *testing.T) {
+	// 生成的构建函数覆盖 lego 中按代码注册的其他 provider，包括带备用变量名的写法
+	t.Setenv("CLOUDNS_AUTH_ID", "env-id")
+	if _, err := NewLegoGenericProvider(map[string]string{"provider_code": "cloudns"}, 0); err == nil {
+		t.Fatal("expected missing cloudns credentials to fail")
+	}
+	if _, err := NewLegoGenericProvider(map[string]string{
+		"provider_code":         "cloudns",
+		"CLOUDNS_AUTH_ID":       "id",
+		"CLOUDNS_AUTH_PASSWORD": "password",
+	}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	for _, code := range []string{"acme-dns", "acmedns", "namesilo", "porkbun", "ovh"} {
+		if _, ok := legoGenericProviders[code]; !ok {
+			t.Errorf("expected provider %s to be generated", code)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package apply
+
+import (
+	"ALLinSSL/backend/internal/access"
+	"encoding/json"
+	"fmt"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetDNSProviderByAccess 根据 DNS 授权创建验证提供者，providerName 为空时使用授权的类型
+func GetDNSProviderByAccess(providerName, providerID string, httpClient *http.Client, maxWait time.Duration) (challenge.Provider, error) {
+	providerData, err := access.GetAccess(providerID)
+	if err != nil {
+		return nil, err
+	}
+	providerConfigStr, ok := providerData["config"].(string)
+	if !ok {
+		return nil, fmt.Errorf("api配置错误")
+	}
+	// 解析 JSON 配置
+	var providerConfig map[string]string
+	err = json.Unmarshal([]byte(providerConfigStr), &providerConfig)
+	if err != nil {
+		return nil, err
+	}
+	if providerName == "" {
+		providerName, _ = providerData["type"].(string)
+	}
+	return GetDNSProvider(providerName, providerConfig, httpClient, maxWait)
+}
+
+// DomainProvider 域名与 DNS 授权的对应关系，domain 为主域名（zone），其子域名同样使用此授权
+type DomainProvider struct {
+	Domain     string
+	Provider   string
+	ProviderID string
+}
+
+// ParseDomainProviders 解析节点配置中的 domain_providers，支持数组或 JSON 字符串
+//
+//	[{"domain": "example.com", "provider": "aliyun", "provider_id": 1}, ...]
+func ParseDomainProviders(v any) ([]DomainProvider, error) {
+	var items []any
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if strings.TrimSpace(val) == "" {
+			return nil, nil
+		}
+		if err := json.Unmarshal([]byte(val), &items); err != nil {
+			return nil, fmt.Errorf("参数错误：domain_providers，%v", err)
+		}
+	case []any:
+		items = val
+	default:
+		return nil, fmt.Errorf("参数错误：domain_providers")
+	}
+
+	var result []DomainProvider
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("参数错误：domain_providers")
+		}
+		domain, _ := m["domain"].(string)
+		domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "*.")
+		if domain == "" {
+			return nil, fmt.Errorf("参数错误：domain_providers 中的 domain 不能为空")
+		}
+		var providerID string
+		switch id := m["provider_id"].(type) {
+		case float64:
+			providerID = strconv.Itoa(int(id))
+		case string:
+			providerID = id
+		}
+		if providerID == "" {
+			return nil, fmt.Errorf("参数错误：域名 %s 未指定 provider_id", domain)
+		}
+		provider, _ := m["provider"].(string)
+		result = append(result, DomainProvider{
+			Domain:     dns01.UnFqdn(domain),
+			Provider:   provider,
+			ProviderID: providerID,
+		})
+	}
+	return result, nil
+}
+
+type zoneProvider struct {
+	zone     string
+	provider challenge.Provider
+}
+
+// multiDNSProvider 按域名将 DNS-01 验证分发到对应的 DNS 授权，未匹配的域名使用默认授权
+type multiDNSProvider struct {
+	defaultProvider challenge.Provider
+	providers       []zoneProvider
+}
+
+var _ challenge.ProviderTimeout = (*multiDNSProvider)(nil)
+
+// NewMultiDNSProvider 创建按域名分发的 DNS 验证提供者，defaultProvider 可为空
+func NewMultiDNSProvider(defaultProvider challenge.Provider, mappings []DomainProvider, httpClient *http.Client, maxWait time.Duration) (challenge.Provider, error) {
+	m := &multiDNSProvider{defaultProvider: defaultProvider}
+	for _, mapping := range mappings {
+		provider, err := GetDNSProviderByAccess(mapping.Provider, mapping.ProviderID, httpClient, maxWait)
+		if err != nil {
+			return nil, fmt.Errorf("创建域名 %s 的 DNS provider 失败: %v", mapping.Domain, err)
+		}
+		m.providers = append(m.providers, zoneProvider{zone: mapping.Domain, provider: provider})
+	}
+	// 优先匹配更长（更具体）的域名
+	sort.SliceStable(m.providers, func(i, j int) bool {
+		return len(m.providers[i].zone) > len(m.providers[j].zone)
+	})
+	return m, nil
+}
+
+func (m *multiDNSProvider) getProvider(domain string) (challenge.Provider, error) {
+	domain = strings.TrimPrefix(strings.ToLower(dns01.UnFqdn(domain)), "*.")
+	for _, p := range m.providers {
+		if domain == p.zone || strings.HasSuffix(domain, "."+p.zone) {
+			return p.provider, nil
+		}
+	}
+	if m.defaultProvider == nil {
+		return nil, fmt.Errorf("域名 %s 未配置 DNS 授权", domain)
+	}
+	return m.defaultProvider, nil
+}
+
+func (m *multiDNSProvider) Present(domain, token, keyAuth string) error {
+	provider, err := m.getProvider(domain)
+	if err != nil {
+		return err
+	}
+	return provider.Present(domain, token, keyAuth)
+}
+
+func (m *multiDNSProvider) CleanUp(domain, token, keyAuth string) error {
+	provider, err := m.getProvider(domain)
+	if err != nil {
+		return err
+	}
+	return provider.CleanUp(domain, token, keyAuth)
+}
+
+// Timeout 取所有授权中最长的等待时间
+func (m *multiDNSProvider) Timeout() (timeout, interval time.Duration) {
+	timeout, interval = dns01.DefaultPropagationTimeout, dns01.DefaultPollingInterval
+	providers := []challenge.Provider{m.defaultProvider}
+	for _, p := range m.providers {
+		providers = append(providers, p.provider)
+	}
+	for _, provider := range providers {
+		if p, ok := provider.(challenge.ProviderTimeout); ok {
+			t, i := p.Timeout()
+			if t > timeout {
+				timeout = t
+			}
+			if i > interval {
+				interval = i
+			}
+		}
+	}
+	return timeout, interval
+}
@@ -0,0 +1,67 @@
+package apply
+
+import (
+	"testing"
+)
+
+type fakeDNSProvider struct {
+	name    string
+	present []string
+}
+
+func (f *fakeDNSProvider) Present(domain, token, keyAuth string) error {
+	f.present = append(f.present, domain)
+	return nil
+}
+
+func (f *fakeDNSProvider) CleanUp(domain, token, keyAuth string) error {
+	return nil
+}
+
+func TestMultiDNSProvider(t *testing.T) {
+	def := &fakeDNSProvider{name: "default"}
+	ali := &fakeDNSProvider{name: "aliyun"}
+	cf := &fakeDNSProvider{name: "cloudflare"}
+	m := &multiDNSProvider{
+		defaultProvider: def,
+		providers: []zoneProvider{
+			{zone: "dev.example.com", provider: cf},
+			{zone: "example.com", provider: ali},
+		},
+	}
+	cases := map[string]*fakeDNSProvider{
+		"example.com":         ali,
+		"*.example.com":       ali,
+		"www.example.com":     ali,
+		"api.dev.example.com": cf,
+		"other.org":           def,
+		"notexample.com":      def,
+	}
+	for domain, want := range cases {
+		got, err := m.getProvider(domain)
+		if err != nil {
+			t.Fatalf("getProvider(%s) failed: %v", domain, err)
+		}
+		if got != want {
+			t.Fatalf("getProvider(%s) = %s; want %s", domain, got.(*fakeDNSProvider).name, want.name)
+		}
+	}
+
+	m.defaultProvider = nil
+	if _, err := m.getProvider("other.org"); err == nil {
+		t.Fatal("expected error for unmapped domain without default provider")
+	}
+}
+
+func TestParseDomainProviders(t *testing.T) {
+	mappings, err := ParseDomainProviders(`[{"domain":"*.Example.com","provider":"aliyun","provider_id":3},{"domain":"example.org","provider_id":"4"}]`)
+	if err != nil {
+		t.Fatalf("ParseDomainProviders failed: %v", err)
+	}
+	if len(mappings) != 2 || mappings[0].Domain != "example.com" || mappings[0].ProviderID != "3" || mappings[1].ProviderID != "4" {
+		t.Fatalf("unexpected mappings: %+v", mappings)
+	}
+	if _, err := ParseDomainProviders([]any{map[string]any{"domain": "example.com"}}); err == nil {
+		t.Fatal("expected error for missing provider_id")
+	}
+}
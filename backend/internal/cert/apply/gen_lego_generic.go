@@ -0,0 +1,690 @@
+//go:build ignore
+
+// gen_lego_generic 根据 lego 各 DNS provider 的 NewDefaultConfig、NewDNSProvider 生成 dns_generic_gen.go。
+//
+// lego 的 NewDNSProvider 只能从进程环境变量读取配置，生成的构建函数复制其逻辑，
+// 把 env 包的调用换成 legoEnv 的同名方法，值只来自授权配置。
+// 引用了 provider 包内未导出标识符或其他无法改写写法的 provider 会被跳过。
+// 升级 lego 后在本目录执行 go generate 重新生成。
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	legoModule = "github.com/go-acme/lego/v4"
+	envPath    = legoModule + "/platform/config/env"
+	output     = "dns_generic_gen.go"
+)
+
+// excluded 不通过授权开放的 provider：exec 会执行授权中配置的命令，manual 需要人工在终端确认，
+// route53、lightsail、azure、azuredns 的凭据由云厂商 SDK 从进程环境变量或实例元数据读取，无法限定在授权配置内
+var excluded = map[string]bool{
+	"exec":      true,
+	"manual":    true,
+	"route53":   true,
+	"lightsail": true,
+	"azure":     true,
+	"azuredns":  true,
+}
+
+// envMethods 改写为 legoEnv 方法的 env 包函数
+var envMethods = map[string]bool{
+	"Get":                true,
+	"GetWithFallback":    true,
+	"GetOrFile":          true,
+	"GetOrDefaultString": true,
+	"GetOrDefaultBool":   true,
+	"GetOrDefaultInt":    true,
+	"GetOrDefaultSecond": true,
+}
+
+// deniedImports 构建函数中不允许出现的包，避免绕过授权配置读取进程环境或文件
+var deniedImports = map[string]bool{"os": true, legoModule + "/log": true}
+
+type provider struct {
+	codes []string
+	dir   string
+}
+
+type generator struct {
+	fset    *token.FileSet
+	imports map[string]string // path -> 别名
+	aliases map[string]string // 别名 -> path
+	entries []string
+	funcs   []string
+	skipped []string
+}
+
+func main() {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", legoModule).Output()
+	if err != nil {
+		fatal(err)
+	}
+	legoDir := strings.TrimSpace(string(out))
+	providers, err := parseProviders(filepath.Join(legoDir, "providers", "dns", "zz_gen_dns_providers.go"))
+	if err != nil {
+		fatal(err)
+	}
+
+	g := &generator{fset: token.NewFileSet(), imports: map[string]string{}, aliases: map[string]string{}}
+	g.alias(legoModule+"/challenge", "challenge")
+	for _, p := range providers {
+		if excluded[p.dir] {
+			g.skipped = append(g.skipped, p.dir+"（不开放）")
+			continue
+		}
+		if err := g.provider(filepath.Join(legoDir, "providers", "dns", p.dir), p); err != nil {
+			g.skipped = append(g.skipped, fmt.Sprintf("%s（%v）", p.dir, err))
+		}
+	}
+	if err := g.write(); err != nil {
+		fatal(err)
+	}
+	fmt.Printf("生成 %d 个 provider，跳过 %d 个：\n", len(g.entries), len(g.skipped))
+	for _, s := range g.skipped {
+		fmt.Println("  " + s)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+// parseProviders 从 lego 生成的 NewDNSChallengeProviderByName 中读取 provider 代码及所在目录
+func parseProviders(file string) ([]provider, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	imports := map[string]string{}
+	for _, imp := range f.Imports {
+		p, _ := strconv.Unquote(imp.Path.Value)
+		imports[path.Base(p)] = p
+	}
+	var providers []provider
+	ast.Inspect(f, func(n ast.Node) bool {
+		clause, ok := n.(*ast.CaseClause)
+		if !ok || len(clause.Body) != 1 {
+			return true
+		}
+		ret, ok := clause.Body[0].(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			return true
+		}
+		call, ok := ret.Results[0].(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "NewDNSProvider" {
+			return true
+		}
+		pkg, _ := sel.X.(*ast.Ident)
+		if pkg == nil || !strings.HasPrefix(imports[pkg.Name], legoModule+"/providers/dns/") {
+			return true
+		}
+		p := provider{dir: path.Base(imports[pkg.Name])}
+		for _, e := range clause.List {
+			if lit, ok := e.(*ast.BasicLit); ok {
+				code, _ := strconv.Unquote(lit.Value)
+				p.codes = append(p.codes, code)
+			}
+		}
+		providers = append(providers, p)
+		return true
+	})
+	return providers, nil
+}
+
+// alias 为导入路径分配生成文件中的包名，重名时追加序号
+func (g *generator) alias(importPath, name string) string {
+	if a, ok := g.imports[importPath]; ok {
+		return a
+	}
+	a := name
+	for i := 2; g.aliases[a] != ""; i++ {
+		a = fmt.Sprintf("%s%d", name, i)
+	}
+	g.imports[importPath] = a
+	g.aliases[a] = importPath
+	return a
+}
+
+// rewriter 改写单个 provider 的函数体
+type rewriter struct {
+	g       *generator
+	pkg     string            // provider 包在生成文件中的别名
+	decls   map[string]bool   // provider 包的顶层标识符
+	imports map[string]string // 源文件中的包名 -> path
+	locals  map[string]bool
+	keys    []string
+	seen    map[string]bool
+	used    map[string]string // 本 provider 用到的导入 path -> 包名
+	cfgFunc string
+	err     error
+}
+
+func (g *generator) provider(dir string, p provider) error {
+	pkgs, err := parser.ParseDir(g.fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return err
+	}
+	if len(pkgs) != 1 {
+		return fmt.Errorf("目录中有 %d 个包", len(pkgs))
+	}
+	var pkg *ast.Package
+	for _, v := range pkgs {
+		pkg = v
+	}
+
+	decls := map[string]bool{}
+	var newConfig, newProvider *ast.FuncDecl
+	var file *ast.File
+	for _, f := range pkg.Files {
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				if d.Recv != nil {
+					continue
+				}
+				decls[d.Name.Name] = true
+				switch d.Name.Name {
+				case "NewDefaultConfig":
+					newConfig = d
+				case "NewDNSProvider":
+					newProvider, file = d, f
+				}
+			case *ast.GenDecl:
+				for _, s := range d.Specs {
+					switch s := s.(type) {
+					case *ast.ValueSpec:
+						for _, n := range s.Names {
+							decls[n.Name] = true
+						}
+					case *ast.TypeSpec:
+						decls[s.Name.Name] = true
+					}
+				}
+			}
+		}
+	}
+	if newConfig == nil || newProvider == nil {
+		return fmt.Errorf("缺少 NewDefaultConfig 或 NewDNSProvider")
+	}
+
+	importPath := legoModule + "/providers/dns/" + p.dir
+	name := "newLego" + exportName(p.dir)
+	r := &rewriter{
+		g:       g,
+		decls:   decls,
+		imports: fileImports(file),
+		locals:  map[string]bool{},
+		seen:    map[string]bool{},
+		used:    map[string]string{},
+		cfgFunc: name + "Config",
+	}
+	// 先按 provider 包名占位，生成成功后再登记，避免跳过的 provider 占用别名
+	r.pkg = pkg.Name
+	if a, ok := g.imports[importPath]; ok {
+		r.pkg = a
+	} else if g.aliases[r.pkg] != "" {
+		r.pkg += "dns"
+	}
+
+	cfgBody, err := r.configBody(newConfig)
+	if err != nil {
+		return err
+	}
+	body, err := r.providerBody(newProvider)
+	if err != nil {
+		return err
+	}
+
+	if a := g.alias(importPath, r.pkg); a != r.pkg {
+		return fmt.Errorf("包名 %s 冲突", r.pkg)
+	}
+	for imp, a := range r.used {
+		g.imports[imp] = a
+		g.aliases[a] = imp
+	}
+
+	codes := make([]string, len(p.codes))
+	for i, c := range p.codes {
+		codes[i] = strconv.Quote(c)
+	}
+	for _, c := range codes {
+		g.entries = append(g.entries, fmt.Sprintf("%s: {keys: []string{%s}, build: %s},", c, strings.Join(r.keys, ", "), name))
+	}
+	g.funcs = append(g.funcs,
+		fmt.Sprintf("func %s(e *legoEnv) *%s.Config {\n%s}", r.cfgFunc, r.pkg, cfgBody),
+		fmt.Sprintf("func %s(e *legoEnv) (challenge.Provider, error) %s", name, body),
+	)
+	return nil
+}
+
+// versionRegexp 主版本号路径后缀，如 /v2
+var versionRegexp = regexp.MustCompile(`^v[0-9]+$`)
+
+func fileImports(f *ast.File) map[string]string {
+	imports := map[string]string{}
+	for _, imp := range f.Imports {
+		p, _ := strconv.Unquote(imp.Path.Value)
+		name := path.Base(p)
+		if versionRegexp.MatchString(name) {
+			name = path.Base(path.Dir(p))
+		}
+		name = strings.TrimPrefix(strings.TrimSuffix(name, ".go"), "go-")
+		if i := strings.Index(name, "."); i >= 0 {
+			name = name[:i]
+		}
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = p
+	}
+	return imports
+}
+
+func exportName(dir string) string {
+	var b strings.Builder
+	upper := true
+	for _, c := range dir {
+		if c < '0' || (c > '9' && c < 'A') || (c > 'Z' && c < 'a') || c > 'z' {
+			upper = true
+			continue
+		}
+		if upper && c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		upper = false
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// configBody 把 NewDefaultConfig 中读取环境变量的字段改为从 legoEnv 读取，默认值沿用 lego 的 NewDefaultConfig
+func (r *rewriter) configBody(fn *ast.FuncDecl) (string, error) {
+	if len(fn.Body.List) != 1 {
+		return "", fmt.Errorf("NewDefaultConfig 结构不支持")
+	}
+	ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return "", fmt.Errorf("NewDefaultConfig 结构不支持")
+	}
+	lit := compositeLit(ret.Results[0])
+	if lit == nil {
+		return "", fmt.Errorf("NewDefaultConfig 结构不支持")
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "config := %s.NewDefaultConfig()\n", r.pkg)
+	hasTimeout := false
+	if err := r.configFields(&b, "config", lit, &hasTimeout); err != nil {
+		return "", err
+	}
+	if hasTimeout {
+		b.WriteString("config.PropagationTimeout = e.propagationTimeout(config.PropagationTimeout)\n")
+	}
+	b.WriteString("return config\n")
+	return b.String(), nil
+}
+
+func compositeLit(e ast.Expr) *ast.CompositeLit {
+	if u, ok := e.(*ast.UnaryExpr); ok && u.Op == token.AND {
+		e = u.X
+	}
+	lit, _ := e.(*ast.CompositeLit)
+	return lit
+}
+
+func (r *rewriter) configFields(b *strings.Builder, prefix string, lit *ast.CompositeLit, hasTimeout *bool) error {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			return fmt.Errorf("NewDefaultConfig 结构不支持")
+		}
+		if !ast.IsExported(key.Name) && usesEnv(kv.Value) {
+			return fmt.Errorf("NewDefaultConfig 字段 %s 未导出", key.Name)
+		}
+		field := prefix + "." + key.Name
+		if prefix == "config" && key.Name == "PropagationTimeout" {
+			*hasTimeout = true
+		}
+		if !usesEnv(kv.Value) {
+			continue
+		}
+		if sub := compositeLit(kv.Value); sub != nil {
+			if err := r.configFields(b, field, sub, hasTimeout); err != nil {
+				return err
+			}
+			continue
+		}
+		call, ok := kv.Value.(*ast.CallExpr)
+		if !ok || !isEnvCall(call) {
+			return fmt.Errorf("NewDefaultConfig 字段 %s 结构不支持", key.Name)
+		}
+		// 默认值使用 lego NewDefaultConfig 已设置的值，避免引用 provider 包内未导出的常量
+		switch sel := call.Fun.(*ast.SelectorExpr); sel.Sel.Name {
+		case "GetOrDefaultString", "GetOrDefaultBool", "GetOrDefaultInt", "GetOrDefaultSecond", "GetOneWithFallback":
+			if len(call.Args) < 2 {
+				return fmt.Errorf("NewDefaultConfig 字段 %s 结构不支持", key.Name)
+			}
+			call.Args[1] = ast.NewIdent(field)
+		}
+		if err := r.rewrite(call); err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "%s = %s\n", field, r.print(call))
+	}
+	return nil
+}
+
+func usesEnv(n ast.Node) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && id.Name == "env" {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+func isEnvCall(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	id, ok := sel.X.(*ast.Ident)
+	return ok && id.Name == "env"
+}
+
+// providerBody 改写 NewDNSProvider 的函数体
+func (r *rewriter) providerBody(fn *ast.FuncDecl) (string, error) {
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE {
+				for _, l := range n.Lhs {
+					if id, ok := l.(*ast.Ident); ok {
+						r.locals[id.Name] = true
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for _, id := range n.Names {
+				r.locals[id.Name] = true
+			}
+		case *ast.Field:
+			for _, id := range n.Names {
+				r.locals[id.Name] = true
+			}
+		case *ast.RangeStmt:
+			for _, e := range []ast.Expr{n.Key, n.Value} {
+				if id, ok := e.(*ast.Ident); ok {
+					r.locals[id.Name] = true
+				}
+			}
+		}
+		return true
+	})
+	for name := range r.locals {
+		if name != "_" && (r.decls[name] || name == "e") {
+			return "", fmt.Errorf("局部变量 %s 与包级标识符重名", name)
+		}
+	}
+	if err := r.rewrite(fn.Body); err != nil {
+		return "", err
+	}
+	return r.print(fn.Body), nil
+}
+
+// rewrite 就地改写语法树：env 调用改为 legoEnv，包级标识符加上包名，导入包换成生成文件中的别名
+func (r *rewriter) rewrite(root ast.Node) error {
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		if r.err != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if isEnvCall(n) {
+				r.envCall(n)
+				for _, a := range n.Args {
+					ast.Inspect(a, func(c ast.Node) bool { return visit(c) })
+				}
+				return false
+			}
+			if id, ok := n.Fun.(*ast.Ident); ok && id.Name == "NewDefaultConfig" && !r.locals[id.Name] {
+				id.Name = r.cfgFunc
+				n.Args = []ast.Expr{ast.NewIdent("e")}
+				return false
+			}
+		case *ast.SelectorExpr:
+			if id, ok := n.X.(*ast.Ident); ok && !r.locals[id.Name] && !r.decls[id.Name] {
+				if strings.Contains(id.Name, ".") {
+					return false
+				}
+				imp, ok := r.imports[id.Name]
+				if !ok {
+					r.err = fmt.Errorf("无法识别 %s.%s", id.Name, n.Sel.Name)
+					return false
+				}
+				if imp == envPath && (n.Sel.Name == "ParseSecond" || n.Sel.Name == "ParseString") {
+					r.used[imp] = "env"
+					id.Name = "env"
+					return false
+				}
+				if strings.Contains(imp+"/", "/internal/") {
+					r.err = fmt.Errorf("使用了内部包 %s", imp)
+					return false
+				}
+				if deniedImports[imp] || imp == envPath {
+					r.err = fmt.Errorf("使用了 %s", imp)
+					return false
+				}
+				r.used[imp] = r.importAlias(imp, id.Name)
+				id.Name = r.used[imp]
+				return false
+			}
+			if !ast.IsExported(n.Sel.Name) {
+				r.err = fmt.Errorf("引用了未导出的字段 %s", n.Sel.Name)
+				return false
+			}
+			ast.Inspect(n.X, func(c ast.Node) bool { return visit(c) })
+			return false
+		case *ast.CompositeLit:
+			if n.Type != nil {
+				ast.Inspect(n.Type, func(c ast.Node) bool { return visit(c) })
+			}
+			_, isMap := n.Type.(*ast.MapType)
+			for _, elt := range n.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if isMap {
+						ast.Inspect(kv.Key, func(c ast.Node) bool { return visit(c) })
+					} else if _, ok := kv.Key.(*ast.Ident); !ok {
+						ast.Inspect(kv.Key, func(c ast.Node) bool { return visit(c) })
+					}
+					ast.Inspect(kv.Value, func(c ast.Node) bool { return visit(c) })
+					continue
+				}
+				ast.Inspect(elt, func(c ast.Node) bool { return visit(c) })
+			}
+			return false
+		case *ast.Ident:
+			r.ident(n)
+			return false
+		}
+		return true
+	}
+	ast.Inspect(root, func(n ast.Node) bool { return visit(n) })
+	return r.err
+}
+
+func (r *rewriter) importAlias(imp, local string) string {
+	if a, ok := r.g.imports[imp]; ok {
+		return a
+	}
+	if a, ok := r.used[imp]; ok {
+		return a
+	}
+	taken := func(a string) bool {
+		if r.g.aliases[a] != "" || a == r.pkg {
+			return true
+		}
+		for _, u := range r.used {
+			if u == a {
+				return true
+			}
+		}
+		return false
+	}
+	a := local
+	for i := 2; taken(a); i++ {
+		a = fmt.Sprintf("%s%d", local, i)
+	}
+	return a
+}
+
+func (r *rewriter) ident(id *ast.Ident) {
+	name := id.Name
+	if r.locals[name] || strings.Contains(name, ".") || name == "_" {
+		return
+	}
+	if r.decls[name] {
+		if !ast.IsExported(name) {
+			r.err = fmt.Errorf("引用了未导出的 %s", name)
+			return
+		}
+		id.Name = r.pkg + "." + name
+		return
+	}
+	if types.Universe.Lookup(name) != nil {
+		return
+	}
+	r.err = fmt.Errorf("无法识别 %s", name)
+}
+
+// envCall 改写 env 包调用并记录用到的环境变量名
+func (r *rewriter) envCall(call *ast.CallExpr) {
+	sel := call.Fun.(*ast.SelectorExpr)
+	fn := sel.Sel.Name
+	switch {
+	case envMethods[fn]:
+		sel.X.(*ast.Ident).Name = "e"
+	case fn == "GetOneWithFallback":
+		call.Fun = ast.NewIdent("legoGetOneWithFallback")
+		call.Args = append([]ast.Expr{ast.NewIdent("e")}, call.Args...)
+	default:
+		r.err = fmt.Errorf("不支持 env.%s", fn)
+		return
+	}
+
+	var names []ast.Expr
+	args := call.Args
+	switch fn {
+	case "Get":
+		names = args
+	case "GetWithFallback":
+		for _, a := range args {
+			lit, ok := a.(*ast.CompositeLit)
+			if !ok {
+				r.err = fmt.Errorf("env.GetWithFallback 参数不支持")
+				return
+			}
+			names = append(names, lit.Elts...)
+		}
+	case "GetOneWithFallback":
+		// 已插入 e 参数：e, main, default, fn, names...
+		names = append([]ast.Expr{args[1]}, args[4:]...)
+	default:
+		names = args[:1]
+	}
+	for _, n := range names {
+		switch n := n.(type) {
+		case *ast.Ident:
+			if !r.decls[n.Name] || !ast.IsExported(n.Name) {
+				r.err = fmt.Errorf("环境变量名 %s 不支持", n.Name)
+				return
+			}
+			r.key(r.pkg + "." + n.Name)
+		case *ast.BasicLit:
+			r.key(n.Value)
+		default:
+			r.err = fmt.Errorf("环境变量名 %s 不支持", r.print(n))
+			return
+		}
+	}
+}
+
+func (r *rewriter) key(k string) {
+	if !r.seen[k] {
+		r.seen[k] = true
+		r.keys = append(r.keys, k)
+	}
+}
+
+func (r *rewriter) print(n ast.Node) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, r.g.fset, n); err != nil {
+		r.err = err
+	}
+	return buf.String()
+}
+
+func (g *generator) write() error {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen_lego_generic.go; DO NOT EDIT.\n\npackage apply\n\nimport (\n")
+	paths := make([]string, 0, len(g.imports))
+	for p := range g.imports {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if a := g.imports[p]; a != path.Base(p) {
+			fmt.Fprintf(&b, "%s %q\n", a, p)
+		} else {
+			fmt.Fprintf(&b, "%q\n", p)
+		}
+	}
+	b.WriteString(")\n\n")
+	b.WriteString("// legoGenericProviders 可通过 lego-generic 授权使用的 lego DNS provider，键为 lego 的 provider 代码\n")
+	b.WriteString("var legoGenericProviders = map[string]legoGenericProvider{\n")
+	sort.Strings(g.entries)
+	for _, e := range g.entries {
+		b.WriteString(e + "\n")
+	}
+	b.WriteString("}\n\n")
+	for _, f := range g.funcs {
+		b.WriteString(f + "\n\n")
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		os.WriteFile(output, b.Bytes(), 0o644)
+		return fmt.Errorf("格式化生成代码失败: %v", err)
+	}
+	return os.WriteFile(output, src, 0o644)
+}
@@ -0,0 +1,125 @@
+package apply
+
+import (
+	certDeploy "ALLinSSL/backend/internal/cert/deploy"
+	"ALLinSSL/backend/public"
+	"context"
+	"fmt"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/http01"
+	"github.com/go-acme/lego/v4/providers/http/webroot"
+	"net"
+	"path"
+	"strconv"
+)
+
+// sshHTTPProvider 通过 ssh 授权把验证文件写入远程网站根目录
+type sshHTTPProvider struct {
+	ctx        context.Context
+	providerID string
+	webroot    string
+	logger     *public.Logger
+}
+
+func (p *sshHTTPProvider) Present(domain, token, keyAuth string) error {
+	files := []certDeploy.RemoteFile{
+		{Path: path.Join(p.webroot, http01.ChallengePath(token)), Content: keyAuth},
+	}
+	err := certDeploy.WriteFilesViaSSH(p.ctx, p.providerID, files, "", "", p.logger)
+	if err != nil {
+		return fmt.Errorf("上传 HTTP-01 验证文件失败: %v", err)
+	}
+	return nil
+}
+
+func (p *sshHTTPProvider) CleanUp(domain, token, keyAuth string) error {
+	rmCmd := fmt.Sprintf("rm -f %q", path.Join(p.webroot, http01.ChallengePath(token)))
+	// 取消执行后仍需清理验证文件
+	err := certDeploy.WriteFilesViaSSH(context.Background(), p.providerID, nil, "", rmCmd, p.logger)
+	if err != nil {
+		return fmt.Errorf("清理 HTTP-01 验证文件失败: %v", err)
+	}
+	return nil
+}
+
+// splitBindAddr 解析监听地址，支持 "80"、":80"、"0.0.0.0:80" 等写法
+func splitBindAddr(v any, defaultPort string) (string, string, error) {
+	var addr string
+	switch val := v.(type) {
+	case nil:
+		return "", defaultPort, nil
+	case float64:
+		addr = strconv.Itoa(int(val))
+	case int:
+		addr = strconv.Itoa(val)
+	case string:
+		addr = val
+	default:
+		return "", "", fmt.Errorf("监听地址格式错误")
+	}
+	if addr == "" {
+		return "", defaultPort, nil
+	}
+	if _, err := strconv.Atoi(addr); err == nil {
+		return "", addr, nil
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", "", fmt.Errorf("监听地址格式错误: %v", err)
+	}
+	if port == "" {
+		port = defaultPort
+	}
+	return host, port, nil
+}
+
+// GetHTTPProvider 根据 http_mode 创建 HTTP-01 验证提供者
+//
+//	webroot:    将验证文件写入本地网站根目录
+//	standalone: 启动内置 HTTP 服务响应验证请求
+//	ssh:        通过 ssh 授权将验证文件上传到远程网站根目录
+func GetHTTPProvider(ctx context.Context, cfg map[string]any, logger *public.Logger) (challenge.Provider, error) {
+	mode, ok := cfg["http_mode"].(string)
+	if !ok || mode == "" {
+		mode = "webroot"
+	}
+	switch mode {
+	case "webroot":
+		webrootPath, ok := cfg["webroot"].(string)
+		if !ok || webrootPath == "" {
+			return nil, fmt.Errorf("参数错误：webroot")
+		}
+		return webroot.NewHTTPProvider(webrootPath)
+	case "standalone":
+		host, port, err := splitBindAddr(cfg["http_addr"], "80")
+		if err != nil {
+			return nil, fmt.Errorf("参数错误：http_addr，%v", err)
+		}
+		if err := checkBindAddr(host, port); err != nil {
+			return nil, err
+		}
+		return http01.NewProviderServer(host, port), nil
+	case "ssh":
+		var providerID string
+		switch v := cfg["provider_id"].(type) {
+		case float64:
+			providerID = strconv.Itoa(int(v))
+		case string:
+			providerID = v
+		default:
+			return nil, fmt.Errorf("参数错误：provider_id")
+		}
+		webrootPath, ok := cfg["webroot"].(string)
+		if !ok || webrootPath == "" {
+			return nil, fmt.Errorf("参数错误：webroot")
+		}
+		return &sshHTTPProvider{
+			ctx:        ctx,
+			providerID: providerID,
+			webroot:    webrootPath,
+			logger:     logger,
+		}, nil
+	default:
+		return nil, fmt.Errorf("不支持的 HTTP-01 验证方式: %s", mode)
+	}
+}
@@ -0,0 +1,244 @@
+package apply
+
+import (
+	"ALLinSSL/backend/public"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"github.com/go-acme/lego/v4/challenge/http01"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func newTestLogger(t *testing.T) *public.Logger {
+	logger, err := public.NewLogger(filepath.Join(t.TempDir(), "apply.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(logger.Close)
+	return logger
+}
+
+func TestHTTPProviderWebroot(t *testing.T) {
+	root := t.TempDir()
+	provider, err := GetHTTPProvider(context.Background(), map[string]any{"http_mode": "webroot", "webroot": root}, newTestLogger(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = provider.Present("example.com", "token-1", "token-1.keyauth"); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(root, ".well-known", "acme-challenge", "token-1")
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("challenge file not written: %v", err)
+	}
+	if string(content) != "token-1.keyauth" {
+		t.Fatalf("unexpected challenge content %q", content)
+	}
+	if err = provider.CleanUp("example.com", "token-1", "token-1.keyauth"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(file); !os.IsNotExist(err) {
+		t.Fatalf("challenge file should be removed, stat err: %v", err)
+	}
+
+	if _, err = GetHTTPProvider(context.Background(), map[string]any{"http_mode": "webroot"}, nil); err == nil {
+		t.Fatal("expected missing webroot to fail")
+	}
+}
+
+func TestHTTPProviderStandalone(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	provider, err := GetHTTPProvider(context.Background(), map[string]any{"http_mode": "standalone", "http_addr": addr}, newTestLogger(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = provider.Present("example.com", "token-2", "token-2.keyauth"); err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "http://"+addr+http01.ChallengePath("token-2"), nil)
+	req.Host = "example.com"
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		provider.CleanUp("example.com", "token-2", "token-2.keyauth")
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "token-2.keyauth" {
+		t.Fatalf("unexpected response %d %q", resp.StatusCode, body)
+	}
+	// 监听端口被占用时在申请前报错
+	if _, err = GetHTTPProvider(context.Background(), map[string]any{"http_mode": "standalone", "http_addr": addr}, nil); err == nil {
+		t.Fatal("expected occupied port to fail")
+	}
+	if err = provider.CleanUp("example.com", "token-2", "token-2.keyauth"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = http.Get("http://" + addr + http01.ChallengePath("token-2")); err == nil {
+		t.Fatal("standalone server should be stopped after cleanup")
+	}
+}
+
+// startTestSSH 启动本地 SSH 服务，支持 sftp 子系统及在本机执行命令
+func startTestSSH(t *testing.T, user, password string) string {
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() == user && string(pass) == password {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("password rejected for %s", c.User())
+		},
+	}
+	config.AddHostKey(signer)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestSSH(conn, config)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func serveTestSSH(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			defer channel.Close()
+			for req := range requests {
+				var payload struct{ Value string }
+				ssh.Unmarshal(req.Payload, &payload)
+				switch {
+				case req.Type == "subsystem" && payload.Value == "sftp":
+					req.Reply(true, nil)
+					server, err := sftp.NewServer(channel)
+					if err == nil {
+						server.Serve()
+					}
+					return
+				case req.Type == "exec":
+					req.Reply(true, nil)
+					cmd := exec.Command("sh", "-c", payload.Value)
+					cmd.Stdout, cmd.Stderr = channel, channel.Stderr()
+					status := make([]byte, 4)
+					if err := cmd.Run(); err != nil {
+						binary.BigEndian.PutUint32(status, 1)
+					}
+					channel.SendRequest("exit-status", false, status)
+					return
+				default:
+					req.Reply(false, nil)
+				}
+			}
+		}()
+	}
+}
+
+// useTestDB 在临时目录中创建 data/data.db 并切换工作目录，测试结束后恢复
+func useTestDB(t *testing.T, schema string) *public.Sqlite {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "data"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "data", "data.db"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	s, err := public.NewSqlite("data/data.db", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+	if _, err = s.Conn.Exec(schema); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestHTTPProviderSSH(t *testing.T) {
+	addr := startTestSSH(t, "deploy", "secret")
+	host, port, _ := net.SplitHostPort(addr)
+	s := useTestDB(t, `create table access (id integer primary key autoincrement, config TEXT not null, type TEXT not null, create_time TEXT, update_time TEXT, name TEXT not null)`)
+	sshConfig, _ := json.Marshal(map[string]any{"host": host, "port": port, "user": "deploy", "password": "secret", "mode": "password"})
+	s.TableName = "access"
+	id, err := s.Insert(map[string]any{"config": string(sshConfig), "type": "ssh", "name": "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root := t.TempDir()
+	provider, err := GetHTTPProvider(context.Background(), map[string]any{"http_mode": "ssh", "provider_id": float64(id), "webroot": root}, newTestLogger(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = provider.Present("example.com", "token-3", "token-3.keyauth"); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(root, ".well-known", "acme-challenge", "token-3")
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("challenge file not uploaded: %v", err)
+	}
+	if string(content) != "token-3.keyauth" {
+		t.Fatalf("unexpected challenge content %q", content)
+	}
+	if err = provider.CleanUp("example.com", "token-3", "token-3.keyauth"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(file); !os.IsNotExist(err) {
+		t.Fatalf("challenge file should be removed, stat err: %v", err)
+	}
+}
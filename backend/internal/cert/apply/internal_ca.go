@@ -0,0 +1,98 @@
+package apply
+
+import (
+	"ALLinSSL/backend/internal/cert"
+	"ALLinSSL/backend/internal/cert/privateca"
+	"ALLinSSL/backend/public"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// applyInternal 使用内置私有 CA 签发证书，不需要域名验证，支持任意内部域名、IP 及有效期
+//
+// 签发的证书与 ACME 证书一样保存到证书表，并以相同的格式输出给部署节点。
+func applyInternal(ctx context.Context, cfg map[string]any, logger *public.Logger) (map[string]any, error) {
+	var caID string
+	switch v := cfg["private_ca_id"].(type) {
+	case float64:
+		caID = strconv.Itoa(int(v))
+	case string:
+		caID = v
+	}
+	if caID == "" {
+		return nil, fmt.Errorf("参数错误：private_ca_id")
+	}
+	domains, ok := cfg["domains"].(string)
+	if !ok || strings.TrimSpace(domains) == "" {
+		return nil, fmt.Errorf("参数错误：domains")
+	}
+	runId, ok := cfg["_runId"].(string)
+	if !ok {
+		return nil, fmt.Errorf("参数错误：_runId")
+	}
+	algorithms, err := parseAlgorithms(cfg["algorithm"])
+	if err != nil {
+		return nil, err
+	}
+	validityDays, err := parseIntCfg(cfg, "validity_days", 365)
+	if err != nil {
+		return nil, err
+	}
+	endDay, err := parseIntCfg(cfg, "end_day", 30)
+	if err != nil {
+		return nil, err
+	}
+	if endDay >= validityDays {
+		return nil, fmt.Errorf("续期天数 end_day 需小于证书有效期 validity_days")
+	}
+
+	var domainArr []string
+	normalized, _ := normalizeIdentifiers(strings.Split(domains, ","))
+	for _, d := range normalized {
+		if d = strings.TrimSpace(d); d != "" {
+			domainArr = append(domainArr, d)
+		}
+	}
+	multiAlgorithm := len(algorithms) > 1
+	results := make([]map[string]any, len(algorithms))
+	for i, alg := range algorithms {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var keyAlgorithm string
+		if multiAlgorithm {
+			keyAlgorithm = alg
+		}
+		certData, _, err := GetCert(runId, domainArr, keyAlgorithm, endDay, nil, logger)
+		if err == nil {
+			results[i] = certData
+			continue
+		}
+		logger.Debug(fmt.Sprintf("未获取到符合条件的本地%s证书:%s", keyAlgorithm, err.Error()))
+		logger.Debug(fmt.Sprintf("正在使用私有CA签发%s证书，域名: %s", alg, domains))
+		certStr, keyStr, issuerCertStr, err := privateca.Issue(caID, domainArr, AlgorithmMap[alg], validityDays)
+		if err != nil {
+			return nil, err
+		}
+		sha256, err := cert.SaveCert("workflow", keyStr, certStr, issuerCertStr, runId)
+		if err != nil {
+			return nil, err
+		}
+		err = cert.UpdateCert(sha256, map[string]any{
+			"key_source":    "generated",
+			"acme_ca":       privateca.ProviderName,
+			"private_ca_id": caID,
+		})
+		if err != nil {
+			logger.Debug("记录证书签发信息失败:", err)
+		}
+		results[i] = map[string]any{
+			"cert":       certStr,
+			"key":        keyStr,
+			"issuerCert": issuerCertStr,
+		}
+	}
+	return mergeCertResults(algorithms, results), nil
+}
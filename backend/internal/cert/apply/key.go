@@ -0,0 +1,129 @@
+package apply
+
+import (
+	"ALLinSSL/backend/internal/cert"
+	"ALLinSSL/backend/public"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"strconv"
+	"strings"
+)
+
+// getReusableKey 获取上一张同算法证书的私钥用于续期，节点修改了算法或私钥曾因泄露被吊销时不再复用
+func getReusableKey(runId string, domainArr []string, algorithm string, logger *public.Logger) (crypto.PrivateKey, error) {
+	lastCert, _, err := GetLastCert(runId, domainArr, algorithm)
+	if err != nil {
+		return nil, err
+	}
+	keyStr, ok := lastCert["key"].(string)
+	if !ok || keyStr == "" {
+		return nil, fmt.Errorf("上一张证书没有私钥")
+	}
+	privateKey, err := public.ParsePrivateKey([]byte(keyStr))
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("不支持的私钥类型")
+	}
+	if keyAlgorithm := publicKeyAlgorithm(signer.Public()); keyAlgorithm != algorithm {
+		return nil, fmt.Errorf("上一张证书的私钥算法 %s 与配置的 %s 不一致", keyAlgorithm, algorithm)
+	}
+	keySha256, err := public.GetPublicKeySHA256(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	compromised, err := cert.IsKeyCompromised(keySha256)
+	if err != nil {
+		return nil, err
+	}
+	if compromised {
+		return nil, fmt.Errorf("私钥曾因泄露被吊销，不能复用")
+	}
+	logger.Debug(fmt.Sprintf("复用上一张证书的私钥，公钥指纹：%s", keySha256))
+	return privateKey, nil
+}
+
+// publicKeyAlgorithm 返回公钥对应的算法名称，格式与 AlgorithmMap 的键一致，如 EC256、RSA2048
+func publicKeyAlgorithm(pub crypto.PublicKey) string {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return "RSA" + strconv.Itoa(pub.N.BitLen())
+	case *ecdsa.PublicKey:
+		return "EC" + strconv.Itoa(pub.Curve.Params().BitSize)
+	default:
+		return ""
+	}
+}
+
+// oidTLSFeature TLS Feature 扩展（RFC 7633），包含 status_request(5) 时即 OCSP Must-Staple
+var oidTLSFeature = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}
+
+// csrHasMustStaple 判断 CSR 是否请求了 OCSP Must-Staple 扩展
+func csrHasMustStaple(csr *x509.CertificateRequest) bool {
+	for _, ext := range csr.Extensions {
+		if !ext.Id.Equal(oidTLSFeature) {
+			continue
+		}
+		var features []int
+		if _, err := asn1.Unmarshal(ext.Value, &features); err != nil {
+			return false
+		}
+		for _, f := range features {
+			if f == 5 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseCSR 解析用户提供的 CSR 及可选的私钥，并校验 CSR 中的域名与节点配置一致
+func parseCSR(csrStr, keyStr string, domainArr []string) (*x509.CertificateRequest, crypto.PrivateKey, error) {
+	csr, err := certcrypto.PemDecodeTox509CSR([]byte(strings.TrimSpace(csrStr)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("解析 CSR 失败: %v", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, nil, fmt.Errorf("CSR 签名校验失败: %v", err)
+	}
+	csrDomains := csr.DNSNames
+	if csr.Subject.CommonName != "" {
+		csrDomains = append(csrDomains, csr.Subject.CommonName)
+	}
+	for _, ip := range csr.IPAddresses {
+		csrDomains = append(csrDomains, ip.String())
+	}
+	if !public.ContainsAllIgnoreBRepeats(csrDomains, domainArr) || !public.ContainsAllIgnoreBRepeats(domainArr, csrDomains) {
+		return nil, nil, fmt.Errorf("CSR 中的域名 %s 与配置的域名 %s 不一致", strings.Join(csrDomains, ","), strings.Join(domainArr, ","))
+	}
+	if strings.TrimSpace(keyStr) == "" {
+		return csr, nil, nil
+	}
+	privateKey, err := public.ParsePrivateKey([]byte(strings.TrimSpace(keyStr)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("解析 CSR 私钥失败: %v", err)
+	}
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("不支持的私钥类型")
+	}
+	csrKeySha256, err := public.GetPublicKeySHA256(csr.PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keySha256, err := public.GetPublicKeySHA256(signer.Public())
+	if err != nil {
+		return nil, nil, err
+	}
+	if csrKeySha256 != keySha256 {
+		return nil, nil, fmt.Errorf("CSR 与私钥不匹配")
+	}
+	return csr, privateKey, nil
+}
@@ -0,0 +1,90 @@
+package apply
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"testing"
+)
+
+func TestParseCSR(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "example.com"},
+		DNSNames: []string{"example.com", "www.example.com"},
+	}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+
+	if _, _, err := parseCSR(csrPEM, keyPEM, []string{"www.example.com", "example.com"}); err != nil {
+		t.Fatalf("parseCSR failed: %v", err)
+	}
+	if _, privateKey, err := parseCSR(csrPEM, "", []string{"example.com", "www.example.com"}); err != nil || privateKey != nil {
+		t.Fatalf("parseCSR without key = %v, %v", privateKey, err)
+	}
+	if _, _, err := parseCSR(csrPEM, "", []string{"example.com"}); err == nil {
+		t.Fatal("expected domain mismatch error")
+	}
+
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherDer, _ := x509.MarshalECPrivateKey(otherKey)
+	otherPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: otherDer}))
+	if _, _, err := parseCSR(csrPEM, otherPEM, []string{"example.com", "www.example.com"}); err == nil {
+		t.Fatal("expected key mismatch error")
+	}
+}
+
+func TestCSRHasMustStaple(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	for _, mustStaple := range []bool{true, false} {
+		der, err := certcrypto.GenerateCSR(key, "example.com", nil, mustStaple)
+		if err != nil {
+			t.Fatal(err)
+		}
+		csr, err := x509.ParseCertificateRequest(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := csrHasMustStaple(csr); got != mustStaple {
+			t.Fatalf("csrHasMustStaple = %v, want %v", got, mustStaple)
+		}
+	}
+}
+
+func TestPublicKeyAlgorithm(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 节点从 RSA2048 改为 EC256 时，上一张证书的 RSA 私钥不能复用
+	if got := publicKeyAlgorithm(rsaKey.Public()); got != "RSA2048" {
+		t.Fatalf("publicKeyAlgorithm(rsa) = %s", got)
+	}
+	if got := publicKeyAlgorithm(ecKey.Public()); got != "EC256" {
+		t.Fatalf("publicKeyAlgorithm(ec) = %s", got)
+	}
+	key, err := certcrypto.GeneratePrivateKey(AlgorithmMap["EC384"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := publicKeyAlgorithm(key.(crypto.Signer).Public()); got != "EC384" {
+		t.Fatalf("publicKeyAlgorithm(ec384) = %s", got)
+	}
+}
@@ -0,0 +1,75 @@
+package apply
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseAlgorithms 解析节点配置中的证书算法，支持单个算法、逗号分隔的字符串或数组，默认 RSA2048
+func parseAlgorithms(v any) ([]string, error) {
+	var items []string
+	switch val := v.(type) {
+	case nil:
+	case string:
+		items = strings.Split(val, ",")
+	case []any:
+		for _, item := range val {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("参数错误：algorithm")
+			}
+			items = append(items, s)
+		}
+	default:
+		return nil, fmt.Errorf("参数错误：algorithm")
+	}
+
+	var algorithms []string
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" || containsString(algorithms, item) {
+			continue
+		}
+		if _, ok := AlgorithmMap[item]; !ok {
+			return nil, fmt.Errorf("不支持的证书算法: %s", item)
+		}
+		algorithms = append(algorithms, item)
+	}
+	if len(algorithms) == 0 {
+		algorithms = []string{"RSA2048"}
+	}
+	return algorithms, nil
+}
+
+// mergeCertResults 合并各算法的证书作为节点输出
+//
+// 顶层的 cert、key、issuerCert 为主证书（第一个算法），保持与单证书时一致；
+// 多个算法时在 certs 中按顺序列出全部证书，供部署节点选择其一或全部部署。
+func mergeCertResults(algorithms []string, results []map[string]any) map[string]any {
+	if len(results) == 1 {
+		return results[0]
+	}
+	data := map[string]any{
+		"cert":       results[0]["cert"],
+		"key":        results[0]["key"],
+		"issuerCert": results[0]["issuerCert"],
+	}
+	skip := true
+	certs := make([]any, 0, len(results))
+	for i, result := range results {
+		if v, ok := result["skip"].(bool); !ok || !v {
+			skip = false
+		}
+		certs = append(certs, map[string]any{
+			"algorithm":  algorithms[i],
+			"cert":       result["cert"],
+			"key":        result["key"],
+			"issuerCert": result["issuerCert"],
+		})
+	}
+	data["certs"] = certs
+	if skip {
+		data["skip"] = true
+	}
+	return data
+}
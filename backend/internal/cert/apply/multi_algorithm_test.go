@@ -0,0 +1,44 @@
+package apply
+
+import "testing"
+
+func TestParseAlgorithms(t *testing.T) {
+	algorithms, err := parseAlgorithms("EC256, RSA2048,EC256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(algorithms) != 2 || algorithms[0] != "EC256" || algorithms[1] != "RSA2048" {
+		t.Fatalf("unexpected algorithms: %v", algorithms)
+	}
+	if algorithms, _ := parseAlgorithms([]any{"RSA4096"}); len(algorithms) != 1 || algorithms[0] != "RSA4096" {
+		t.Fatalf("unexpected algorithms: %v", algorithms)
+	}
+	if algorithms, _ := parseAlgorithms(nil); len(algorithms) != 1 || algorithms[0] != "RSA2048" {
+		t.Fatalf("expected RSA2048 by default, got %v", algorithms)
+	}
+	if _, err := parseAlgorithms("ED25519"); err == nil {
+		t.Fatal("expected unsupported algorithm to fail")
+	}
+}
+
+func TestMergeCertResults(t *testing.T) {
+	single := map[string]any{"cert": "a", "key": "ka", "issuerCert": "ia"}
+	if data := mergeCertResults([]string{"EC256"}, []map[string]any{single}); data["cert"] != "a" || data["certs"] != nil {
+		t.Fatalf("unexpected single output: %v", data)
+	}
+
+	data := mergeCertResults([]string{"EC256", "RSA2048"}, []map[string]any{
+		{"cert": "a", "key": "ka", "issuerCert": "ia", "skip": true},
+		{"cert": "b", "key": "kb", "issuerCert": "ib"},
+	})
+	if data["cert"] != "a" || data["skip"] != nil {
+		t.Fatalf("unexpected primary certificate: %v", data)
+	}
+	certs, ok := data["certs"].([]any)
+	if !ok || len(certs) != 2 {
+		t.Fatalf("unexpected certs: %v", data["certs"])
+	}
+	if c := certs[1].(map[string]any); c["algorithm"] != "RSA2048" || c["cert"] != "b" {
+		t.Fatalf("unexpected RSA certificate: %v", c)
+	}
+}
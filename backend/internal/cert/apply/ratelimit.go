@@ -0,0 +1,242 @@
+package apply
+
+import (
+	"ALLinSSL/backend/public"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-acme/lego/v4/acme"
+	"golang.org/x/net/publicsuffix"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const rateLimitedErr = "urn:ietf:params:acme:error:rateLimited"
+
+// errLocalRateLimit 根据本地下单记录判断将超出 CA 的限制，不再向 CA 下单
+var errLocalRateLimit = errors.New("超出CA限制")
+
+// caRateLimit CA 公开的签发限制，参考 https://letsencrypt.org/docs/rate-limits/
+type caRateLimit struct {
+	// 相同域名组合的证书每周最多签发数量
+	DuplicatePerWeek int
+	// 每个注册域名每周最多签发的证书数量
+	PerDomainPerWeek int
+	// 每小时最多失败的订单数量
+	FailedPerHour int
+}
+
+var caRateLimits = map[string]caRateLimit{
+	"Let's Encrypt": {DuplicatePerWeek: 5, PerDomainPerWeek: 50, FailedPerHour: 5},
+}
+
+// caAttempt 备用 CA 及账号
+type caAttempt struct {
+	CA    string
+	Email string
+	EabId string
+}
+
+// parseFallbackCAs 解析备用 CA 列表，支持数组或 JSON 字符串，未指定 email 时使用主账号的邮箱
+//
+//	[{"ca": "zerossl", "email": "a@example.com"}, {"ca": "google", "eabId": 1}]
+func parseFallbackCAs(v any, email string) ([]caAttempt, error) {
+	var items []any
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if strings.TrimSpace(val) == "" {
+			return nil, nil
+		}
+		if err := json.Unmarshal([]byte(val), &items); err != nil {
+			return nil, fmt.Errorf("参数错误：fallback_cas，%v", err)
+		}
+	case []any:
+		items = val
+	default:
+		return nil, fmt.Errorf("参数错误：fallback_cas")
+	}
+
+	var result []caAttempt
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("参数错误：fallback_cas")
+		}
+		attempt := caAttempt{Email: email}
+		attempt.CA, _ = m["ca"].(string)
+		if e, ok := m["email"].(string); ok && e != "" {
+			attempt.Email = e
+		}
+		switch id := m["eabId"].(type) {
+		case float64:
+			attempt.EabId = strconv.Itoa(int(id))
+		case string:
+			attempt.EabId = id
+		}
+		if attempt.CA == "" && attempt.EabId == "" {
+			return nil, fmt.Errorf("参数错误：fallback_cas 中的 ca 不能为空")
+		}
+		result = append(result, attempt)
+	}
+	return result, nil
+}
+
+// isFailoverError 判断错误是否为限流、CAA 限制、CA 服务端错误或网络错误，此时可切换到备用 CA
+func isFailoverError(err error) bool {
+	if errors.Is(err, errLocalRateLimit) || errors.Is(err, errCAAForbidden) {
+		return true
+	}
+	var problem *acme.ProblemDetails
+	if errors.As(err, &problem) {
+		return problem.Type == rateLimitedErr || problem.HTTPStatus == 429 || problem.HTTPStatus >= 500
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	// 验证失败等错误由 lego 汇总为文本，只能按错误类型匹配
+	return strings.Contains(err.Error(), rateLimitedErr)
+}
+
+// registeredDomain 获取域名的注册域名（如 www.example.co.uk 为 example.co.uk），IP 地址原样返回
+func registeredDomain(domain string) string {
+	domain = strings.TrimPrefix(strings.ToLower(domain), "*.")
+	if net.ParseIP(domain) != nil {
+		return domain
+	}
+	if d, err := publicsuffix.EffectiveTLDPlusOne(domain); err == nil {
+		return d
+	}
+	return domain
+}
+
+func getOrderLogSqlite() (*public.Sqlite, error) {
+	s, err := public.NewSqlite("data/data.db", "")
+	if err != nil {
+		return nil, err
+	}
+	s.TableName = "order_log"
+	return s, nil
+}
+
+// orderKey 域名组合的标识，与顺序及大小写无关
+func orderKey(domains []string) string {
+	list := make([]string, 0, len(domains))
+	for _, d := range domains {
+		list = append(list, strings.ToLower(d))
+	}
+	sort.Strings(list)
+	return strings.Join(list, ",")
+}
+
+func registeredDomains(domains []string) []string {
+	var result []string
+	for _, d := range domains {
+		if r := registeredDomain(d); !containsString(result, r) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// orderStatus 下单结果的记录状态：CA 返回的错误记为 fail，计入 CA 的失败次数限制；
+// 网络错误、取消执行等未得到 CA 答复的错误记为 error，不计入
+func orderStatus(orderErr error) string {
+	if orderErr == nil {
+		return "success"
+	}
+	var problem *acme.ProblemDetails
+	if errors.As(orderErr, &problem) {
+		return "fail"
+	}
+	// 验证失败由 lego 汇总为文本，包含 CA 返回的错误类型
+	if strings.Contains(orderErr.Error(), "urn:ietf:params:acme:error:") {
+		return "fail"
+	}
+	return "error"
+}
+
+// RecordOrder 按注册域名及 CA 记录每次向 CA 下单的结果，下单前的本地错误不应记录
+func RecordOrder(domains []string, ca string, orderErr error) {
+	s, err := getOrderLogSqlite()
+	if err != nil {
+		return
+	}
+	defer s.Close()
+	status, errMsg, rateLimited := orderStatus(orderErr), "", 0
+	if orderErr != nil {
+		errMsg = orderErr.Error()
+		if isFailoverError(orderErr) && strings.Contains(errMsg, "rateLimited") {
+			rateLimited = 1
+		}
+	}
+	now := time.Now().Format("2006-01-02 15:04:05")
+	key := orderKey(domains)
+	for _, d := range registeredDomains(domains) {
+		_, _ = s.Insert(map[string]any{
+			"domain":       d,
+			"domains":      key,
+			"ca":           ca,
+			"status":       status,
+			"error":        errMsg,
+			"rate_limited": rateLimited,
+			"create_time":  now,
+		})
+	}
+}
+
+// checkRateLimit 根据本地下单记录判断本次下单（orders 张证书）是否会超出 CA 的限制，
+// 超出时返回错误，仅剩一次额度时给出提醒
+func checkRateLimit(domains []string, ca string, orders int, logger *public.Logger) error {
+	limit, ok := caRateLimits[ca]
+	if !ok {
+		return nil
+	}
+	s, err := getOrderLogSqlite()
+	if err != nil {
+		logger.Debug("读取下单记录失败，跳过限制检查:", err)
+		return nil
+	}
+	defer s.Close()
+	now := time.Now()
+	week := now.Add(-7 * 24 * time.Hour).Format("2006-01-02 15:04:05")
+	hour := now.Add(-time.Hour).Format("2006-01-02 15:04:05")
+	key := orderKey(domains)
+	regDomains := registeredDomains(domains)
+
+	check := func(name string, count int64, max int) error {
+		switch {
+		case int(count)+orders > max:
+			return fmt.Errorf("%w：%s已达 %d/%d，请稍后再试或使用其他CA", errLocalRateLimit, name, count, max)
+		case int(count)+orders == max:
+			logger.Info(fmt.Sprintf("即将达到CA限制：%s已达 %d/%d", name, count, max))
+		}
+		return nil
+	}
+
+	// 同一域名组合的证书按第一个注册域名统计，避免重复计数
+	count, err := s.Where("domains=? and domain=? and ca=? and status='success' and create_time>=?", []any{key, regDomains[0], ca, week}).Count()
+	if err == nil {
+		if err = check("相同域名组合每周签发的证书数", count, limit.DuplicatePerWeek); err != nil {
+			return err
+		}
+	}
+	for _, d := range regDomains {
+		count, err = s.Where("domain=? and ca=? and status='success' and create_time>=?", []any{d, ca, week}).Count()
+		if err == nil {
+			if err = check(fmt.Sprintf("域名 %s 每周签发的证书数", d), count, limit.PerDomainPerWeek); err != nil {
+				return err
+			}
+		}
+	}
+	count, err = s.Where("domains=? and domain=? and ca=? and status='fail' and create_time>=?", []any{key, regDomains[0], ca, hour}).Count()
+	if err == nil && int(count) >= limit.FailedPerHour {
+		return fmt.Errorf("%w：相同域名组合每小时失败的订单数已达 %d/%d，请检查验证配置后再试", errLocalRateLimit, count, limit.FailedPerHour)
+	}
+	return nil
+}
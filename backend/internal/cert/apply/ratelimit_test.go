@@ -0,0 +1,79 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-acme/lego/v4/acme"
+	"testing"
+)
+
+func TestParseFallbackCAs(t *testing.T) {
+	attempts, err := parseFallbackCAs(`[{"ca": "zerossl"}, {"ca": "google", "email": "b@example.com", "eabId": 3}]`, "a@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attempts) != 2 || attempts[0] != (caAttempt{CA: "zerossl", Email: "a@example.com"}) ||
+		attempts[1] != (caAttempt{CA: "google", Email: "b@example.com", EabId: "3"}) {
+		t.Fatalf("unexpected fallback CAs: %+v", attempts)
+	}
+	if _, err := parseFallbackCAs([]any{map[string]any{"email": "a@example.com"}}, ""); err == nil {
+		t.Fatal("expected fallback without ca to fail")
+	}
+}
+
+func TestIsFailoverError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{&acme.ProblemDetails{Type: rateLimitedErr, HTTPStatus: 429}, true},
+		{fmt.Errorf("obtain: %w", &acme.ProblemDetails{Type: "urn:ietf:params:acme:error:serverInternal", HTTPStatus: 500}), true},
+		{&acme.ProblemDetails{Type: "urn:ietf:params:acme:error:unauthorized", HTTPStatus: 403}, false},
+		{fmt.Errorf("%w：test", errLocalRateLimit), true},
+		{fmt.Errorf("%w：test", errCAAForbidden), true},
+		{errors.New("acme: error: 400 :: urn:ietf:params:acme:error:dns"), false},
+	}
+	for _, c := range cases {
+		if got := isFailoverError(c.err); got != c.want {
+			t.Errorf("isFailoverError(%v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+}
+
+func TestOrderStatus(t *testing.T) {
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{nil, "success"},
+		{fmt.Errorf("obtain: %w", &acme.ProblemDetails{Type: "urn:ietf:params:acme:error:rejectedIdentifier", HTTPStatus: 400}), "fail"},
+		{errors.New("error: one or more domains had a problem:\n[a.com] acme: error: 403 :: urn:ietf:params:acme:error:unauthorized"), "fail"},
+		{fmt.Errorf("%w：test", errCAAForbidden), "error"},
+		{fmt.Errorf("解析CSR失败: %v", errors.New("asn1: syntax error")), "error"},
+		{fmt.Errorf("post: %w", context.Canceled), "error"},
+	}
+	for _, c := range cases {
+		if got := orderStatus(c.err); got != c.want {
+			t.Errorf("orderStatus(%v) = %s, want %s", c.err, got, c.want)
+		}
+	}
+}
+
+func TestRegisteredDomain(t *testing.T) {
+	cases := map[string]string{
+		"www.example.com":    "example.com",
+		"*.example.co.uk":    "example.co.uk",
+		"a.b.example.com.cn": "example.com.cn",
+		"192.0.2.1":          "192.0.2.1",
+		"Example.COM":        "example.com",
+	}
+	for domain, want := range cases {
+		if got := registeredDomain(domain); got != want {
+			t.Errorf("registeredDomain(%s) = %s, want %s", domain, got, want)
+		}
+	}
+	if orderKey([]string{"b.example.com", "A.example.com"}) != orderKey([]string{"a.example.com", "b.example.com"}) {
+		t.Fatal("expected order key to ignore order and case")
+	}
+}
@@ -0,0 +1,204 @@
+package apply
+
+import (
+	"ALLinSSL/backend/internal/cert"
+	"ALLinSSL/backend/internal/cert/privateca"
+	"ALLinSSL/backend/public"
+	"encoding/base64"
+	"fmt"
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RevokeReasonMap RFC 5280 吊销原因代码
+var RevokeReasonMap = map[string]uint{
+	"unspecified":          0,
+	"keyCompromise":        1,
+	"affiliationChanged":   3,
+	"superseded":           4,
+	"cessationOfOperation": 5,
+}
+
+// ParseRevokeReason 解析吊销原因，支持原因代码或名称，默认 unspecified
+func ParseRevokeReason(v any) (uint, error) {
+	switch val := v.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return checkRevokeReason(uint(val))
+	case int:
+		return checkRevokeReason(uint(val))
+	case string:
+		if val == "" {
+			return 0, nil
+		}
+		if reason, ok := RevokeReasonMap[val]; ok {
+			return reason, nil
+		}
+		code, err := strconv.Atoi(val)
+		if err != nil || code < 0 {
+			return 0, fmt.Errorf("不支持的吊销原因: %s", val)
+		}
+		return checkRevokeReason(uint(code))
+	default:
+		return 0, fmt.Errorf("吊销原因格式错误")
+	}
+}
+
+func checkRevokeReason(code uint) (uint, error) {
+	for _, reason := range RevokeReasonMap {
+		if reason == code {
+			return code, nil
+		}
+	}
+	return 0, fmt.Errorf("不支持的吊销原因代码: %d", code)
+}
+
+// getCADirURL 获取 CA 的 ACME 目录地址，自定义 CA 从账号信息中读取
+func getCADirURL(ca, email string) (string, error) {
+	if CADirURL := CADirURLMap[ca]; CADirURL != "" {
+		return CADirURL, nil
+	}
+	if caInfo, err := GetRegistryCA(ca); err == nil && caInfo != nil {
+		if CADirURL, _ := caInfo["dir_url"].(string); CADirURL != "" {
+			return CADirURL, nil
+		}
+	}
+	db, err := GetSqlite()
+	if err != nil {
+		return "", err
+	}
+	defer db.Close()
+	var data []map[string]any
+	if email != "" {
+		data, err = db.Where("email=? and type=?", []interface{}{email, ca}).Select()
+	} else {
+		data, err = db.Where("type=?", []interface{}{ca}).Select()
+	}
+	if err != nil {
+		return "", err
+	}
+	for _, v := range data {
+		if CADirURL, ok := v["CADirURL"].(string); ok && CADirURL != "" {
+			return CADirURL, nil
+		}
+	}
+	return "", fmt.Errorf("未找到CA【%s】请求地址", ca)
+}
+
+// RevokeCert 向签发 CA 吊销证书并标记证书记录
+//
+//	mode 为 account 时使用签发账号（accounts.db）签名吊销请求，
+//	为 key 时使用证书自身的私钥签名，为空时有签发账号记录则使用账号，否则使用私钥。
+//	email、ca 为空时使用证书记录中的签发账号。
+func RevokeCert(id string, reason uint, mode, email, ca string, httpClient *http.Client, logger *public.Logger) error {
+	certRow, err := cert.GetCertRow(id)
+	if err != nil {
+		return err
+	}
+	if revoked, ok := certRow["revoked"].(int64); ok && revoked == 1 {
+		return fmt.Errorf("证书已吊销")
+	}
+	certStr, ok := certRow["cert"].(string)
+	if !ok || certStr == "" {
+		return fmt.Errorf("证书内容为空")
+	}
+	// 私有 CA 签发的证书在本地吊销并重新发布 CRL
+	if caID, _ := certRow["private_ca_id"].(string); caID != "" {
+		err = cert.UpdateCert(id, map[string]any{
+			"revoked":       1,
+			"revoke_reason": strconv.Itoa(int(reason)),
+			"revoke_time":   time.Now().Format("2006-01-02 15:04:05"),
+		})
+		if err != nil {
+			return err
+		}
+		return privateca.PublishCRL(caID)
+	}
+	if email == "" {
+		email, _ = certRow["acme_email"].(string)
+	}
+	if ca == "" {
+		ca, _ = certRow["acme_ca"].(string)
+	}
+	if ca == "letsencrypt" {
+		ca = "Let's Encrypt"
+	}
+	if mode == "" {
+		if email != "" && ca != "" {
+			mode = "account"
+		} else {
+			mode = "key"
+		}
+	}
+	if ca == "" {
+		return fmt.Errorf("未知的签发CA，请指定ca")
+	}
+
+	switch mode {
+	case "account":
+		if email == "" {
+			return fmt.Errorf("未知的签发账号，请指定email")
+		}
+		logger.Debug(fmt.Sprintf("使用账号 %s 吊销证书", email))
+		client, err := GetAcmeClient(email, "RSA2048", "", ca, httpClient, logger)
+		if err != nil {
+			return err
+		}
+		err = client.Certificate.RevokeWithReason([]byte(certStr), &reason)
+		if err != nil {
+			return fmt.Errorf("吊销证书失败: %v", err)
+		}
+	case "key":
+		logger.Debug("使用证书私钥吊销证书")
+		keyStr, ok := certRow["key"].(string)
+		if !ok || keyStr == "" {
+			return fmt.Errorf("证书私钥为空")
+		}
+		privateKey, err := public.ParsePrivateKey([]byte(keyStr))
+		if err != nil {
+			return err
+		}
+		CADirURL, err := getCADirURL(ca, email)
+		if err != nil {
+			return err
+		}
+		if httpClient == nil {
+			httpClient = &http.Client{Timeout: 30 * time.Second}
+		}
+		if caInfo, err := GetRegistryCA(ca); err == nil && caInfo != nil {
+			httpClient, err = caHTTPClient(caInfo, httpClient)
+			if err != nil {
+				return err
+			}
+		}
+		// 不携带 kid 时请求以证书私钥的 JWK 签名，参考 RFC 8555 7.6
+		core, err := api.New(httpClient, "ALLinSSL", CADirURL, "", privateKey)
+		if err != nil {
+			return err
+		}
+		certificates, err := certcrypto.ParsePEMBundle([]byte(certStr))
+		if err != nil {
+			return err
+		}
+		err = core.Certificates.Revoke(acme.RevokeCertMessage{
+			Certificate: base64.RawURLEncoding.EncodeToString(certificates[0].Raw),
+			Reason:      &reason,
+		})
+		if err != nil {
+			return fmt.Errorf("吊销证书失败: %v", err)
+		}
+	default:
+		return fmt.Errorf("不支持的吊销方式: %s", mode)
+	}
+
+	return cert.UpdateCert(id, map[string]any{
+		"revoked":       1,
+		"revoke_reason": strconv.Itoa(int(reason)),
+		"revoke_time":   time.Now().Format("2006-01-02 15:04:05"),
+	})
+}
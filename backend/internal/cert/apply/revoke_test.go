@@ -0,0 +1,27 @@
+package apply
+
+import "testing"
+
+func TestParseRevokeReason(t *testing.T) {
+	cases := map[any]uint{
+		nil:             0,
+		"":              0,
+		"keyCompromise": 1,
+		"4":             4,
+		float64(5):      5,
+	}
+	for in, want := range cases {
+		got, err := ParseRevokeReason(in)
+		if err != nil {
+			t.Fatalf("ParseRevokeReason(%v) failed: %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("ParseRevokeReason(%v) = %d; want %d", in, got, want)
+		}
+	}
+	for _, in := range []any{"2", "7", "unknown", float64(9)} {
+		if _, err := ParseRevokeReason(in); err == nil {
+			t.Fatalf("ParseRevokeReason(%v) expected error", in)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package apply
+
+import (
+	"ALLinSSL/backend/internal/access"
+	"encoding/json"
+	"fmt"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/providers/dns/rfc2136"
+	"github.com/miekg/dns"
+	"strconv"
+	"time"
+)
+
+// newRFC2136Provider 根据授权配置创建 RFC 2136 动态更新提供者，适用于 BIND、PowerDNS 等自建 DNS
+//
+//	nameserver: DNS 服务器地址，host 或 host:port
+//	tsig_key / tsig_algorithm / tsig_secret: TSIG 密钥名、算法（默认 hmac-sha256）、密钥，均为空时不签名
+func newRFC2136Provider(creds map[string]string, maxWait time.Duration) (*rfc2136.DNSProvider, *rfc2136.Config, error) {
+	config := rfc2136.NewDefaultConfig()
+	config.Nameserver = creds["nameserver"]
+	config.TSIGKey = creds["tsig_key"]
+	config.TSIGSecret = creds["tsig_secret"]
+	config.TSIGAlgorithm = creds["tsig_algorithm"]
+	if config.TSIGAlgorithm == "" {
+		config.TSIGAlgorithm = dns.HmacSHA256
+	}
+	if maxWait > 0 {
+		config.PropagationTimeout = maxWait
+	}
+	provider, err := rfc2136.NewDNSProviderConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return provider, config, nil
+}
+
+// rfc2136Update 向 DNS 服务器发送一次 TXT 记录的动态更新
+func rfc2136Update(config *rfc2136.Config, zone, fqdn, value string, insert bool) error {
+	rr := &dns.TXT{
+		Hdr: dns.RR_Header{Name: fqdn, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: uint32(config.TTL)},
+		Txt: []string{value},
+	}
+	m := new(dns.Msg)
+	m.SetUpdate(zone)
+	if insert {
+		m.Insert([]dns.RR{rr})
+	} else {
+		m.Remove([]dns.RR{rr})
+	}
+	c := &dns.Client{Timeout: config.DNSTimeout}
+	if config.TSIGKey != "" && config.TSIGSecret != "" {
+		m.SetTsig(config.TSIGKey, config.TSIGAlgorithm, 300, time.Now().Unix())
+		c.TsigSecret = map[string]string{config.TSIGKey: config.TSIGSecret}
+	}
+	reply, _, err := c.Exchange(m, config.Nameserver)
+	if err != nil {
+		return err
+	}
+	if reply.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("DNS服务器返回：%s", dns.RcodeToString[reply.Rcode])
+	}
+	return nil
+}
+
+// RFC2136Test 在 zone 下添加并删除一条测试 TXT 记录，验证服务器地址与 TSIG 配置是否可用
+func RFC2136Test(creds map[string]string) error {
+	_, config, err := newRFC2136Provider(creds, 0)
+	if err != nil {
+		return err
+	}
+	if creds["zone"] == "" {
+		return fmt.Errorf("测试需要填写域名（zone）")
+	}
+	zone, err := dns01.FindZoneByFqdnCustom(dns01.ToFqdn(creds["zone"]), []string{config.Nameserver})
+	if err != nil {
+		return fmt.Errorf("查询域名 %s 所在的区域失败: %v", creds["zone"], err)
+	}
+	fqdn := "_allinssl-test." + zone
+	value := "allinssl-test-" + strconv.FormatInt(time.Now().Unix(), 10)
+	if err = rfc2136Update(config, zone, fqdn, value, true); err != nil {
+		return fmt.Errorf("添加测试记录失败: %v", err)
+	}
+	if err = rfc2136Update(config, zone, fqdn, value, false); err != nil {
+		return fmt.Errorf("删除测试记录 %s 失败，请手动删除: %v", dns01.UnFqdn(fqdn), err)
+	}
+	return nil
+}
+
+// RFC2136APITest 测试 rfc2136 授权
+func RFC2136APITest(providerID string) error {
+	providerData, err := access.GetAccess(providerID)
+	if err != nil {
+		return err
+	}
+	providerConfigStr, ok := providerData["config"].(string)
+	if !ok {
+		return fmt.Errorf("api配置错误")
+	}
+	var providerConfig map[string]string
+	err = json.Unmarshal([]byte(providerConfigStr), &providerConfig)
+	if err != nil {
+		return err
+	}
+	return RFC2136Test(providerConfig)
+}
@@ -0,0 +1,97 @@
+package apply
+
+import (
+	"github.com/miekg/dns"
+	"net"
+	"sync"
+	"testing"
+)
+
+const (
+	testTSIGKey    = "allinssl-test."
+	testTSIGSecret = "IwBTJx9wrDp4Y1RyC3H0gA=="
+)
+
+// startUpdateServer 启动一个只接受 example.com 区域动态更新的本地 DNS 服务器，
+// 返回的函数在锁内复制已收到的更新操作
+func startUpdateServer(t *testing.T) (string, func() []string) {
+	var mu sync.Mutex
+	var ops []string
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := dns.NewServeMux()
+	mux.HandleFunc("example.com.", func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		switch r.Opcode {
+		case dns.OpcodeQuery:
+			m.Authoritative = true
+			if r.Question[0].Qtype == dns.TypeSOA && r.Question[0].Name == "example.com." {
+				soa, _ := dns.NewRR("example.com. 300 IN SOA ns.example.com. admin.example.com. 1 3600 600 86400 300")
+				m.Answer = append(m.Answer, soa)
+			} else {
+				soa, _ := dns.NewRR("example.com. 300 IN SOA ns.example.com. admin.example.com. 1 3600 600 86400 300")
+				m.Ns = append(m.Ns, soa)
+				m.Rcode = dns.RcodeNameError
+			}
+		case dns.OpcodeUpdate:
+			if r.IsTsig() == nil || w.TsigStatus() != nil {
+				m.Rcode = dns.RcodeNotAuth
+				break
+			}
+			mu.Lock()
+			for _, rr := range r.Ns {
+				switch rr.Header().Class {
+				case dns.ClassINET:
+					ops = append(ops, "insert "+rr.Header().Name)
+				case dns.ClassNONE:
+					ops = append(ops, "remove "+rr.Header().Name)
+				}
+			}
+			mu.Unlock()
+		}
+		if r.IsTsig() != nil {
+			m.SetTsig(testTSIGKey, dns.HmacSHA256, 300, int64(r.IsTsig().TimeSigned))
+		}
+		_ = w.WriteMsg(m)
+	})
+	server := &dns.Server{
+		PacketConn: pc,
+		Handler:    mux,
+		TsigSecret: map[string]string{testTSIGKey: testTSIGSecret},
+		// 默认只接受查询请求，这里需要放行 UPDATE
+		MsgAcceptFunc: func(dh dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+	}
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+	return pc.LocalAddr().String(), func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), ops...)
+	}
+}
+
+func TestRFC2136Test(t *testing.T) {
+	addr, updates := startUpdateServer(t)
+	creds := map[string]string{
+		"nameserver":  addr,
+		"tsig_key":    "allinssl-test",
+		"tsig_secret": testTSIGSecret,
+		"zone":        "www.example.com",
+	}
+	if err := RFC2136Test(creds); err != nil {
+		t.Fatal(err)
+	}
+	ops := updates()
+	want := []string{"insert _allinssl-test.example.com.", "remove _allinssl-test.example.com."}
+	if len(ops) != len(want) || ops[0] != want[0] || ops[1] != want[1] {
+		t.Fatalf("unexpected update operations: %v", ops)
+	}
+
+	creds["tsig_secret"] = "d3JvbmctYWxsaW5zc2wtc2VjcmV0"
+	if err := RFC2136Test(creds); err == nil {
+		t.Fatal("expected update with wrong TSIG secret to fail")
+	}
+}
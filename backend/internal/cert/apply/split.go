@@ -0,0 +1,154 @@
+package apply
+
+import (
+	"ALLinSSL/backend/internal/cert"
+	"ALLinSSL/backend/public"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// caMaxNames CA 单张证书（单个订单）允许的最大域名数量
+var caMaxNames = map[string]int{
+	"Let's Encrypt": 100,
+	"zerossl":       100,
+	"google":        100,
+}
+
+const defaultSplitSize = 100
+
+// parseSplitSize 解析每个订单的域名数量 split_size，未配置时为 CA 上限；
+// CA 上限已知（knownLimit）时不能超过该上限，否则拆分后的订单仍会被 CA 拒绝
+func parseSplitSize(cfg map[string]any, maxNames int, knownLimit bool) (int, error) {
+	splitSize, err := parseIntCfg(cfg, "split_size", maxNames)
+	if err != nil {
+		return 0, err
+	}
+	if knownLimit && splitSize > maxNames {
+		return 0, fmt.Errorf("参数错误：split_size %d 超过CA单张证书上限 %d", splitSize, maxNames)
+	}
+	return splitSize, nil
+}
+
+// splitDomains 将域名列表拆分为多个订单，同一注册域名下的域名（如 example.com 与 *.example.com）尽量放在同一订单
+//
+//	mode 为 domain 时每个注册域名单独一个订单；为 count 时按顺序将注册域名装入订单，每个订单不超过 size 个域名。
+//	单个注册域名下的域名超过 size 时拆分到多个订单。
+func splitDomains(domains []string, mode string, size int) ([][]string, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("参数错误：split_size")
+	}
+	if mode != "count" && mode != "domain" {
+		return nil, fmt.Errorf("不支持的拆分方式: %s", mode)
+	}
+	var order []string
+	groups := map[string][]string{}
+	for _, d := range domains {
+		r := registeredDomain(d)
+		if _, ok := groups[r]; !ok {
+			order = append(order, r)
+		}
+		groups[r] = append(groups[r], d)
+	}
+
+	var result [][]string
+	var current []string
+	for _, r := range order {
+		group := groups[r]
+		if mode == "domain" {
+			for len(group) > size {
+				result = append(result, group[:size])
+				group = group[size:]
+			}
+			result = append(result, group)
+			continue
+		}
+		if len(current)+len(group) > size && len(current) > 0 {
+			result = append(result, current)
+			current = nil
+		}
+		for len(group) > size {
+			result = append(result, group[:size])
+			group = group[size:]
+		}
+		current = append(current, group...)
+	}
+	if len(current) > 0 {
+		result = append(result, current)
+	}
+	return result, nil
+}
+
+// certGroupKey 拆分签发的证书组标识，由完整的域名列表确定，重复执行时保持不变
+func certGroupKey(domains []string) string {
+	sum := sha1.Sum([]byte(orderKey(domains)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// applySplit 按拆分后的域名组分别申请证书，各组的证书记录以 cert_group 关联
+//
+// 节点输出的顶层 cert、key、issuerCert 为第一组的证书，certs 中列出全部证书及其域名，
+// 供部署节点按站点域名选择。某组申请失败时已签发的证书会保留，再次执行时直接复用。
+func applySplit(ctx context.Context, cfg map[string]any, domainArr []string, groups [][]string, logger *public.Logger) (map[string]any, error) {
+	groupKey := certGroupKey(domainArr)
+	skip := true
+	var certs []any
+	for i, group := range groups {
+		sub := make(map[string]any, len(cfg))
+		for k, v := range cfg {
+			sub[k] = v
+		}
+		sub["domains"] = strings.Join(group, ",")
+		delete(sub, "split_mode")
+		logger.Info(fmt.Sprintf("拆分申请第 %d/%d 张证书，域名数：%d", i+1, len(groups), len(group)))
+		result, err := Apply(ctx, sub, logger)
+		if err != nil {
+			return nil, fmt.Errorf("拆分申请第 %d/%d 张证书失败: %w", i+1, len(groups), err)
+		}
+		if v, ok := result["skip"].(bool); !ok || !v {
+			skip = false
+		}
+		entries := []map[string]any{result}
+		if list, ok := result["certs"].([]any); ok {
+			entries = nil
+			for _, item := range list {
+				if c, ok := item.(map[string]any); ok {
+					entries = append(entries, c)
+				}
+			}
+		}
+		for _, entry := range entries {
+			c := map[string]any{
+				"domains":    sub["domains"],
+				"cert":       entry["cert"],
+				"key":        entry["key"],
+				"issuerCert": entry["issuerCert"],
+			}
+			if algorithm, ok := entry["algorithm"]; ok {
+				c["algorithm"] = algorithm
+			}
+			certs = append(certs, c)
+			if certStr, ok := entry["cert"].(string); ok {
+				if sha256, err := public.GetSHA256(certStr); err == nil {
+					if err = cert.UpdateCert(sha256, map[string]any{"cert_group": groupKey}); err != nil {
+						logger.Debug("记录证书组失败:", err)
+					}
+				}
+			}
+		}
+	}
+	first := certs[0].(map[string]any)
+	data := map[string]any{
+		"cert":       first["cert"],
+		"key":        first["key"],
+		"issuerCert": first["issuerCert"],
+		"certs":      certs,
+		"cert_group": groupKey,
+	}
+	if skip {
+		data["skip"] = true
+	}
+	return data, nil
+}
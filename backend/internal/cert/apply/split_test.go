@@ -0,0 +1,66 @@
+package apply
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSplitDomains(t *testing.T) {
+	domains := []string{"a.com", "*.a.com", "b.com", "www.b.com", "api.b.com", "c.co.uk"}
+
+	groups, err := splitDomains(domains, "domain", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"a.com", "*.a.com"}, {"b.com", "www.b.com", "api.b.com"}, {"c.co.uk"}}
+	if !reflect.DeepEqual(groups, want) {
+		t.Fatalf("unexpected groups: %v", groups)
+	}
+
+	// 按数量拆分时同一注册域名的域名不会被分开
+	groups, err = splitDomains(domains, "count", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = [][]string{{"a.com", "*.a.com"}, {"b.com", "www.b.com", "api.b.com", "c.co.uk"}}
+	if !reflect.DeepEqual(groups, want) {
+		t.Fatalf("unexpected groups: %v", groups)
+	}
+
+	// 单个注册域名超过上限时拆分
+	var many []string
+	for i := 0; i < 250; i++ {
+		many = append(many, fmt.Sprintf("s%d.example.com", i))
+	}
+	groups, err = splitDomains(many, "count", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 3 || len(groups[0]) != 100 || len(groups[2]) != 50 {
+		t.Fatalf("unexpected group sizes: %d", len(groups))
+	}
+
+	if _, err = splitDomains(domains, "size", 100); err == nil {
+		t.Fatal("expected unknown split mode to fail")
+	}
+	if certGroupKey([]string{"b.com", "a.com"}) != certGroupKey([]string{"A.com", "b.com"}) {
+		t.Fatal("expected group key to ignore order and case")
+	}
+}
+
+func TestParseSplitSize(t *testing.T) {
+	if size, err := parseSplitSize(map[string]any{}, 100, true); err != nil || size != 100 {
+		t.Fatalf("default split_size = %d, %v; want CA limit", size, err)
+	}
+	if size, err := parseSplitSize(map[string]any{"split_size": "50"}, 100, true); err != nil || size != 50 {
+		t.Fatalf("split_size = %d, %v; want 50", size, err)
+	}
+	if _, err := parseSplitSize(map[string]any{"split_size": float64(150)}, 100, true); err == nil {
+		t.Fatal("split_size above the CA limit should be rejected")
+	}
+	// CA 上限未知时按配置拆分
+	if size, err := parseSplitSize(map[string]any{"split_size": float64(150)}, defaultSplitSize, false); err != nil || size != 150 {
+		t.Fatalf("split_size = %d, %v; want 150", size, err)
+	}
+}
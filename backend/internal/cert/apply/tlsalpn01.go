@@ -0,0 +1,30 @@
+package apply
+
+import (
+	"fmt"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
+	"net"
+)
+
+// checkBindAddr 预先尝试监听验证端口，避免 CA 回连时才发现端口被占用
+func checkBindAddr(host, port string) error {
+	addr := net.JoinHostPort(host, port)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("验证端口 %s 不可用（可能已被 nginx 等服务占用，或当前用户无权限监听该端口）: %v", addr, err)
+	}
+	return listener.Close()
+}
+
+// GetTLSALPNProvider 创建 TLS-ALPN-01 验证提供者，默认监听 443 端口
+func GetTLSALPNProvider(cfg map[string]any) (challenge.Provider, error) {
+	host, port, err := splitBindAddr(cfg["tls_addr"], "443")
+	if err != nil {
+		return nil, fmt.Errorf("参数错误：tls_addr，%v", err)
+	}
+	if err := checkBindAddr(host, port); err != nil {
+		return nil, err
+	}
+	return tlsalpn01.NewProviderServer(host, port), nil
+}
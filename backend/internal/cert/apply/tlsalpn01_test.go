@@ -0,0 +1,50 @@
+package apply
+
+import (
+	"net"
+	"testing"
+)
+
+func TestCheckBindAddr(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	defer listener.Close()
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		t.Fatalf("split addr failed: %v", err)
+	}
+	if err := checkBindAddr(host, port); err == nil {
+		t.Fatalf("expected error for occupied port %s", port)
+	}
+	listener.Close()
+	if err := checkBindAddr(host, port); err != nil {
+		t.Fatalf("expected port %s to be free: %v", port, err)
+	}
+}
+
+func TestSplitBindAddr(t *testing.T) {
+	cases := []struct {
+		in   any
+		host string
+		port string
+	}{
+		{nil, "", "443"},
+		{"", "", "443"},
+		{"8443", "", "8443"},
+		{float64(8443), "", "8443"},
+		{":10443", "", "10443"},
+		{"127.0.0.1:5001", "127.0.0.1", "5001"},
+		{"[::1]:5001", "::1", "5001"},
+	}
+	for _, c := range cases {
+		host, port, err := splitBindAddr(c.in, "443")
+		if err != nil {
+			t.Fatalf("splitBindAddr(%v) failed: %v", c.in, err)
+		}
+		if host != c.host || port != c.port {
+			t.Fatalf("splitBindAddr(%v) = %q, %q; want %q, %q", c.in, host, port, c.host, c.port)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package apply
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseBoolCfg 解析节点配置中的开关参数，兼容前端传入的 bool、数字及字符串
+func parseBoolCfg(cfg map[string]any, key string) (bool, error) {
+	switch v := cfg[key].(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case int:
+		return v > 0, nil
+	case float64:
+		return v > 0, nil
+	case string:
+		return v == "true" || v == "1", nil
+	default:
+		return false, fmt.Errorf("参数错误：%s", key)
+	}
+}
+
+// parseIntCfg 解析节点配置中的整数参数，兼容数字及字符串，未配置时返回默认值
+func parseIntCfg(cfg map[string]any, key string, def int) (int, error) {
+	switch v := cfg[key].(type) {
+	case nil:
+		return def, nil
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		return int(v), nil
+	case string:
+		if v == "" {
+			return def, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("参数错误：%s", key)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("参数错误：%s", key)
+	}
+}
+
+// parseNameServers 解析用于 DNS 预检查的递归 DNS 服务器，默认 8.8.8.8 和 1.1.1.1
+func parseNameServers(cfg map[string]any) ([]string, error) {
+	if cfg["name_server"] == nil {
+		return []string{
+			"8.8.8.8:53",
+			"1.1.1.1:53",
+		}, nil
+	}
+	nameServerStr, ok := cfg["name_server"].(string)
+	if !ok {
+		return nil, fmt.Errorf("参数错误：name_server")
+	}
+	nameServers := strings.Split(nameServerStr, ",")
+	for i := range nameServers {
+		nameServers[i] = strings.TrimSpace(nameServers[i])
+	}
+	return nameServers, nil
+}
+
+// parseMaxWait 解析 DNS 记录生效的最大等待时间（秒），默认2分钟
+func parseMaxWait(cfg map[string]any) time.Duration {
+	switch v := cfg["max_wait"].(type) {
+	case int:
+		return time.Duration(v) * time.Second
+	case float64:
+		return time.Duration(v) * time.Second
+	case string:
+		if d, err := strconv.Atoi(v); err == nil && v != "" {
+			return time.Duration(d) * time.Second
+		}
+	}
+	return 2 * time.Minute
+}
+
+// normalizeIdentifiers 统一 IP 地址的写法（如 IPv6 的压缩形式），与证书中记录的格式保持一致，
+// 返回值 hasIP 表示是否包含 IP 标识
+func normalizeIdentifiers(domains []string) (result []string, hasIP bool) {
+	for _, domain := range domains {
+		domain = strings.TrimSpace(domain)
+		if ip := net.ParseIP(strings.Trim(domain, "[]")); ip != nil {
+			domain = ip.String()
+			hasIP = true
+		}
+		result = append(result, domain)
+	}
+	return result, hasIP
+}
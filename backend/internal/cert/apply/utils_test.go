@@ -0,0 +1,19 @@
+package apply
+
+import "testing"
+
+func TestNormalizeIdentifiers(t *testing.T) {
+	domains, hasIP := normalizeIdentifiers([]string{" example.com", "[2001:db8:0:0::1]", "192.0.2.1 "})
+	want := []string{"example.com", "2001:db8::1", "192.0.2.1"}
+	if !hasIP {
+		t.Fatal("expected IP identifiers to be detected")
+	}
+	for i := range want {
+		if domains[i] != want[i] {
+			t.Fatalf("unexpected identifiers: %v", domains)
+		}
+	}
+	if _, hasIP := normalizeIdentifiers([]string{"example.com", "*.example.com"}); hasIP {
+		t.Fatal("expected no IP identifiers")
+	}
+}
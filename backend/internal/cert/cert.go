@@ -55,7 +55,7 @@ func GetList(search string, p, limit int64) ([]map[string]any, int, error) {
 	return data, int(count), nil
 }
 
-func AddCert(source, key, cert, issuer, issuerCert, domains, sha256, historyId, startTime, endTime, endDay string) error {
+func AddCert(source, key, cert, issuer, issuerCert, domains, sha256, historyId, startTime, endTime, endDay, keySha256 string) error {
 	s, err := GetSqlite()
 	if err != nil {
 		return err
@@ -95,6 +95,7 @@ func AddCert(source, key, cert, issuer, issuerCert, domains, sha256, historyId,
 		"start_time":  startTime,
 		"end_time":    endTime,
 		"end_day":     endDay,
+		"key_sha256":  keySha256,
 	})
 	if err != nil {
 		return err
@@ -106,7 +107,20 @@ func SaveCert(source, key, cert, issuerCert, historyId string) (string, error) {
 	if err := public.ValidateSSLCertificate(cert, key); err != nil {
 		return "", err
 	}
+	return saveCert(source, key, cert, issuerCert, historyId)
+}
+
+// SaveCSRCert 保存使用自带 CSR 签发的证书，私钥由用户持有，可为空
+func SaveCSRCert(source, key, cert, issuerCert, historyId string) (string, error) {
+	if key != "" {
+		if err := public.ValidateSSLCertificate(cert, key); err != nil {
+			return "", err
+		}
+	}
+	return saveCert(source, key, cert, issuerCert, historyId)
+}
 
+func saveCert(source, key, cert, issuerCert, historyId string) (string, error) {
 	certObj, err := public.ParseCertificate([]byte(cert))
 	if err != nil {
 		return "", fmt.Errorf("解析证书失败: %v", err)
@@ -128,6 +142,9 @@ func SaveCert(source, key, cert, issuerCert, historyId string) (string, error) {
 	for _, dns := range certObj.DNSNames {
 		domainSet[dns] = true
 	}
+	for _, ip := range certObj.IPAddresses {
+		domainSet[ip.String()] = true
+	}
 
 	// 转成切片并拼接成逗号分隔的字符串
 	var domains []string
@@ -147,8 +164,12 @@ func SaveCert(source, key, cert, issuerCert, historyId string) (string, error) {
 	startTime := certObj.NotBefore.Format("2006-01-02 15:04:05")
 	endTime := certObj.NotAfter.Format("2006-01-02 15:04:05")
 	endDay := fmt.Sprintf("%d", int(certObj.NotAfter.Sub(time.Now()).Hours()/24))
+	keySha256, err := public.GetPublicKeySHA256(certObj.PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("获取公钥 SHA256 失败: %v", err)
+	}
 
-	err = AddCert(source, key, cert, caName, issuerCert, domainList, sha256, historyId, startTime, endTime, endDay)
+	err = AddCert(source, key, cert, caName, issuerCert, domainList, sha256, historyId, startTime, endTime, endDay, keySha256)
 	if err != nil {
 		return "", fmt.Errorf("保存证书失败: %v", err)
 	}
@@ -177,6 +198,74 @@ func DelCert(id string) error {
 	return nil
 }
 
+// UpdateCertARI 记录 CA 通过 ARI 建议的续期窗口及下次查询时间
+func UpdateCertARI(id any, start, end, retryAfter string) error {
+	s, err := GetSqlite()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	_, err = s.Where("id=?", []interface{}{id}).Update(map[string]any{
+		"ari_start":       start,
+		"ari_end":         end,
+		"ari_retry_after": retryAfter,
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateCert 按证书 id 或 sha256 更新证书记录
+func UpdateCert(id string, data map[string]any) error {
+	s, err := GetSqlite()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	data["update_time"] = time.Now().Format("2006-01-02 15:04:05")
+	_, err = s.Where("id=? or sha256=?", []interface{}{id, id}).Update(data)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// IsKeyCompromised 判断私钥是否曾因泄露被吊销（吊销原因 keyCompromise）
+func IsKeyCompromised(keySha256 string) (bool, error) {
+	s, err := GetSqlite()
+	if err != nil {
+		return false, err
+	}
+	defer s.Close()
+
+	count, err := s.Where("key_sha256=? and revoked=1 and revoke_reason='1'", []interface{}{keySha256}).Count()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetCertRow 按证书 id 或 sha256 获取完整的证书记录
+func GetCertRow(id string) (map[string]any, error) {
+	s, err := GetSqlite()
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	res, err := s.Where("id=? or sha256=?", []interface{}{id, id}).Select()
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("证书不存在")
+	}
+	return res[0], nil
+}
+
 func GetCert(id string) (map[string]string, error) {
 	s, err := GetSqlite()
 	if err != nil {
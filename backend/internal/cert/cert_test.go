@@ -0,0 +1,40 @@
+package cert
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestUploadCertRequiresKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "upload.example.com"},
+		DNSNames:     []string{"upload.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	// 上传证书必须附带匹配的私钥，只有自带 CSR 的申请才允许无私钥保存
+	if _, err := UploadCert("", certPEM); err == nil {
+		t.Fatal("expected upload without key to be rejected")
+	}
+	if _, err := SaveCert("workflow", "", certPEM, "", ""); err == nil {
+		t.Fatal("expected SaveCert without key to be rejected")
+	}
+}
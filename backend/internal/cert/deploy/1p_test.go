@@ -1,6 +1,9 @@
 package deploy
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 func TestSite(t *testing.T) {
 	cfg := map[string]any{
@@ -12,7 +15,7 @@ func TestSite(t *testing.T) {
 			"issuer": "cert-issuer",
 		},
 	}
-	err := Deploy1panelSite(cfg)
+	err := Deploy1panelSite(context.Background(), cfg)
 	println(err)
 }
 
@@ -26,7 +29,7 @@ func TestP(t *testing.T) {
 			"issuer": "cert-issuer",
 		},
 	}
-	err := Deploy1panel(cfg)
+	err := Deploy1panel(context.Background(), cfg)
 	println(err)
 }
 
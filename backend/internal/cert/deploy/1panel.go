@@ -4,6 +4,7 @@ import (
 	"ALLinSSL/backend/app/dto/response"
 	"ALLinSSL/backend/internal/access"
 	"bytes"
+	"context"
 	"crypto/md5"
 	"crypto/tls"
 	"encoding/hex"
@@ -24,7 +25,7 @@ func generateToken(timestamp string, apiKey string) string {
 
 // method provider_id url data
 
-func Request1panel(data *map[string]any, method, providerID, requestUrl string) (map[string]any, error) {
+func Request1panel(ctx context.Context, data *map[string]any, method, providerID, requestUrl string) (map[string]any, error) {
 	providerData, err := access.GetAccess(providerID)
 	if err != nil {
 		return nil, err
@@ -57,7 +58,7 @@ func Request1panel(data *map[string]any, method, providerID, requestUrl string)
 		return nil, err
 	}
 	baseURL := fmt.Sprintf("%s://%s/", parsedURL.Scheme, parsedURL.Host)
-	req, err := http.NewRequest(method, baseURL+requestUrl, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+requestUrl, bytes.NewBuffer(jsonData))
 	if err != nil {
 		// fmt.Println(err)
 		return nil, err
@@ -81,10 +82,13 @@ func Request1panel(data *map[string]any, method, providerID, requestUrl string)
 	resp, err := client.Do(req)
 	if err != nil {
 		// fmt.Println(err)
-		return nil, fmt.Errorf("请求1panel失败: %v", err)
+		return nil, fmt.Errorf("请求1panel失败: %w", err)
 	}
-	body, _ := io.ReadAll(resp.Body)
 	defer resp.Body.Close()
+	if err = checkResponseStatus("1Panel", resp); err != nil {
+		return nil, err
+	}
+	body, _ := io.ReadAll(resp.Body)
 
 	var res map[string]interface{}
 	err = json.Unmarshal(body, &res)
@@ -106,7 +110,7 @@ func Request1panel(data *map[string]any, method, providerID, requestUrl string)
 
 }
 
-func Deploy1panel(cfg map[string]any) error {
+func Deploy1panel(ctx context.Context, cfg map[string]any) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -136,14 +140,14 @@ func Deploy1panel(cfg map[string]any) error {
 		"ssl":     "enable",
 		"sslType": "import-paste",
 	}
-	_, err := Request1panel(&data, "POST", providerID, "api/v1/settings/ssl/update")
+	_, err := Request1panel(ctx, &data, "POST", providerID, "api/v1/settings/ssl/update")
 	if err != nil {
 		return fmt.Errorf("证书部署失败: %v", err)
 	}
 	return nil
 }
 
-func Deploy1panelSite(cfg map[string]any) error {
+func Deploy1panelSite(ctx context.Context, cfg map[string]any) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -171,7 +175,7 @@ func Deploy1panelSite(cfg map[string]any) error {
 		return fmt.Errorf("证书错误：cert")
 	}
 	// 获取网站参数
-	siteData, err := Request1panel(&map[string]any{}, "GET", providerID, fmt.Sprintf("api/v1/websites/%s/https", siteId))
+	siteData, err := Request1panel(ctx, &map[string]any{}, "GET", providerID, fmt.Sprintf("api/v1/websites/%s/https", siteId))
 	if err != nil {
 		return fmt.Errorf("获取网站参数失败: %v", err)
 	}
@@ -226,13 +230,13 @@ func Deploy1panelSite(cfg map[string]any) error {
 		"type":       "manual",
 		"websiteId":  websiteId,
 	}
-	_, err = Request1panel(&data, "POST", providerID, fmt.Sprintf("api/v1/websites/%s/https", siteId))
+	_, err = Request1panel(ctx, &data, "POST", providerID, fmt.Sprintf("api/v1/websites/%s/https", siteId))
 	return err
 }
 
 func OnePanelAPITest(providerID string) error {
 	data := map[string]interface{}{}
-	_, err := Request1panel(&data, "GET", providerID, "api/v1/settings/upgrade")
+	_, err := Request1panel(context.Background(), &data, "GET", providerID, "api/v1/settings/upgrade")
 	if err != nil {
 		return fmt.Errorf("测试请求失败: %v", err)
 	}
@@ -248,7 +252,7 @@ func OnePanelSiteList(providerID string) ([]response.AccessSiteList, error) {
 		"pageSize":       1000,
 		"websiteGroupId": 0,
 	}
-	siteList, err := Request1panel(&data, "POST", providerID, "api/v1/websites/search")
+	siteList, err := Request1panel(context.Background(), &data, "POST", providerID, "api/v1/websites/search")
 	if err != nil {
 		return nil, fmt.Errorf("获取网站列表失败 %v", err)
 	}
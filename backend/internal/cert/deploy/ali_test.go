@@ -1,6 +1,9 @@
 package deploy
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 func TestALiCdn(t *testing.T) {
 	cfg := map[string]any{
@@ -32,7 +35,7 @@ func TestALiOss(t *testing.T) {
 			"issuer": "cert-issuer",
 		},
 	}
-	err := DeployOss(cfg)
+	err := DeployOss(context.Background(), cfg)
 	if err != nil {
 		t.Errorf("DeployAliCdn failed: %v", err)
 	} else {
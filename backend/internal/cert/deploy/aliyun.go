@@ -3,6 +3,7 @@ package deploy
 import (
 	"ALLinSSL/backend/internal/access"
 	"ALLinSSL/backend/internal/cert/deploy/client/aliyun"
+	"context"
 	"encoding/json"
 	"fmt"
 	aliyuncdn "github.com/alibabacloud-go/cdn-20180510/v6/client"
@@ -118,7 +119,7 @@ func ClientOss(accessKeyId, accessKeySecret, region string) (*oss.Client, error)
 	return client, nil
 }
 
-func DeployOss(cfg map[string]any) error {
+func DeployOss(ctx context.Context, cfg map[string]any) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -182,7 +183,7 @@ func DeployOss(cfg map[string]any) error {
 			Force:       true,
 		},
 	}
-	err = client.PutBucketCnameWithCertificate(bucket, putBucketCnameWithCertificateRequest)
+	err = client.PutBucketCnameWithCertificate(bucket, putBucketCnameWithCertificateRequest, oss.WithContext(ctx))
 	return err
 }
 
@@ -1,6 +1,7 @@
 package deploy
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -16,7 +17,7 @@ func TestBTSite(t *testing.T) {
 			"issuer": "cert-issuer",
 		},
 	}
-	err := DeployBtSite(cfg)
+	err := DeployBtSite(context.Background(), cfg)
 	t.Logf("err:%v", err)
 }
 
@@ -30,7 +31,7 @@ func TestBTP(t *testing.T) {
 			"issuer": "cert-issuer",
 		},
 	}
-	err := DeployBt(cfg)
+	err := DeployBt(context.Background(), cfg)
 	println(err)
 }
 
@@ -44,7 +45,7 @@ func TestBTDockerSite(t *testing.T) {
 			"issuer": "cert-issuer",
 		},
 	}
-	err := DeployBtDockerSite(cfg)
+	err := DeployBtDockerSite(context.Background(), cfg)
 	println(err)
 }
 
@@ -82,7 +83,7 @@ func TestDeployBtSingleSite(t *testing.T) {
 			"issuer": "cert-issuer",
 		},
 	}
-	err = DeployBtSingleSite(cfg)
+	err = DeployBtSingleSite(context.Background(), cfg)
 	if err != nil {
 		t.Fatalf("DeployBtSingleSite failed: %v", err)
 	} else {
@@ -3,6 +3,7 @@ package deploy
 import (
 	"ALLinSSL/backend/app/dto/response"
 	"ALLinSSL/backend/internal/access"
+	"context"
 	"crypto/md5"
 	"crypto/tls"
 	"encoding/hex"
@@ -25,7 +26,7 @@ func generateSignature(timestamp, apiKey string) string {
 	return signMd5Hex
 }
 
-func RequestBt(data *url.Values, method, providerID, requestUrl string) (map[string]any, error) {
+func RequestBt(ctx context.Context, data *url.Values, method, providerID, requestUrl string) (map[string]any, error) {
 	providerData, err := access.GetAccess(providerID)
 	if err != nil {
 		return nil, err
@@ -52,7 +53,7 @@ func RequestBt(data *url.Values, method, providerID, requestUrl string) (map[str
 	}
 	baseURL := fmt.Sprintf("%s://%s/", parsedURL.Scheme, parsedURL.Host)
 
-	req, err := http.NewRequest(method, baseURL+requestUrl, strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+requestUrl, strings.NewReader(data.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -72,10 +73,13 @@ func RequestBt(data *url.Values, method, providerID, requestUrl string) (map[str
 	resp, err := client.Do(req)
 	if err != nil {
 		// fmt.Println(err)
-		return nil, fmt.Errorf("请求BT失败: %v", err)
+		return nil, fmt.Errorf("请求BT失败: %w", err)
 	}
-	body, _ := io.ReadAll(resp.Body)
 	defer resp.Body.Close()
+	if err = checkResponseStatus("宝塔面板", resp); err != nil {
+		return nil, err
+	}
+	body, _ := io.ReadAll(resp.Body)
 
 	var res map[string]interface{}
 	err = json.Unmarshal(body, &res)
@@ -89,11 +93,11 @@ func RequestBt(data *url.Values, method, providerID, requestUrl string) (map[str
 	return res, nil
 }
 
-func UploadBt(key, csr, providerID string) (string, error) {
+func UploadBt(ctx context.Context, key, csr, providerID string) (string, error) {
 	data := url.Values{}
 	data.Set("key", key)
 	data.Set("csr", csr)
-	response, err := RequestBt(&data, "POST", providerID, "ssl/cert/save_cert")
+	response, err := RequestBt(ctx, &data, "POST", providerID, "ssl/cert/save_cert")
 	if response == nil {
 		return "", fmt.Errorf("证书上传失败: %v", err)
 	}
@@ -105,7 +109,7 @@ func UploadBt(key, csr, providerID string) (string, error) {
 	return sslHash, nil
 }
 
-func DeployBt(cfg map[string]any) error {
+func DeployBt(ctx context.Context, cfg map[string]any) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -132,14 +136,14 @@ func DeployBt(cfg map[string]any) error {
 	data.Set("cert_type", "1")
 	data.Set("privateKey", keyPem)
 	data.Set("certPem", certPem)
-	_, err := RequestBt(&data, "POST", providerID, "config?action=SetPanelSSL")
+	_, err := RequestBt(ctx, &data, "POST", providerID, "config?action=SetPanelSSL")
 	if err != nil {
 		return fmt.Errorf("证书部署失败: %v", err)
 	}
 	return nil
 }
 
-func DeployBtSite(cfg map[string]any) error {
+func DeployBtSite(ctx context.Context, cfg map[string]any) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -167,7 +171,7 @@ func DeployBtSite(cfg map[string]any) error {
 		return fmt.Errorf("参数错误：siteName")
 	}
 
-	sslHash, err := UploadBt(keyPem, certPem, providerID)
+	sslHash, err := UploadBt(ctx, keyPem, certPem, providerID)
 	if err != nil {
 		return err
 	}
@@ -184,14 +188,14 @@ func DeployBtSite(cfg map[string]any) error {
 
 	data := url.Values{}
 	data.Set("BatchInfo", string(batchs))
-	_, err = RequestBt(&data, "POST", providerID, "ssl?action=SetBatchCertToSite")
+	_, err = RequestBt(ctx, &data, "POST", providerID, "ssl?action=SetBatchCertToSite")
 	if err != nil {
 		return fmt.Errorf("证书部署失败: %v", err)
 	}
 	return nil
 }
 
-func DeployBtDockerSite(cfg map[string]any) error {
+func DeployBtDockerSite(ctx context.Context, cfg map[string]any) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -222,7 +226,7 @@ func DeployBtDockerSite(cfg map[string]any) error {
 	data.Set("key", keyPem)
 	data.Set("csr", certPem)
 	data.Set("siteName", siteName)
-	_, err := RequestBt(&data, "POST", providerID, "mod/docker/com/set_ssl")
+	_, err := RequestBt(ctx, &data, "POST", providerID, "mod/docker/com/set_ssl")
 	if err != nil {
 		return fmt.Errorf("证书部署失败: %v", err)
 	}
@@ -231,7 +235,7 @@ func DeployBtDockerSite(cfg map[string]any) error {
 
 func BtPanelAPITest(providerID string) error {
 	data := url.Values{}
-	_, err := RequestBt(&data, "POST", providerID, "system?action=GetNetWork")
+	_, err := RequestBt(context.Background(), &data, "POST", providerID, "system?action=GetNetWork")
 	if err != nil {
 		return fmt.Errorf("测试请求失败: %v", err)
 	}
@@ -241,7 +245,7 @@ func BtPanelAPITest(providerID string) error {
 func BtPanelSiteList(providerID string) ([]response.AccessSiteList, error) {
 	data := url.Values{}
 	data.Set("cert_list", "")
-	siteList, err := RequestBt(&data, "POST", providerID, "ssl?action=GetSiteDomain")
+	siteList, err := RequestBt(context.Background(), &data, "POST", providerID, "ssl?action=GetSiteDomain")
 	if err != nil {
 		fmt.Println("获取网站列表失败:", err)
 		return nil, err
@@ -260,7 +264,7 @@ func BtPanelSiteList(providerID string) ([]response.AccessSiteList, error) {
 	//fmt.Printf("siteList:%#v\n", result)
 	return result, nil
 }
-func DeployBtSingleSite(cfg map[string]any) error {
+func DeployBtSingleSite(ctx context.Context, cfg map[string]any) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -291,7 +295,7 @@ func DeployBtSingleSite(cfg map[string]any) error {
 	data.Set("key", keyPem)
 	data.Set("csr", certPem)
 	data.Set("siteName", siteName)
-	_, err := RequestBt(&data, "POST", providerID, "/site?action=SetSSL")
+	_, err := RequestBt(ctx, &data, "POST", providerID, "/site?action=SetSSL")
 	if err != nil {
 		return fmt.Errorf("证书部署失败: %v", err)
 	}
@@ -3,6 +3,7 @@ package deploy
 import (
 	"ALLinSSL/backend/internal/access"
 	"bytes"
+	"context"
 	"crypto/md5"
 	"crypto/tls"
 	"encoding/hex"
@@ -25,7 +26,7 @@ func bfWafToken(timestamp, apiKey string) string {
 	return signMd5Hex
 }
 
-func RequestBtWaf(data *map[string]any, method, providerID, requestUrl string) (map[string]any, error) {
+func RequestBtWaf(ctx context.Context, data *map[string]any, method, providerID, requestUrl string) (map[string]any, error) {
 	providerData, err := access.GetAccess(providerID)
 	if err != nil {
 		return nil, err
@@ -48,7 +49,7 @@ func RequestBtWaf(data *map[string]any, method, providerID, requestUrl string) (
 	baseURL := fmt.Sprintf("%s://%s/", parsedURL.Scheme, parsedURL.Host)
 
 	jsonData, err := json.Marshal(data)
-	req, err := http.NewRequest(method, baseURL+requestUrl, bytes.NewReader(jsonData))
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+requestUrl, bytes.NewReader(jsonData))
 	if err != nil {
 		return nil, err
 	}
@@ -72,10 +73,13 @@ func RequestBtWaf(data *map[string]any, method, providerID, requestUrl string) (
 	resp, err := client.Do(req)
 	if err != nil {
 		// fmt.Println(err)
-		return nil, fmt.Errorf("请求BTWAF失败: %v", err)
+		return nil, fmt.Errorf("请求BTWAF失败: %w", err)
 	}
-	body, _ := io.ReadAll(resp.Body)
 	defer resp.Body.Close()
+	if err = checkResponseStatus("宝塔WAF", resp); err != nil {
+		return nil, err
+	}
+	body, _ := io.ReadAll(resp.Body)
 	var res map[string]interface{}
 	err = json.Unmarshal(body, &res)
 	if err != nil {
@@ -87,13 +91,13 @@ func RequestBtWaf(data *map[string]any, method, providerID, requestUrl string) (
 	return res, nil
 }
 
-func GetBTWafSiteList(page int, pageSize int, siteName string, providerId string) ([]any, error) {
+func GetBTWafSiteList(ctx context.Context, page int, pageSize int, siteName string, providerId string) ([]any, error) {
 	data := map[string]any{
 		"p":         page,
 		"p_size":    pageSize,
 		"site_name": siteName,
 	}
-	response, err := RequestBtWaf(&data, "POST", providerId, "api/wafmastersite/get_site_list")
+	response, err := RequestBtWaf(ctx, &data, "POST", providerId, "api/wafmastersite/get_site_list")
 	res := response["res"].(map[string]any)
 	if err != nil {
 		return nil, err
@@ -103,7 +107,7 @@ func GetBTWafSiteList(page int, pageSize int, siteName string, providerId string
 }
 
 // btwaf不支持通过API设置SSL
-func DeployBtWaf(cfg map[string]any) error {
+func DeployBtWaf(ctx context.Context, cfg map[string]any) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -131,14 +135,14 @@ func DeployBtWaf(cfg map[string]any) error {
 		"certContent": certPem,
 		"keyContent":  keyPem,
 	}
-	_, err := RequestBtWaf(&data, "POST", providerID, "api/config/set_cert")
+	_, err := RequestBtWaf(ctx, &data, "POST", providerID, "api/config/set_cert")
 	if err != nil {
 		return fmt.Errorf("证书部署失败: %v", err)
 	}
 	return nil
 }
 
-func DeployBtWafSite(cfg map[string]any) error {
+func DeployBtWafSite(ctx context.Context, cfg map[string]any) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -167,7 +171,7 @@ func DeployBtWafSite(cfg map[string]any) error {
 	}
 
 	siteId := ""
-	sitelist, err := GetBTWafSiteList(1, 100, siteName, providerID)
+	sitelist, err := GetBTWafSiteList(ctx, 1, 100, siteName, providerID)
 	if len(sitelist) != 0 && err == nil {
 		for _, site := range sitelist {
 			siteInfo := site.(map[string]any)
@@ -192,7 +196,7 @@ func DeployBtWafSite(cfg map[string]any) error {
 			},
 		},
 	}
-	_, err = RequestBtWaf(&data, "POST", providerID, "api/wafmastersite/modify_site")
+	_, err = RequestBtWaf(ctx, &data, "POST", providerID, "api/wafmastersite/modify_site")
 
 	if err != nil {
 		return fmt.Errorf("证书部署失败: %v", err)
@@ -202,7 +206,7 @@ func DeployBtWafSite(cfg map[string]any) error {
 
 func BtWafAPITest(providerID string) error {
 	data := map[string]any{}
-	_, err := RequestBtWaf(&data, "POST", providerID, "api/overview/infos")
+	_, err := RequestBtWaf(context.Background(), &data, "POST", providerID, "api/overview/infos")
 	if err != nil {
 		return fmt.Errorf("测试请求失败: %v", err)
 	}
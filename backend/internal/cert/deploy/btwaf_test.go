@@ -1,6 +1,7 @@
 package deploy
 
 import (
+	"context"
 	"fmt"
 	"testing"
 )
@@ -15,7 +16,7 @@ func TestBTWAFSite(t *testing.T) {
 			"issuer": "cert-issuer",
 		},
 	}
-	err := DeployBtWafSite(cfg)
+	err := DeployBtWafSite(context.Background(), cfg)
 	fmt.Println(err)
 }
 
@@ -33,7 +34,7 @@ func TestBTWAFSite(t *testing.T) {
 //}
 
 func TestGetBTWAFSiteList(t *testing.T) {
-	res, err := GetBTWafSiteList(1, 10, "xxx.cn", "1")
+	res, err := GetBTWafSiteList(context.Background(), 1, 10, "xxx.cn", "1")
 	for _, site := range res {
 		sites := site.(map[string]any)
 		fmt.Printf("网站名: %s   ID：%s  \n", sites["site_name"], sites["site_id"])
@@ -1,6 +1,7 @@
 package deploy
 
 import (
+	"context"
 	"fmt"
 	"github.com/volcengine/volcengine-go-sdk/service/cdn"
 	"github.com/volcengine/volcengine-go-sdk/volcengine"
@@ -36,7 +37,7 @@ func ClientVolcEngineCdn(ak, sk, region string) (*VolcEngineCdnClient, error) {
 	return cdnClient, nil
 }
 
-func (v *VolcEngineCdnClient) IUploadCert(certContent, certKey string) (string, error) {
+func (v *VolcEngineCdnClient) IUploadCert(ctx context.Context, certContent, certKey string) (string, error) {
 	// 创建证书上传请求
 	input := &cdn.AddCertificateInput{
 		Certificate: volcengine.String(certContent),
@@ -45,7 +46,7 @@ func (v *VolcEngineCdnClient) IUploadCert(certContent, certKey string) (string,
 		Source:      volcengine.String("volc_cert_center"),
 	}
 
-	output, err := v.AddCertificate(input)
+	output, err := v.AddCertificateWithContext(ctx, input)
 	if err != nil {
 		if output.Metadata.Error.Code == "InvalidParameter.Certificate.Duplicated" {
 			re := regexp.MustCompile(`cert-[a-f0-9]{32}`)
@@ -58,13 +59,13 @@ func (v *VolcEngineCdnClient) IUploadCert(certContent, certKey string) (string,
 	return *output.CertId, nil
 }
 
-func (v *VolcEngineCdnClient) IBatchDeployCert(certId, domain string) error {
+func (v *VolcEngineCdnClient) IBatchDeployCert(ctx context.Context, certId, domain string) error {
 	batchDeployCertInput := &cdn.BatchDeployCertInput{
 		CertId: volcengine.String(certId),
 		Domain: volcengine.String(domain),
 	}
 
-	res, err := v.BatchDeployCert(batchDeployCertInput)
+	res, err := v.BatchDeployCertWithContext(ctx, batchDeployCertInput)
 	if err != nil {
 		return fmt.Errorf("部署证书失败: %w", err)
 	}
@@ -1,6 +1,7 @@
 package deploy
 
 import (
+	"context"
 	"fmt"
 	"github.com/volcengine/volcengine-go-sdk/service/dcdn"
 	"github.com/volcengine/volcengine-go-sdk/volcengine"
@@ -21,13 +22,13 @@ func ClientVolcEngineDcdn(ak, sk, region string) (*VolcEngineDcdnClient, error)
 	return dcdnClient, nil
 }
 
-func (v *VolcEngineDcdnClient) IDCDNCreateCertBindInput(certId, domain string) error {
+func (v *VolcEngineDcdnClient) IDCDNCreateCertBindInput(ctx context.Context, certId, domain string) error {
 	createCertBindInput := &dcdn.CreateCertBindInput{
 		CertId:      volcengine.String(certId),
 		DomainNames: volcengine.StringSlice([]string{domain}),
 	}
 
-	_, err := v.CreateCertBind(createCertBindInput)
+	_, err := v.CreateCertBindWithContext(ctx, createCertBindInput)
 	if err != nil {
 		return fmt.Errorf("部署证书失败: %w", err)
 	}
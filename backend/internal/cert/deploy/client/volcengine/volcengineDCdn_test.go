@@ -1,6 +1,7 @@
 package deploy
 
 import (
+	"context"
 	"fmt"
 	"testing"
 )
@@ -11,7 +12,7 @@ func TestVolcEngineDcdnClient_IDCDNCreateCertBindInput(t *testing.T) {
 
 	client, _ := ClientVolcEngineDcdn("", "==", "cn-north-1")
 
-	err := client.IDCDNCreateCertBindInput(id, domain)
+	err := client.IDCDNCreateCertBindInput(context.Background(), id, domain)
 	if err != nil {
 		fmt.Printf("err:%+v", err)
 		return
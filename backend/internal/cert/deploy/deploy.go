@@ -5,10 +5,16 @@ import (
 	"ALLinSSL/backend/internal/cert/deploy/doge"
 	"ALLinSSL/backend/internal/cert/deploy/plugin"
 	"ALLinSSL/backend/public"
+	"context"
 	"fmt"
 )
 
-func Deploy(cfg map[string]any, logger *public.Logger) error {
+// Deploy 部署证书，ctx 取消时中断 ssh 会话、本地命令、插件进程、面板/WAF 的 HTTP 请求及支持 context 的云厂商 SDK 请求
+// （腾讯云、七牛、火山引擎、阿里云 OSS、多吉云）；阿里云 CDN/WAF/ESA、华为云、百度云的 SDK 不支持 context，只在开始部署前检查
+func Deploy(ctx context.Context, cfg map[string]any, logger *public.Logger) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	providerName, ok := cfg["provider"].(string)
 	if !ok {
 		return fmt.Errorf("provider is not string")
@@ -16,50 +22,50 @@ func Deploy(cfg map[string]any, logger *public.Logger) error {
 	switch providerName {
 	case "btpanel":
 		logger.Debug("部署到宝塔面板...")
-		return DeployBt(cfg)
+		return DeployBt(ctx, cfg)
 	case "btpanel-site":
 		logger.Debug("部署到宝塔面板网站...")
-		return DeployBtSite(cfg)
+		return DeployBtSite(ctx, cfg)
 	case "btpanel-dockersite":
 		logger.Debug("部署到宝塔Docker面板网站...")
-		return DeployBtDockerSite(cfg)
+		return DeployBtDockerSite(ctx, cfg)
 	case "btpanel-singlesite":
 		logger.Debug("部署到旧版本宝塔单个站点...")
-		return DeployBtSingleSite(cfg)
+		return DeployBtSingleSite(ctx, cfg)
 	case "btwaf-site":
 		logger.Debug("部署到宝塔WAF面板网站...")
-		return DeployBtWafSite(cfg)
+		return DeployBtWafSite(ctx, cfg)
 	case "tencentcloud-cdn":
 		cfg["resource_type"] = "cdn"
 		logger.Debug("部署到腾讯云CDN...")
-		return DeployToTX(cfg)
+		return DeployToTX(ctx, cfg)
 	case "tencentcloud-cos":
 		cfg["resource_type"] = "cos"
 		logger.Debug("部署到腾讯云COS...")
-		return DeployToTX(cfg)
+		return DeployToTX(ctx, cfg)
 	case "tencentcloud-waf":
 		cfg["resource_type"] = "waf"
 		logger.Debug("部署到腾讯云WAF...")
-		return DeployToTX(cfg)
+		return DeployToTX(ctx, cfg)
 	case "tencentcloud-teo":
 		cfg["resource_type"] = "teo"
 		logger.Debug("部署到腾讯云EdgeOne...")
-		return DeployToTX(cfg)
+		return DeployToTX(ctx, cfg)
 	case "1panel":
 		logger.Debug("部署到1Panel...")
-		return Deploy1panel(cfg)
+		return Deploy1panel(ctx, cfg)
 	case "1panel-site":
 		logger.Debug("部署到1Panel网站...")
-		return Deploy1panelSite(cfg)
+		return Deploy1panelSite(ctx, cfg)
 	case "ssh":
 		logger.Debug("使用ssh部署到指定路径...")
-		return DeploySSH(cfg, logger)
+		return DeploySSH(ctx, cfg, logger)
 	case "aliyun-cdn":
 		logger.Debug("部署到阿里云CDN...")
 		return DeployAliCdn(cfg)
 	case "aliyun-oss":
 		logger.Debug("部署到阿里云OSS...")
-		return DeployOss(cfg)
+		return DeployOss(ctx, cfg)
 	case "aliyun-waf":
 		logger.Debug("部署到阿里云WAF...")
 		return DeployAliyunWaf(cfg)
@@ -68,19 +74,19 @@ func Deploy(cfg map[string]any, logger *public.Logger) error {
 		return aliyun.DeployAliyunESA(cfg)
 	case "safeline-site":
 		logger.Debug("部署雷池WAF网站...")
-		return DeploySafeLineWafSite(cfg, logger)
+		return DeploySafeLineWafSite(ctx, cfg, logger)
 	case "safeline-panel":
 		logger.Debug("部署雷池WAF面板...")
-		return DeploySafeLineWaf(cfg)
+		return DeploySafeLineWaf(ctx, cfg)
 	case "localhost":
 		logger.Debug("部署到本地...")
-		return DeployLocalhost(cfg)
+		return DeployLocalhost(ctx, cfg, logger)
 	case "qiniu-cdn":
 		logger.Debug("部署到七牛云CDN...")
-		return DeployQiniuCdn(cfg)
+		return DeployQiniuCdn(ctx, cfg)
 	case "qiniu-oss":
 		logger.Debug("部署到七牛云OSS...")
-		return DeployQiniuOss(cfg)
+		return DeployQiniuOss(ctx, cfg)
 	case "baidu-cdn":
 		logger.Debug("部署到百度云CDN...")
 		return DeployBaiduCdn(cfg)
@@ -89,16 +95,16 @@ func Deploy(cfg map[string]any, logger *public.Logger) error {
 		return DeployHwCdn(cfg)
 	case "volcengine-cdn":
 		logger.Debug("部署到火山CDN...")
-		return DeployVolcEngineCdn(cfg)
+		return DeployVolcEngineCdn(ctx, cfg)
 	case "volcengine-dcdn":
 		logger.Debug("部署到火山DCDN...")
-		return DeployVolcEngineDCdn(cfg)
+		return DeployVolcEngineDCdn(ctx, cfg)
 	case "doge-cdn":
 		logger.Debug("部署到多吉云CDN...")
-		return doge.DeployCdn(cfg)
+		return doge.DeployCdn(ctx, cfg)
 	case "plugin":
 		logger.Debug("使用插件部署...")
-		return plugin.Deploy(cfg, logger)
+		return plugin.Deploy(ctx, cfg, logger)
 	default:
 		return fmt.Errorf("不支持的部署: %s", providerName)
 	}
@@ -3,6 +3,7 @@ package doge
 import (
 	"ALLinSSL/backend/internal/access"
 	"ALLinSSL/backend/public"
+	"context"
 	"crypto/hmac"
 	"crypto/sha1"
 	"encoding/hex"
@@ -27,7 +28,7 @@ func NewAuth(accessKey, secretKey string) *Auth {
 	}
 }
 
-func DeployCdn(cfg map[string]any) error {
+func DeployCdn(ctx context.Context, cfg map[string]any) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -77,7 +78,7 @@ func DeployCdn(cfg map[string]any) error {
 
 	a := NewAuth(providerConfig["access_key"], providerConfig["secret_key"])
 	// 检查证书是否已存在于 CDN
-	certList, err := a.listCertFromCdn()
+	certList, err := a.listCertFromCdn(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to list certs from CDN: %w", err)
 	}
@@ -92,13 +93,13 @@ func DeployCdn(cfg map[string]any) error {
 	}
 	// 如果证书不存在，则上传证书到 CDN
 	if certID == 0 {
-		certID, err = a.uploadCertToCdn(certStr, keyStr, note)
+		certID, err = a.uploadCertToCdn(ctx, certStr, keyStr, note)
 		if err != nil || certID == 0 {
 			return fmt.Errorf("failed to upload to CDN: %w", err)
 		}
 	}
 	// 绑定证书到域名
-	_, err = a.bindCertToCdn(certID, domain)
+	_, err = a.bindCertToCdn(ctx, certID, domain)
 	if err != nil {
 		return fmt.Errorf("failed to bind cert to CDN: %w", err)
 	}
@@ -106,14 +107,14 @@ func DeployCdn(cfg map[string]any) error {
 	return nil
 }
 
-func (a Auth) uploadCertToCdn(cert, key, note string) (float64, error) {
+func (a Auth) uploadCertToCdn(ctx context.Context, cert, key, note string) (float64, error) {
 	params := map[string]any{
 		"cert":    cert,
 		"private": key,
 		"note":    note,
 	}
 
-	res, err := a.DogeCloudAPI("/cdn/cert/upload.json", params, true)
+	res, err := a.DogeCloudAPI(ctx, "/cdn/cert/upload.json", params, true)
 	if err != nil {
 		return 0, fmt.Errorf("failed to call DogeCloud API: %w", err)
 	}
@@ -135,8 +136,8 @@ func (a Auth) uploadCertToCdn(cert, key, note string) (float64, error) {
 	return certID, nil
 }
 
-func (a Auth) listCertFromCdn() ([]map[string]any, error) {
-	res, err := a.DogeCloudAPI("/cdn/cert/list.json", map[string]interface{}{}, true)
+func (a Auth) listCertFromCdn(ctx context.Context) ([]map[string]any, error) {
+	res, err := a.DogeCloudAPI(ctx, "/cdn/cert/list.json", map[string]interface{}{}, true)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call DogeCloud API: %w", err)
 	}
@@ -166,12 +167,12 @@ func (a Auth) listCertFromCdn() ([]map[string]any, error) {
 	return certs, nil
 }
 
-func (a Auth) bindCertToCdn(certID float64, domain string) (map[string]interface{}, error) {
+func (a Auth) bindCertToCdn(ctx context.Context, certID float64, domain string) (map[string]interface{}, error) {
 	params := map[string]interface{}{
 		"id":     certID,
 		"domain": domain,
 	}
-	res, err := a.DogeCloudAPI("/cdn/cert/bind.json", params, true)
+	res, err := a.DogeCloudAPI(ctx, "/cdn/cert/bind.json", params, true)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call DogeCloud API: %w", err)
 	}
@@ -187,7 +188,7 @@ func (a Auth) bindCertToCdn(certID float64, domain string) (map[string]interface
 }
 
 // DogeCloudAPI 调用多吉云的 API 根据多吉云官网示例修改
-func (a Auth) DogeCloudAPI(apiPath string, data map[string]interface{}, jsonMode bool) (map[string]interface{}, error) {
+func (a Auth) DogeCloudAPI(ctx context.Context, apiPath string, data map[string]interface{}, jsonMode bool) (map[string]interface{}, error) {
 	AccessKey := a.AccessKey
 	SecretKey := a.SecretKey
 
@@ -215,7 +216,7 @@ func (a Auth) DogeCloudAPI(apiPath string, data map[string]interface{}, jsonMode
 	sign := hex.EncodeToString(hmacObj.Sum(nil))
 	Authorization := "TOKEN " + AccessKey + ":" + sign
 
-	req, err := http.NewRequest("POST", "https://api.dogecloud.com"+apiPath, strings.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.dogecloud.com"+apiPath, strings.NewReader(body))
 	if err != nil {
 		return nil, err // 创建请求错误
 	}
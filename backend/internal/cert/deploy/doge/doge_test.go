@@ -1,6 +1,7 @@
 package doge
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -12,7 +13,7 @@ func TestPlugin(t *testing.T) {
 		fmt.Fprintf(os.Stderr, "切换目录失败: %v\n", err)
 		os.Exit(1)
 	}
-	err = DeployCdn(map[string]interface{}{
+	err = DeployCdn(context.Background(), map[string]interface{}{
 		"provider_id": "xx",
 		"certificate": map[string]any{
 			"key":  "xx",
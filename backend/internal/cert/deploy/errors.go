@@ -0,0 +1,137 @@
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"github.com/alibabacloud-go/tea/tea"
+	"github.com/baidubce/bce-sdk-go/bce"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/sdkerr"
+	qiniuClient "github.com/qiniu/go-sdk/v7/client"
+	tcErrors "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+)
+
+// ErrorClass 错误分类，用于判断失败的节点是否可以重试
+type ErrorClass string
+
+const (
+	// ErrorThrottle 限流
+	ErrorThrottle ErrorClass = "throttle"
+	// ErrorServer 服务端错误（5xx）
+	ErrorServer ErrorClass = "server"
+	// ErrorNetwork 网络错误（连接失败、超时、连接被重置等）
+	ErrorNetwork ErrorClass = "network"
+	// ErrorPermanent 授权错误、参数错误等，重试不会成功
+	ErrorPermanent ErrorClass = ""
+)
+
+// StatusError 部署目标返回的限流或服务端错误状态码
+type StatusError struct {
+	Target     string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("请求%s失败，状态码：%d", e.Target, e.StatusCode)
+}
+
+// checkResponseStatus 响应为限流（429）或服务端错误（5xx）时返回 StatusError，其他状态码由调用方按返回内容处理
+func checkResponseStatus(target string, resp *http.Response) error {
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return &StatusError{Target: target, StatusCode: resp.StatusCode}
+	}
+	return nil
+}
+
+func classifyStatus(statusCode int) ErrorClass {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return ErrorThrottle
+	case statusCode >= 500:
+		return ErrorServer
+	}
+	return ErrorPermanent
+}
+
+// ClassifyError 按各云厂商 SDK 的错误类型、HTTP 状态码及网络错误对错误分类，
+// 被包装为文本的错误按错误信息中的关键字匹配，无法识别的错误视为不可重试
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrorPermanent
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return classifyStatus(statusErr.StatusCode)
+	}
+	var tcErr *tcErrors.TencentCloudSDKError
+	if errors.As(err, &tcErr) {
+		switch {
+		case strings.HasPrefix(tcErr.Code, "RequestLimitExceeded"):
+			return ErrorThrottle
+		case strings.HasPrefix(tcErr.Code, "InternalError"), strings.HasPrefix(tcErr.Code, "ResourceUnavailable"):
+			return ErrorServer
+		case tcErr.Code == "ClientError.NetworkError":
+			return ErrorNetwork
+		}
+		return ErrorPermanent
+	}
+	var teaErr *tea.SDKError
+	if errors.As(err, &teaErr) {
+		if code := tea.StringValue(teaErr.Code); strings.Contains(code, "Throttling") {
+			return ErrorThrottle
+		}
+		return classifyStatus(tea.IntValue(teaErr.StatusCode))
+	}
+	var hwErr *sdkerr.ServiceResponseError
+	if errors.As(err, &hwErr) {
+		return classifyStatus(hwErr.StatusCode)
+	}
+	var hwValueErr sdkerr.ServiceResponseError
+	if errors.As(err, &hwValueErr) {
+		return classifyStatus(hwValueErr.StatusCode)
+	}
+	var bceErr *bce.BceServiceError
+	if errors.As(err, &bceErr) {
+		return classifyStatus(bceErr.StatusCode)
+	}
+	var qnErr *qiniuClient.ErrorInfo
+	if errors.As(err, &qnErr) {
+		return classifyStatus(qnErr.Code)
+	}
+	// 火山引擎等 SDK 的请求错误提供 StatusCode 方法
+	var statusCoder interface{ StatusCode() int }
+	if errors.As(err, &statusCoder) && statusCoder.StatusCode() > 0 {
+		return classifyStatus(statusCoder.StatusCode())
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return ErrorNetwork
+	}
+	return classifyMessage(err.Error())
+}
+
+var (
+	throttleKeywords = []string{"too many requests", "throttl", "requestlimitexceeded", "rate limit", "ratelimit", "限流", "请求过于频繁"}
+	serverKeywords   = []string{"internal server error", "bad gateway", "service unavailable", "gateway timeout", "internalerror", "serviceunavailable"}
+	networkKeywords  = []string{"connection refused", "connection reset", "i/o timeout", "timeout awaiting", "tls handshake timeout", "no such host", "broken pipe", "network is unreachable", "unexpected eof"}
+)
+
+func classifyMessage(msg string) ErrorClass {
+	msg = strings.ToLower(msg)
+	for _, keywords := range []struct {
+		class ErrorClass
+		words []string
+	}{{ErrorThrottle, throttleKeywords}, {ErrorServer, serverKeywords}, {ErrorNetwork, networkKeywords}} {
+		for _, w := range keywords.words {
+			if strings.Contains(msg, w) {
+				return keywords.class
+			}
+		}
+	}
+	return ErrorPermanent
+}
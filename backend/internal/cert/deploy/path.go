@@ -0,0 +1,36 @@
+package deploy
+
+import (
+	"ALLinSSL/backend/public"
+	"strings"
+)
+
+// ExpandCertPath 替换证书路径中的占位符，用于同一目标部署多张证书时区分文件：
+//
+//	{algorithm} 证书算法（如 ec256、rsa2048）
+//	{domain}    证书的第一个域名，通配符 * 替换为 _，用于拆分签发的多张证书
+func ExpandCertPath(path string, cert map[string]any) string {
+	if strings.Contains(path, "{algorithm}") {
+		certPem, _ := cert["cert"].(string)
+		algorithm, _ := cert["algorithm"].(string)
+		if algorithm == "" {
+			algorithm = public.GetCertKeyAlgorithm(certPem)
+		}
+		path = strings.ReplaceAll(path, "{algorithm}", strings.ToLower(algorithm))
+	}
+	if strings.Contains(path, "{domain}") {
+		domains, _ := cert["domains"].(string)
+		domain := strings.TrimSpace(strings.Split(domains, ",")[0])
+		if domain == "" {
+			certPem, _ := cert["cert"].(string)
+			if certObj, err := public.ParseCertificate([]byte(certPem)); err == nil {
+				domain = certObj.Subject.CommonName
+				if len(certObj.DNSNames) > 0 {
+					domain = certObj.DNSNames[0]
+				}
+			}
+		}
+		path = strings.ReplaceAll(path, "{domain}", strings.ReplaceAll(domain, "*", "_"))
+	}
+	return path
+}
@@ -0,0 +1,13 @@
+package deploy
+
+import "testing"
+
+func TestExpandCertPath(t *testing.T) {
+	cert := map[string]any{"algorithm": "EC256", "domains": "*.example.com,example.com"}
+	if got := ExpandCertPath("/etc/ssl/{domain}-{algorithm}.pem", cert); got != "/etc/ssl/_.example.com-ec256.pem" {
+		t.Fatalf("unexpected path: %s", got)
+	}
+	if got := ExpandCertPath("/etc/ssl/site.pem", cert); got != "/etc/ssl/site.pem" {
+		t.Fatalf("unexpected path: %s", got)
+	}
+}
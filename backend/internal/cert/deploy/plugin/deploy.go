@@ -3,6 +3,7 @@ package plugin
 import (
 	"ALLinSSL/backend/internal/access"
 	"ALLinSSL/backend/public"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -14,7 +15,7 @@ type CertDeployPlugin struct {
 	Cert   string
 }
 
-func Deploy(cfg map[string]any, logger *public.Logger) error {
+func Deploy(ctx context.Context, cfg map[string]any, logger *public.Logger) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -93,7 +94,7 @@ func Deploy(cfg map[string]any, logger *public.Logger) error {
 	// 调用插件
 	logger.Debug(fmt.Sprintf("调用插件%s:%s", pluginName, action))
 
-	_, err = CallPlugin(pluginName, action, pluginConfig, logger)
+	_, err = CallPlugin(ctx, pluginName, action, pluginConfig, logger)
 	if err != nil {
 		return fmt.Errorf("调用插件失败：%v", err)
 	}
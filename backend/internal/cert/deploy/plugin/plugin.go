@@ -3,6 +3,7 @@ package plugin
 import (
 	"ALLinSSL/backend/public"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -99,9 +100,9 @@ func getMetadata(path string) (PluginMetadata, error) {
 	return meta, nil
 }
 
-func CallPlugin(name, action string, params map[string]interface{}, logger *public.Logger) (*Response, error) {
+func CallPlugin(ctx context.Context, name, action string, params map[string]interface{}, logger *public.Logger) (*Response, error) {
 	// 第一次尝试
-	resp, err := tryCallPlugin(name, action, params, logger)
+	resp, err := tryCallPlugin(ctx, name, action, params, logger)
 	if err == nil {
 		return resp, nil
 	}
@@ -114,14 +115,14 @@ func CallPlugin(name, action string, params map[string]interface{}, logger *publ
 			logger.Error("插件刷新失败", scanErr)
 			return nil, fmt.Errorf("插件刷新失败: %v", scanErr)
 		}
-		return tryCallPlugin(name, action, params, logger)
+		return tryCallPlugin(ctx, name, action, params, logger)
 	}
 
 	// 其他错误直接返回
 	return nil, err
 }
 
-func tryCallPlugin(name, action string, params map[string]interface{}, logger *public.Logger) (*Response, error) {
+func tryCallPlugin(ctx context.Context, name, action string, params map[string]interface{}, logger *public.Logger) (*Response, error) {
 	plugin, ok := pluginRegistry[name]
 	if !ok {
 		return nil, ErrPluginNotFound
@@ -146,8 +147,8 @@ func tryCallPlugin(name, action string, params map[string]interface{}, logger *p
 		Params: params,
 	}
 
-	// 启动插件进程
-	cmd := exec.Command(plugin.Path)
+	// 启动插件进程，取消执行时结束进程
+	cmd := exec.CommandContext(ctx, plugin.Path)
 	cmd.Stderr = io.Discard // ❌ 忽略所有插件错误/日志输出，避免污染
 
 	stdin, err := cmd.StdinPipe()
@@ -20,7 +20,7 @@ type sslCertResponse struct {
 	CertID string `json:"certID"`
 }
 
-func requestQiniu(cfg map[string]any, path string, m map[string]any, method string, response any) (err error) {
+func requestQiniu(ctx context.Context, cfg map[string]any, path string, m map[string]any, method string, response any) (err error) {
 	var providerID string
 	switch v := cfg["provider_id"].(type) {
 	case float64:
@@ -46,11 +46,11 @@ func requestQiniu(cfg map[string]any, path string, m map[string]any, method stri
 	credentials := auth.New(providerConfig["access_key"], providerConfig["access_secret"])
 	header := http.Header{}
 	header.Add("Content-Type", "application/json")
-	err = client.DefaultClient.CredentialedCallWithJson(context.Background(), credentials, auth.TokenQBox, response, method, uri, header, m)
+	err = client.DefaultClient.CredentialedCallWithJson(ctx, credentials, auth.TokenQBox, response, method, uri, header, m)
 	return err
 }
 
-func DeployQiniuCdn(cfg map[string]any) error {
+func DeployQiniuCdn(ctx context.Context, cfg map[string]any) error {
 	_, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -60,7 +60,7 @@ func DeployQiniuCdn(cfg map[string]any) error {
 		return fmt.Errorf("参数错误：domain")
 	}
 
-	certId, err := uploadQiniuCert(cfg)
+	certId, err := uploadQiniuCert(ctx, cfg)
 	if err != nil {
 		return err
 	}
@@ -69,11 +69,11 @@ func DeployQiniuCdn(cfg map[string]any) error {
 		"certid": certId,
 	}
 	var response commonResponse
-	err = requestQiniu(cfg, path, m, "PUT", &response)
+	err = requestQiniu(ctx, cfg, path, m, "PUT", &response)
 	return err
 }
 
-func updateQiniuDomainCert(cfg map[string]any) error {
+func updateQiniuDomainCert(ctx context.Context, cfg map[string]any) error {
 	_, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -94,7 +94,7 @@ func updateQiniuDomainCert(cfg map[string]any) error {
 		http2Enable = true
 	}
 
-	certId, err := uploadQiniuCert(cfg)
+	certId, err := uploadQiniuCert(ctx, cfg)
 	if err != nil {
 		return err
 	}
@@ -106,11 +106,11 @@ func updateQiniuDomainCert(cfg map[string]any) error {
 	}
 
 	var response commonResponse
-	err = requestQiniu(cfg, fmt.Sprintf("domain/%s/httpsconf", domain), m, "PUT", &response)
+	err = requestQiniu(ctx, cfg, fmt.Sprintf("domain/%s/httpsconf", domain), m, "PUT", &response)
 	return err
 }
 
-func DeployQiniuOss(cfg map[string]any) error {
+func DeployQiniuOss(ctx context.Context, cfg map[string]any) error {
 	_, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -133,12 +133,12 @@ func DeployQiniuOss(cfg map[string]any) error {
 			Http2Enable bool   `json:"http2Enable"`
 		} `json:"https"`
 	}
-	err := requestQiniu(cfg, fmt.Sprintf("domain/%s", domain), nil, "GET", &httpsConfig)
+	err := requestQiniu(ctx, cfg, fmt.Sprintf("domain/%s", domain), nil, "GET", &httpsConfig)
 	if err != nil {
 		return fmt.Errorf("获取域名HTTPS配置失败: %v", err)
 	}
 
-	certId, err := uploadQiniuCert(cfg)
+	certId, err := uploadQiniuCert(ctx, cfg)
 	if err != nil {
 		return err
 	}
@@ -148,7 +148,7 @@ func DeployQiniuOss(cfg map[string]any) error {
 		cfg["cert_id"] = certId
 		cfg["force_https"] = httpsConfig.Https.ForceHttps
 		cfg["http2_enable"] = httpsConfig.Https.Http2Enable
-		err = updateQiniuDomainCert(cfg)
+		err = updateQiniuDomainCert(ctx, cfg)
 		return err
 	} else {
 		// 如果未开启HTTPS，则使用POST请求绑定证书
@@ -157,23 +157,23 @@ func DeployQiniuOss(cfg map[string]any) error {
 			"domain": domain,
 		}
 		var response commonResponse
-		err = requestQiniu(cfg, "cert/bind", m, "POST", &response)
+		err = requestQiniu(ctx, cfg, "cert/bind", m, "POST", &response)
 		return err
 	}
 }
 
-func delQiniuCert(cfg map[string]any) error {
+func delQiniuCert(ctx context.Context, cfg map[string]any) error {
 	certId, ok := cfg["old_cert_id"].(string)
 	if !ok {
 		return fmt.Errorf("参数错误：cert_id")
 	}
 	m := map[string]any{}
 	var response commonResponse
-	err := requestQiniu(cfg, fmt.Sprintf("sslcert/%v", certId), m, "DELETE", &response)
+	err := requestQiniu(ctx, cfg, fmt.Sprintf("sslcert/%v", certId), m, "DELETE", &response)
 	return err
 }
 
-func uploadQiniuCert(cfg map[string]any) (string, error) {
+func uploadQiniuCert(ctx context.Context, cfg map[string]any) (string, error) {
 	cert, ok := cfg["certificate"].(map[string]any)
 	keyPem, ok := cert["key"].(string)
 	if !ok {
@@ -188,7 +188,7 @@ func uploadQiniuCert(cfg map[string]any) (string, error) {
 		"ca":  certPem,
 	}
 	var response sslCertResponse
-	err := requestQiniu(cfg, "sslcert", m, "POST", &response)
+	err := requestQiniu(ctx, cfg, "sslcert", m, "POST", &response)
 	return response.CertID, err
 }
 
@@ -198,7 +198,7 @@ func QiniuAPITest(providerID string) error {
 	}
 	m := map[string]any{}
 	var response commonResponse
-	err := requestQiniu(cfg, "sslcert", m, "GET", &response)
+	err := requestQiniu(context.Background(), cfg, "sslcert", m, "GET", &response)
 	if err != nil {
 		return fmt.Errorf("测试请求失败: %v", err)
 	}
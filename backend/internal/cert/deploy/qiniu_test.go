@@ -1,6 +1,9 @@
 package deploy
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 func TestQiniuUploadCert(t *testing.T) {
 	cfg := map[string]any{
@@ -11,7 +14,7 @@ func TestQiniuUploadCert(t *testing.T) {
 			"cert": "-----BEGIN CERTIFICATE-----\nMIIFADCCA+igAwIBAgISBo+AmwbcW4aYdv34jdvxVwLAMA0GCSqGSIb3DQEBCwUA\nMDMxCzAJBgNVBAYTAlVTMRYwFAYDVQQKEw1MZXQncyBFbmNyeXB0MQwwCgYDVQQD\nEwNSMTAwHhcNMjUwNTIxMDA1NDIyWhcNMjUwODE5MDA1NDIxWjAeMRwwGgYDVQQD\nExNxaWFubml1LndhbnRvcGVyLm1lMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIB\nCgKCAQEAuHz6s4Q4R5DcNV0d+1R6FiGJTfrb4LLN9VNEGOWfgG3ArnJz3gFzOLMU\nZp0BLT8b6HaY1IpV1ol4ViVd0sf/oWusGG3S5+qJw1DIdr+KB096lRwrIH64cyht\nPdtPEjY+f8PU2BBGO6qbH9qrXiGABYir0wFIvP8/17YCxmc1qcCL8r7UvJ58ZiFw\n0jw3qgxkGebPfKGAasD2irGKWf3qtHp/U9OLRTQ+vh7Vp+l5tUMLE+3nrLcj0nZM\n2GMDARjrydPMY0H53RbVkpfdEDciH1NiJMYZ1nOySAnKnE8w4Ah/+VQCamEUkmUi\nL8bor2tFkX6jE7F1JzdkL+4mGmPyaQIDAQABo4ICITCCAh0wDgYDVR0PAQH/BAQD\nAgWgMB0GA1UdJQQWMBQGCCsGAQUFBwMBBggrBgEFBQcDAjAMBgNVHRMBAf8EAjAA\nMB0GA1UdDgQWBBSoKVx8BE4dXkNxwpF+m6e4BN/3yTAfBgNVHSMEGDAWgBS7vMNH\npeS8qcbDpHIMEI2iNeHI6DAzBggrBgEFBQcBAQQnMCUwIwYIKwYBBQUHMAKGF2h0\ndHA6Ly9yMTAuaS5sZW5jci5vcmcvMB4GA1UdEQQXMBWCE3FpYW5uaXUud2FudG9w\nZXIubWUwEwYDVR0gBAwwCjAIBgZngQwBAgEwLgYDVR0fBCcwJTAjoCGgH4YdaHR0\ncDovL3IxMC5jLmxlbmNyLm9yZy82NS5jcmwwggECBgorBgEEAdZ5AgQCBIHzBIHw\nAO4AdQDtPEvW6AbCpKIAV9vLJOI4Ad9RL+3EhsVwDyDdtz4/4AAAAZbwiyI8AAAE\nAwBGMEQCIC+TpGUBZaC5oRkWi4j3zwk3fDLWJ9xX+BGv2x136zloAiBWANxlia2I\np7ILDHI8PikI4hnEnxK53seyylleexYccQB1AMz7D2qFcQll/pWbU87psnwi6YVc\nDZeNtql+VMD+TA2wAAABlvCLIl0AAAQDAEYwRAIgIzYfFQEsW7F6cKkR2djfkDih\nyCC6L9U7oWcQFw28dxICIFnz/I8+eWs8894oz8D6JogVsP5W4w9yidkQ3C9Pzcoj\nMA0GCSqGSIb3DQEBCwUAA4IBAQBKVpDe0xZCFGJ0pX4vEdnBgaoBt8As8QAG3VCA\nuVzR2baqvRd5XNFph6mBAu5AdRgrMLhhrlIPLMqMXDpV0bdG5zzILJ7Xv6xjZE55\n9bfdTRt0hSDqD///6F4cy4STF9JJ3iJ0bkqfu9k0k4iBixqhYY0OYeIF70Zocyy9\n01BwvWdsIFz2OyVPihsdXNOwR+szAlkoyN578cFVavlOQVyAJte114gMi3++JkBd\n1e1zSbWMhaOzoQC/VSRuye+9WW66T706APTyPKHWK0NkPnv+VqIIO2Z5rBtomRxz\n/EK9ZM4IZZf19ybCaCYnScD01QCGMjE2ERCtanN8l3tZClSb\n-----END CERTIFICATE-----\n\n-----BEGIN CERTIFICATE-----\nMIIFBTCCAu2gAwIBAgIQS6hSk/eaL6JzBkuoBI110DANBgkqhkiG9w0BAQsFADBP\nMQswCQYDVQQGEwJVUzEpMCcGA1UEChMgSW50ZXJuZXQgU2VjdXJpdHkgUmVzZWFy\nY2ggR3JvdXAxFTATBgNVBAMTDElTUkcgUm9vdCBYMTAeFw0yNDAzMTMwMDAwMDBa\nFw0yNzAzMTIyMzU5NTlaMDMxCzAJBgNVBAYTAlVTMRYwFAYDVQQKEw1MZXQncyBF\nbmNyeXB0MQwwCgYDVQQDEwNSMTAwggEiMA0GCSqGSIb3DQEBAQUAA4IBDwAwggEK\nAoIBAQDPV+XmxFQS7bRH/sknWHZGUCiMHT6I3wWd1bUYKb3dtVq/+vbOo76vACFL\nYlpaPAEvxVgD9on/jhFD68G14BQHlo9vH9fnuoE5CXVlt8KvGFs3Jijno/QHK20a\n/6tYvJWuQP/py1fEtVt/eA0YYbwX51TGu0mRzW4Y0YCF7qZlNrx06rxQTOr8IfM4\nFpOUurDTazgGzRYSespSdcitdrLCnF2YRVxvYXvGLe48E1KGAdlX5jgc3421H5KR\nmudKHMxFqHJV8LDmowfs/acbZp4/SItxhHFYyTr6717yW0QrPHTnj7JHwQdqzZq3\nDZb3EoEmUVQK7GH29/Xi8orIlQ2NAgMBAAGjgfgwgfUwDgYDVR0PAQH/BAQDAgGG\nMB0GA1UdJQQWMBQGCCsGAQUFBwMCBggrBgEFBQcDATASBgNVHRMBAf8ECDAGAQH/\nAgEAMB0GA1UdDgQWBBS7vMNHpeS8qcbDpHIMEI2iNeHI6DAfBgNVHSMEGDAWgBR5\ntFnme7bl5AFzgAiIyBpY9umbbjAyBggrBgEFBQcBAQQmMCQwIgYIKwYBBQUHMAKG\nFmh0dHA6Ly94MS5pLmxlbmNyLm9yZy8wEwYDVR0gBAwwCjAIBgZngQwBAgEwJwYD\nVR0fBCAwHjAcoBqgGIYWaHR0cDovL3gxLmMubGVuY3Iub3JnLzANBgkqhkiG9w0B\nAQsFAAOCAgEAkrHnQTfreZ2B5s3iJeE6IOmQRJWjgVzPw139vaBw1bGWKCIL0vIo\nzwzn1OZDjCQiHcFCktEJr59L9MhwTyAWsVrdAfYf+B9haxQnsHKNY67u4s5Lzzfd\nu6PUzeetUK29v+PsPmI2cJkxp+iN3epi4hKu9ZzUPSwMqtCceb7qPVxEbpYxY1p9\n1n5PJKBLBX9eb9LU6l8zSxPWV7bK3lG4XaMJgnT9x3ies7msFtpKK5bDtotij/l0\nGaKeA97pb5uwD9KgWvaFXMIEt8jVTjLEvwRdvCn294GPDF08U8lAkIv7tghluaQh\n1QnlE4SEN4LOECj8dsIGJXpGUk3aU3KkJz9icKy+aUgA+2cP21uh6NcDIS3XyfaZ\nQjmDQ993ChII8SXWupQZVBiIpcWO4RqZk3lr7Bz5MUCwzDIA359e57SSq5CCkY0N\n4B6Vulk7LktfwrdGNVI5BsC9qqxSwSKgRJeZ9wygIaehbHFHFhcBaMDKpiZlBHyz\nrsnnlFXCb5s8HKn5LsUgGvB24L7sGNZP2CX7dhHov+YhD+jozLW2p9W4959Bz2Ei\nRmqDtmiXLnzqTpXbI+suyCsohKRg6Un0RC47+cpiVwHiXZAW+cn8eiNIjqbVgXLx\nKPpdzvvtTnOPlC7SQZSYmdunr3Bf9b77AiC/ZidstK36dRILKz7OA54=\n-----END CERTIFICATE-----\n",
 		},
 	}
-	certId, _ := uploadQiniuCert(cfg)
+	certId, _ := uploadQiniuCert(context.Background(), cfg)
 	t.Logf(certId)
 
 }
@@ -25,7 +28,7 @@ func TestQiniuCdn(t *testing.T) {
 			"cert": "-----BEGIN CERTIFICATE-----\nMIIFADCCA+igAwIBAgISBo+AmwbcW4aYdv34jdvxVwLAMA0GCSqGSIb3DQEBCwUA\nMDMxCzAJBgNVBAYTAlVTMRYwFAYDVQQKEw1MZXQncyBFbmNyeXB0MQwwCgYDVQQD\nEwNSMTAwHhcNMjUwNTIxMDA1NDIyWhcNMjUwODE5MDA1NDIxWjAeMRwwGgYDVQQD\nExNxaWFubml1LndhbnRvcGVyLm1lMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIB\nCgKCAQEAuHz6s4Q4R5DcNV0d+1R6FiGJTfrb4LLN9VNEGOWfgG3ArnJz3gFzOLMU\nZp0BLT8b6HaY1IpV1ol4ViVd0sf/oWusGG3S5+qJw1DIdr+KB096lRwrIH64cyht\nPdtPEjY+f8PU2BBGO6qbH9qrXiGABYir0wFIvP8/17YCxmc1qcCL8r7UvJ58ZiFw\n0jw3qgxkGebPfKGAasD2irGKWf3qtHp/U9OLRTQ+vh7Vp+l5tUMLE+3nrLcj0nZM\n2GMDARjrydPMY0H53RbVkpfdEDciH1NiJMYZ1nOySAnKnE8w4Ah/+VQCamEUkmUi\nL8bor2tFkX6jE7F1JzdkL+4mGmPyaQIDAQABo4ICITCCAh0wDgYDVR0PAQH/BAQD\nAgWgMB0GA1UdJQQWMBQGCCsGAQUFBwMBBggrBgEFBQcDAjAMBgNVHRMBAf8EAjAA\nMB0GA1UdDgQWBBSoKVx8BE4dXkNxwpF+m6e4BN/3yTAfBgNVHSMEGDAWgBS7vMNH\npeS8qcbDpHIMEI2iNeHI6DAzBggrBgEFBQcBAQQnMCUwIwYIKwYBBQUHMAKGF2h0\ndHA6Ly9yMTAuaS5sZW5jci5vcmcvMB4GA1UdEQQXMBWCE3FpYW5uaXUud2FudG9w\nZXIubWUwEwYDVR0gBAwwCjAIBgZngQwBAgEwLgYDVR0fBCcwJTAjoCGgH4YdaHR0\ncDovL3IxMC5jLmxlbmNyLm9yZy82NS5jcmwwggECBgorBgEEAdZ5AgQCBIHzBIHw\nAO4AdQDtPEvW6AbCpKIAV9vLJOI4Ad9RL+3EhsVwDyDdtz4/4AAAAZbwiyI8AAAE\nAwBGMEQCIC+TpGUBZaC5oRkWi4j3zwk3fDLWJ9xX+BGv2x136zloAiBWANxlia2I\np7ILDHI8PikI4hnEnxK53seyylleexYccQB1AMz7D2qFcQll/pWbU87psnwi6YVc\nDZeNtql+VMD+TA2wAAABlvCLIl0AAAQDAEYwRAIgIzYfFQEsW7F6cKkR2djfkDih\nyCC6L9U7oWcQFw28dxICIFnz/I8+eWs8894oz8D6JogVsP5W4w9yidkQ3C9Pzcoj\nMA0GCSqGSIb3DQEBCwUAA4IBAQBKVpDe0xZCFGJ0pX4vEdnBgaoBt8As8QAG3VCA\nuVzR2baqvRd5XNFph6mBAu5AdRgrMLhhrlIPLMqMXDpV0bdG5zzILJ7Xv6xjZE55\n9bfdTRt0hSDqD///6F4cy4STF9JJ3iJ0bkqfu9k0k4iBixqhYY0OYeIF70Zocyy9\n01BwvWdsIFz2OyVPihsdXNOwR+szAlkoyN578cFVavlOQVyAJte114gMi3++JkBd\n1e1zSbWMhaOzoQC/VSRuye+9WW66T706APTyPKHWK0NkPnv+VqIIO2Z5rBtomRxz\n/EK9ZM4IZZf19ybCaCYnScD01QCGMjE2ERCtanN8l3tZClSb\n-----END CERTIFICATE-----\n\n-----BEGIN CERTIFICATE-----\nMIIFBTCCAu2gAwIBAgIQS6hSk/eaL6JzBkuoBI110DANBgkqhkiG9w0BAQsFADBP\nMQswCQYDVQQGEwJVUzEpMCcGA1UEChMgSW50ZXJuZXQgU2VjdXJpdHkgUmVzZWFy\nY2ggR3JvdXAxFTATBgNVBAMTDElTUkcgUm9vdCBYMTAeFw0yNDAzMTMwMDAwMDBa\nFw0yNzAzMTIyMzU5NTlaMDMxCzAJBgNVBAYTAlVTMRYwFAYDVQQKEw1MZXQncyBF\nbmNyeXB0MQwwCgYDVQQDEwNSMTAwggEiMA0GCSqGSIb3DQEBAQUAA4IBDwAwggEK\nAoIBAQDPV+XmxFQS7bRH/sknWHZGUCiMHT6I3wWd1bUYKb3dtVq/+vbOo76vACFL\nYlpaPAEvxVgD9on/jhFD68G14BQHlo9vH9fnuoE5CXVlt8KvGFs3Jijno/QHK20a\n/6tYvJWuQP/py1fEtVt/eA0YYbwX51TGu0mRzW4Y0YCF7qZlNrx06rxQTOr8IfM4\nFpOUurDTazgGzRYSespSdcitdrLCnF2YRVxvYXvGLe48E1KGAdlX5jgc3421H5KR\nmudKHMxFqHJV8LDmowfs/acbZp4/SItxhHFYyTr6717yW0QrPHTnj7JHwQdqzZq3\nDZb3EoEmUVQK7GH29/Xi8orIlQ2NAgMBAAGjgfgwgfUwDgYDVR0PAQH/BAQDAgGG\nMB0GA1UdJQQWMBQGCCsGAQUFBwMCBggrBgEFBQcDATASBgNVHRMBAf8ECDAGAQH/\nAgEAMB0GA1UdDgQWBBS7vMNHpeS8qcbDpHIMEI2iNeHI6DAfBgNVHSMEGDAWgBR5\ntFnme7bl5AFzgAiIyBpY9umbbjAyBggrBgEFBQcBAQQmMCQwIgYIKwYBBQUHMAKG\nFmh0dHA6Ly94MS5pLmxlbmNyLm9yZy8wEwYDVR0gBAwwCjAIBgZngQwBAgEwJwYD\nVR0fBCAwHjAcoBqgGIYWaHR0cDovL3gxLmMubGVuY3Iub3JnLzANBgkqhkiG9w0B\nAQsFAAOCAgEAkrHnQTfreZ2B5s3iJeE6IOmQRJWjgVzPw139vaBw1bGWKCIL0vIo\nzwzn1OZDjCQiHcFCktEJr59L9MhwTyAWsVrdAfYf+B9haxQnsHKNY67u4s5Lzzfd\nu6PUzeetUK29v+PsPmI2cJkxp+iN3epi4hKu9ZzUPSwMqtCceb7qPVxEbpYxY1p9\n1n5PJKBLBX9eb9LU6l8zSxPWV7bK3lG4XaMJgnT9x3ies7msFtpKK5bDtotij/l0\nGaKeA97pb5uwD9KgWvaFXMIEt8jVTjLEvwRdvCn294GPDF08U8lAkIv7tghluaQh\n1QnlE4SEN4LOECj8dsIGJXpGUk3aU3KkJz9icKy+aUgA+2cP21uh6NcDIS3XyfaZ\nQjmDQ993ChII8SXWupQZVBiIpcWO4RqZk3lr7Bz5MUCwzDIA359e57SSq5CCkY0N\n4B6Vulk7LktfwrdGNVI5BsC9qqxSwSKgRJeZ9wygIaehbHFHFhcBaMDKpiZlBHyz\nrsnnlFXCb5s8HKn5LsUgGvB24L7sGNZP2CX7dhHov+YhD+jozLW2p9W4959Bz2Ei\nRmqDtmiXLnzqTpXbI+suyCsohKRg6Un0RC47+cpiVwHiXZAW+cn8eiNIjqbVgXLx\nKPpdzvvtTnOPlC7SQZSYmdunr3Bf9b77AiC/ZidstK36dRILKz7OA54=\n-----END CERTIFICATE-----\n",
 		},
 	}
-	err := DeployQiniuCdn(cfg)
+	err := DeployQiniuCdn(context.Background(), cfg)
 	if err != nil {
 		t.Errorf("DeployQiniuCdn failed: %v", err)
 	} else {
@@ -44,7 +47,7 @@ func TestUpdateQiniuDomainCert(t *testing.T) {
 			"cert": "-----BEGIN CERTIFICATE-----\nMIIFBjCCA+6gAwIBAgISBYv494Gaq0RQHIs7aB7x8SvrMA0GCSqGSIb3DQEBCwUA\nMDMxCzAJBgNVBAYTAlVTMRYwFAYDVQQKEw1MZXQncyBFbmNyeXB0MQwwCgYDVQQD\nEwNSMTEwHhcNMjUwNjE5MDIwMTAxWhcNMjUwOTE3MDIwMTAwWjAfMR0wGwYDVQQD\nExR4aWFuZ3Fpbi43Nzk5NTIwLmNvbTCCASIwDQYJKoZIhvcNAQEBBQADggEPADCC\nAQoCggEBAJnRnRQBDPsNlzQPstwpJrrRTizNadRvsRxfVSMXZZMwDFRz3w9lfU/M\n5w1o5XBNXEEBMDwB6wHm+/kKsQyNZ3iTZgMOF1I5XTy41wUmipKxZVMv33HnFF86\nkF6F2R21eCsZ09wtrfVhD+fPcNH3mvd4O8VnSVCbHf6G3jcMGpYf6WIaoCSzUfFd\nMikaKhe3jsXGK+QhRnU18hQgM1rQU7iAS8u/c65k181I+el48enU/efEoDpzt5GN\niafgYTUN87GTTZKdrVUc0euv6gNrflXcPdT6U96VJnWy0nRlD5NFvSQ7ac7LNFyd\n+4MmenML+kVcVWTEtRpDmgGhzJnQfrsCAwEAAaOCAiYwggIiMA4GA1UdDwEB/wQE\nAwIFoDAdBgNVHSUEFjAUBggrBgEFBQcDAQYIKwYBBQUHAwIwDAYDVR0TAQH/BAIw\nADAdBgNVHQ4EFgQUUQbZ8GBo/oRpaIavLSGH9etsPkAwHwYDVR0jBBgwFoAUxc9G\npOr0w8B6bJXELbBeki8m47kwMwYIKwYBBQUHAQEEJzAlMCMGCCsGAQUFBzAChhdo\ndHRwOi8vcjExLmkubGVuY3Iub3JnLzAfBgNVHREEGDAWghR4aWFuZ3Fpbi43Nzk5\nNTIwLmNvbTATBgNVHSAEDDAKMAgGBmeBDAECATAvBgNVHR8EKDAmMCSgIqAghh5o\ndHRwOi8vcjExLmMubGVuY3Iub3JnLzEwOC5jcmwwggEFBgorBgEEAdZ5AgQCBIH2\nBIHzAPEAdwDd3Mo0ldfhFgXnlTL6x5/4PRxQ39sAOhQSdgosrLvIKgAAAZeGIJaX\nAAAEAwBIMEYCIQClkE0v+L8IXwqUvoty9HhLLKmjnfnHIBwGlZXti8P0ywIhAL8e\nRjsG1Lfz0c+gJ5MBrb4AaFuBzjpoivvh8G4cG2gAAHYAzPsPaoVxCWX+lZtTzumy\nfCLphVwNl422qX5UwP5MDbAAAAGXhiCepwAABAMARzBFAiEArk+Yu3MAs7bHnrDA\nhl3KvOQnTrZHmx6idubPKnTMUWACIH1Q3IA/EnNlf8cP8Jb69tCce/2i+u6qbsJr\n1ucZqZ7HMA0GCSqGSIb3DQEBCwUAA4IBAQA7PRrre6dv5rCNQ4z9waDcce9wXWZw\nJXzE+AE/OZ2crl8Pb2AWvFukN1w9yR9K5tMTZC/gGcFD1knzTAy2mAJn2GNgNMdE\n9LV4T2GA9H2r/NLRcNM9vE4D0M0UTWRxyJeqNAx0MNXqh5yvbE+3smxhiF190WIK\nt4Dmsk3U4RpilQ3aqK/mqdfCvOlvrDJuFD6I3+sG1Jjb9xtsM8+g/Dqx2iCeMwpH\ngLUbq9EpDf9gLXjYgELFeUdCirKR/eWEP3pxnJjEf2Gnisyy/gxNAhz/7Breb217\nKCW8PqoTgmjVNvQcUL5BKG0L1vB0JQ7Aq+osdXU5xKD1fNKuLWu/nghF\n-----END CERTIFICATE-----\n\n-----BEGIN CERTIFICATE-----\nMIIFBjCCAu6gAwIBAgIRAIp9PhPWLzDvI4a9KQdrNPgwDQYJKoZIhvcNAQELBQAw\nTzELMAkGA1UEBhMCVVMxKTAnBgNVBAoTIEludGVybmV0IFNlY3VyaXR5IFJlc2Vh\ncmNoIEdyb3VwMRUwEwYDVQQDEwxJU1JHIFJvb3QgWDEwHhcNMjQwMzEzMDAwMDAw\nWhcNMjcwMzEyMjM1OTU5WjAzMQswCQYDVQQGEwJVUzEWMBQGA1UEChMNTGV0J3Mg\nRW5jcnlwdDEMMAoGA1UEAxMDUjExMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIB\nCgKCAQEAuoe8XBsAOcvKCs3UZxD5ATylTqVhyybKUvsVAbe5KPUoHu0nsyQYOWcJ\nDAjs4DqwO3cOvfPlOVRBDE6uQdaZdN5R2+97/1i9qLcT9t4x1fJyyXJqC4N0lZxG\nAGQUmfOx2SLZzaiSqhwmej/+71gFewiVgdtxD4774zEJuwm+UE1fj5F2PVqdnoPy\n6cRms+EGZkNIGIBloDcYmpuEMpexsr3E+BUAnSeI++JjF5ZsmydnS8TbKF5pwnnw\nSVzgJFDhxLyhBax7QG0AtMJBP6dYuC/FXJuluwme8f7rsIU5/agK70XEeOtlKsLP\nXzze41xNG/cLJyuqC0J3U095ah2H2QIDAQABo4H4MIH1MA4GA1UdDwEB/wQEAwIB\nhjAdBgNVHSUEFjAUBggrBgEFBQcDAgYIKwYBBQUHAwEwEgYDVR0TAQH/BAgwBgEB\n/wIBADAdBgNVHQ4EFgQUxc9GpOr0w8B6bJXELbBeki8m47kwHwYDVR0jBBgwFoAU\nebRZ5nu25eQBc4AIiMgaWPbpm24wMgYIKwYBBQUHAQEEJjAkMCIGCCsGAQUFBzAC\nhhZodHRwOi8veDEuaS5sZW5jci5vcmcvMBMGA1UdIAQMMAowCAYGZ4EMAQIBMCcG\nA1UdHwQgMB4wHKAaoBiGFmh0dHA6Ly94MS5jLmxlbmNyLm9yZy8wDQYJKoZIhvcN\nAQELBQADggIBAE7iiV0KAxyQOND1H/lxXPjDj7I3iHpvsCUf7b632IYGjukJhM1y\nv4Hz/MrPU0jtvfZpQtSlET41yBOykh0FX+ou1Nj4ScOt9ZmWnO8m2OG0JAtIIE38\n01S0qcYhyOE2G/93ZCkXufBL713qzXnQv5C/viOykNpKqUgxdKlEC+Hi9i2DcaR1\ne9KUwQUZRhy5j/PEdEglKg3l9dtD4tuTm7kZtB8v32oOjzHTYw+7KdzdZiw/sBtn\nUfhBPORNuay4pJxmY/WrhSMdzFO2q3Gu3MUBcdo27goYKjL9CTF8j/Zz55yctUoV\naneCWs/ajUX+HypkBTA+c8LGDLnWO2NKq0YD/pnARkAnYGPfUDoHR9gVSp/qRx+Z\nWghiDLZsMwhN1zjtSC0uBWiugF3vTNzYIEFfaPG7Ws3jDrAMMYebQ95JQ+HIBD/R\nPBuHRTBpqKlyDnkSHDHYPiNX3adPoPAcgdF3H2/W0rmoswMWgTlLn1Wu0mrks7/q\npdWfS6PJ1jty80r2VKsM/Dj3YIDfbjXKdaFU5C+8bhfJGqU3taKauuz0wHVGT3eo\n6FlWkWYtbt4pgdamlwVeZEW+LM7qZEJEsMNPrfC03APKmZsJgpWCDWOKZvkZcvjV\nuYkQ4omYCTX5ohy+knMjdOmdH9c7SpqEWBDC86fiNex+O0XOMEZSa8DA\n-----END CERTIFICATE-----\n\n\n-----BEGIN CERTIFICATE-----\nMIIFBjCCAu6gAwIBAgIRAIp9PhPWLzDvI4a9KQdrNPgwDQYJKoZIhvcNAQELBQAw\nTzELMAkGA1UEBhMCVVMxKTAnBgNVBAoTIEludGVybmV0IFNlY3VyaXR5IFJlc2Vh\ncmNoIEdyb3VwMRUwEwYDVQQDEwxJU1JHIFJvb3QgWDEwHhcNMjQwMzEzMDAwMDAw\nWhcNMjcwMzEyMjM1OTU5WjAzMQswCQYDVQQGEwJVUzEWMBQGA1UEChMNTGV0J3Mg\nRW5jcnlwdDEMMAoGA1UEAxMDUjExMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIB\nCgKCAQEAuoe8XBsAOcvKCs3UZxD5ATylTqVhyybKUvsVAbe5KPUoHu0nsyQYOWcJ\nDAjs4DqwO3cOvfPlOVRBDE6uQdaZdN5R2+97/1i9qLcT9t4x1fJyyXJqC4N0lZxG\nAGQUmfOx2SLZzaiSqhwmej/+71gFewiVgdtxD4774zEJuwm+UE1fj5F2PVqdnoPy\n6cRms+EGZkNIGIBloDcYmpuEMpexsr3E+BUAnSeI++JjF5ZsmydnS8TbKF5pwnnw\nSVzgJFDhxLyhBax7QG0AtMJBP6dYuC/FXJuluwme8f7rsIU5/agK70XEeOtlKsLP\nXzze41xNG/cLJyuqC0J3U095ah2H2QIDAQABo4H4MIH1MA4GA1UdDwEB/wQEAwIB\nhjAdBgNVHSUEFjAUBggrBgEFBQcDAgYIKwYBBQUHAwEwEgYDVR0TAQH/BAgwBgEB\n/wIBADAdBgNVHQ4EFgQUxc9GpOr0w8B6bJXELbBeki8m47kwHwYDVR0jBBgwFoAU\nebRZ5nu25eQBc4AIiMgaWPbpm24wMgYIKwYBBQUHAQEEJjAkMCIGCCsGAQUFBzAC\nhhZodHRwOi8veDEuaS5sZW5jci5vcmcvMBMGA1UdIAQMMAowCAYGZ4EMAQIBMCcG\nA1UdHwQgMB4wHKAaoBiGFmh0dHA6Ly94MS5jLmxlbmNyLm9yZy8wDQYJKoZIhvcN\nAQELBQADggIBAE7iiV0KAxyQOND1H/lxXPjDj7I3iHpvsCUf7b632IYGjukJhM1y\nv4Hz/MrPU0jtvfZpQtSlET41yBOykh0FX+ou1Nj4ScOt9ZmWnO8m2OG0JAtIIE38\n01S0qcYhyOE2G/93ZCkXufBL713qzXnQv5C/viOykNpKqUgxdKlEC+Hi9i2DcaR1\ne9KUwQUZRhy5j/PEdEglKg3l9dtD4tuTm7kZtB8v32oOjzHTYw+7KdzdZiw/sBtn\nUfhBPORNuay4pJxmY/WrhSMdzFO2q3Gu3MUBcdo27goYKjL9CTF8j/Zz55yctUoV\naneCWs/ajUX+HypkBTA+c8LGDLnWO2NKq0YD/pnARkAnYGPfUDoHR9gVSp/qRx+Z\nWghiDLZsMwhN1zjtSC0uBWiugF3vTNzYIEFfaPG7Ws3jDrAMMYebQ95JQ+HIBD/R\nPBuHRTBpqKlyDnkSHDHYPiNX3adPoPAcgdF3H2/W0rmoswMWgTlLn1Wu0mrks7/q\npdWfS6PJ1jty80r2VKsM/Dj3YIDfbjXKdaFU5C+8bhfJGqU3taKauuz0wHVGT3eo\n6FlWkWYtbt4pgdamlwVeZEW+LM7qZEJEsMNPrfC03APKmZsJgpWCDWOKZvkZcvjV\nuYkQ4omYCTX5ohy+knMjdOmdH9c7SpqEWBDC86fiNex+O0XOMEZSa8DA\n-----END CERTIFICATE-----\n",
 		},
 	}
-	err := updateQiniuDomainCert(cfg)
+	err := updateQiniuDomainCert(context.Background(), cfg)
 	if err != nil {
 		t.Errorf("DeployQiniuOss failed: %v", err)
 	} else {
@@ -61,7 +64,7 @@ func TestQiniuOss(t *testing.T) {
 			"cert": "-----BEGIN CERTIFICATE-----\nMIIFBjCCA+6gAwIBAgISBYv494Gaq0RQHIs7aB7x8SvrMA0GCSqGSIb3DQEBCwUA\nMDMxCzAJBgNVBAYTAlVTMRYwFAYDVQQKEw1MZXQncyBFbmNyeXB0MQwwCgYDVQQD\nEwNSMTEwHhcNMjUwNjE5MDIwMTAxWhcNMjUwOTE3MDIwMTAwWjAfMR0wGwYDVQQD\nExR4aWFuZ3Fpbi43Nzk5NTIwLmNvbTCCASIwDQYJKoZIhvcNAQEBBQADggEPADCC\nAQoCggEBAJnRnRQBDPsNlzQPstwpJrrRTizNadRvsRxfVSMXZZMwDFRz3w9lfU/M\n5w1o5XBNXEEBMDwB6wHm+/kKsQyNZ3iTZgMOF1I5XTy41wUmipKxZVMv33HnFF86\nkF6F2R21eCsZ09wtrfVhD+fPcNH3mvd4O8VnSVCbHf6G3jcMGpYf6WIaoCSzUfFd\nMikaKhe3jsXGK+QhRnU18hQgM1rQU7iAS8u/c65k181I+el48enU/efEoDpzt5GN\niafgYTUN87GTTZKdrVUc0euv6gNrflXcPdT6U96VJnWy0nRlD5NFvSQ7ac7LNFyd\n+4MmenML+kVcVWTEtRpDmgGhzJnQfrsCAwEAAaOCAiYwggIiMA4GA1UdDwEB/wQE\nAwIFoDAdBgNVHSUEFjAUBggrBgEFBQcDAQYIKwYBBQUHAwIwDAYDVR0TAQH/BAIw\nADAdBgNVHQ4EFgQUUQbZ8GBo/oRpaIavLSGH9etsPkAwHwYDVR0jBBgwFoAUxc9G\npOr0w8B6bJXELbBeki8m47kwMwYIKwYBBQUHAQEEJzAlMCMGCCsGAQUFBzAChhdo\ndHRwOi8vcjExLmkubGVuY3Iub3JnLzAfBgNVHREEGDAWghR4aWFuZ3Fpbi43Nzk5\nNTIwLmNvbTATBgNVHSAEDDAKMAgGBmeBDAECATAvBgNVHR8EKDAmMCSgIqAghh5o\ndHRwOi8vcjExLmMubGVuY3Iub3JnLzEwOC5jcmwwggEFBgorBgEEAdZ5AgQCBIH2\nBIHzAPEAdwDd3Mo0ldfhFgXnlTL6x5/4PRxQ39sAOhQSdgosrLvIKgAAAZeGIJaX\nAAAEAwBIMEYCIQClkE0v+L8IXwqUvoty9HhLLKmjnfnHIBwGlZXti8P0ywIhAL8e\nRjsG1Lfz0c+gJ5MBrb4AaFuBzjpoivvh8G4cG2gAAHYAzPsPaoVxCWX+lZtTzumy\nfCLphVwNl422qX5UwP5MDbAAAAGXhiCepwAABAMARzBFAiEArk+Yu3MAs7bHnrDA\nhl3KvOQnTrZHmx6idubPKnTMUWACIH1Q3IA/EnNlf8cP8Jb69tCce/2i+u6qbsJr\n1ucZqZ7HMA0GCSqGSIb3DQEBCwUAA4IBAQA7PRrre6dv5rCNQ4z9waDcce9wXWZw\nJXzE+AE/OZ2crl8Pb2AWvFukN1w9yR9K5tMTZC/gGcFD1knzTAy2mAJn2GNgNMdE\n9LV4T2GA9H2r/NLRcNM9vE4D0M0UTWRxyJeqNAx0MNXqh5yvbE+3smxhiF190WIK\nt4Dmsk3U4RpilQ3aqK/mqdfCvOlvrDJuFD6I3+sG1Jjb9xtsM8+g/Dqx2iCeMwpH\ngLUbq9EpDf9gLXjYgELFeUdCirKR/eWEP3pxnJjEf2Gnisyy/gxNAhz/7Breb217\nKCW8PqoTgmjVNvQcUL5BKG0L1vB0JQ7Aq+osdXU5xKD1fNKuLWu/nghF\n-----END CERTIFICATE-----\n\n-----BEGIN CERTIFICATE-----\nMIIFBjCCAu6gAwIBAgIRAIp9PhPWLzDvI4a9KQdrNPgwDQYJKoZIhvcNAQELBQAw\nTzELMAkGA1UEBhMCVVMxKTAnBgNVBAoTIEludGVybmV0IFNlY3VyaXR5IFJlc2Vh\ncmNoIEdyb3VwMRUwEwYDVQQDEwxJU1JHIFJvb3QgWDEwHhcNMjQwMzEzMDAwMDAw\nWhcNMjcwMzEyMjM1OTU5WjAzMQswCQYDVQQGEwJVUzEWMBQGA1UEChMNTGV0J3Mg\nRW5jcnlwdDEMMAoGA1UEAxMDUjExMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIB\nCgKCAQEAuoe8XBsAOcvKCs3UZxD5ATylTqVhyybKUvsVAbe5KPUoHu0nsyQYOWcJ\nDAjs4DqwO3cOvfPlOVRBDE6uQdaZdN5R2+97/1i9qLcT9t4x1fJyyXJqC4N0lZxG\nAGQUmfOx2SLZzaiSqhwmej/+71gFewiVgdtxD4774zEJuwm+UE1fj5F2PVqdnoPy\n6cRms+EGZkNIGIBloDcYmpuEMpexsr3E+BUAnSeI++JjF5ZsmydnS8TbKF5pwnnw\nSVzgJFDhxLyhBax7QG0AtMJBP6dYuC/FXJuluwme8f7rsIU5/agK70XEeOtlKsLP\nXzze41xNG/cLJyuqC0J3U095ah2H2QIDAQABo4H4MIH1MA4GA1UdDwEB/wQEAwIB\nhjAdBgNVHSUEFjAUBggrBgEFBQcDAgYIKwYBBQUHAwEwEgYDVR0TAQH/BAgwBgEB\n/wIBADAdBgNVHQ4EFgQUxc9GpOr0w8B6bJXELbBeki8m47kwHwYDVR0jBBgwFoAU\nebRZ5nu25eQBc4AIiMgaWPbpm24wMgYIKwYBBQUHAQEEJjAkMCIGCCsGAQUFBzAC\nhhZodHRwOi8veDEuaS5sZW5jci5vcmcvMBMGA1UdIAQMMAowCAYGZ4EMAQIBMCcG\nA1UdHwQgMB4wHKAaoBiGFmh0dHA6Ly94MS5jLmxlbmNyLm9yZy8wDQYJKoZIhvcN\nAQELBQADggIBAE7iiV0KAxyQOND1H/lxXPjDj7I3iHpvsCUf7b632IYGjukJhM1y\nv4Hz/MrPU0jtvfZpQtSlET41yBOykh0FX+ou1Nj4ScOt9ZmWnO8m2OG0JAtIIE38\n01S0qcYhyOE2G/93ZCkXufBL713qzXnQv5C/viOykNpKqUgxdKlEC+Hi9i2DcaR1\ne9KUwQUZRhy5j/PEdEglKg3l9dtD4tuTm7kZtB8v32oOjzHTYw+7KdzdZiw/sBtn\nUfhBPORNuay4pJxmY/WrhSMdzFO2q3Gu3MUBcdo27goYKjL9CTF8j/Zz55yctUoV\naneCWs/ajUX+HypkBTA+c8LGDLnWO2NKq0YD/pnARkAnYGPfUDoHR9gVSp/qRx+Z\nWghiDLZsMwhN1zjtSC0uBWiugF3vTNzYIEFfaPG7Ws3jDrAMMYebQ95JQ+HIBD/R\nPBuHRTBpqKlyDnkSHDHYPiNX3adPoPAcgdF3H2/W0rmoswMWgTlLn1Wu0mrks7/q\npdWfS6PJ1jty80r2VKsM/Dj3YIDfbjXKdaFU5C+8bhfJGqU3taKauuz0wHVGT3eo\n6FlWkWYtbt4pgdamlwVeZEW+LM7qZEJEsMNPrfC03APKmZsJgpWCDWOKZvkZcvjV\nuYkQ4omYCTX5ohy+knMjdOmdH9c7SpqEWBDC86fiNex+O0XOMEZSa8DA\n-----END CERTIFICATE-----\n\n\n-----BEGIN CERTIFICATE-----\nMIIFBjCCAu6gAwIBAgIRAIp9PhPWLzDvI4a9KQdrNPgwDQYJKoZIhvcNAQELBQAw\nTzELMAkGA1UEBhMCVVMxKTAnBgNVBAoTIEludGVybmV0IFNlY3VyaXR5IFJlc2Vh\ncmNoIEdyb3VwMRUwEwYDVQQDEwxJU1JHIFJvb3QgWDEwHhcNMjQwMzEzMDAwMDAw\nWhcNMjcwMzEyMjM1OTU5WjAzMQswCQYDVQQGEwJVUzEWMBQGA1UEChMNTGV0J3Mg\nRW5jcnlwdDEMMAoGA1UEAxMDUjExMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIB\nCgKCAQEAuoe8XBsAOcvKCs3UZxD5ATylTqVhyybKUvsVAbe5KPUoHu0nsyQYOWcJ\nDAjs4DqwO3cOvfPlOVRBDE6uQdaZdN5R2+97/1i9qLcT9t4x1fJyyXJqC4N0lZxG\nAGQUmfOx2SLZzaiSqhwmej/+71gFewiVgdtxD4774zEJuwm+UE1fj5F2PVqdnoPy\n6cRms+EGZkNIGIBloDcYmpuEMpexsr3E+BUAnSeI++JjF5ZsmydnS8TbKF5pwnnw\nSVzgJFDhxLyhBax7QG0AtMJBP6dYuC/FXJuluwme8f7rsIU5/agK70XEeOtlKsLP\nXzze41xNG/cLJyuqC0J3U095ah2H2QIDAQABo4H4MIH1MA4GA1UdDwEB/wQEAwIB\nhjAdBgNVHSUEFjAUBggrBgEFBQcDAgYIKwYBBQUHAwEwEgYDVR0TAQH/BAgwBgEB\n/wIBADAdBgNVHQ4EFgQUxc9GpOr0w8B6bJXELbBeki8m47kwHwYDVR0jBBgwFoAU\nebRZ5nu25eQBc4AIiMgaWPbpm24wMgYIKwYBBQUHAQEEJjAkMCIGCCsGAQUFBzAC\nhhZodHRwOi8veDEuaS5sZW5jci5vcmcvMBMGA1UdIAQMMAowCAYGZ4EMAQIBMCcG\nA1UdHwQgMB4wHKAaoBiGFmh0dHA6Ly94MS5jLmxlbmNyLm9yZy8wDQYJKoZIhvcN\nAQELBQADggIBAE7iiV0KAxyQOND1H/lxXPjDj7I3iHpvsCUf7b632IYGjukJhM1y\nv4Hz/MrPU0jtvfZpQtSlET41yBOykh0FX+ou1Nj4ScOt9ZmWnO8m2OG0JAtIIE38\n01S0qcYhyOE2G/93ZCkXufBL713qzXnQv5C/viOykNpKqUgxdKlEC+Hi9i2DcaR1\ne9KUwQUZRhy5j/PEdEglKg3l9dtD4tuTm7kZtB8v32oOjzHTYw+7KdzdZiw/sBtn\nUfhBPORNuay4pJxmY/WrhSMdzFO2q3Gu3MUBcdo27goYKjL9CTF8j/Zz55yctUoV\naneCWs/ajUX+HypkBTA+c8LGDLnWO2NKq0YD/pnARkAnYGPfUDoHR9gVSp/qRx+Z\nWghiDLZsMwhN1zjtSC0uBWiugF3vTNzYIEFfaPG7Ws3jDrAMMYebQ95JQ+HIBD/R\nPBuHRTBpqKlyDnkSHDHYPiNX3adPoPAcgdF3H2/W0rmoswMWgTlLn1Wu0mrks7/q\npdWfS6PJ1jty80r2VKsM/Dj3YIDfbjXKdaFU5C+8bhfJGqU3taKauuz0wHVGT3eo\n6FlWkWYtbt4pgdamlwVeZEW+LM7qZEJEsMNPrfC03APKmZsJgpWCDWOKZvkZcvjV\nuYkQ4omYCTX5ohy+knMjdOmdH9c7SpqEWBDC86fiNex+O0XOMEZSa8DA\n-----END CERTIFICATE-----\n",
 		},
 	}
-	err := DeployQiniuOss(cfg)
+	err := DeployQiniuOss(context.Background(), cfg)
 	if err != nil {
 		t.Errorf("DeployQiniuOss failed: %v", err)
 	} else {
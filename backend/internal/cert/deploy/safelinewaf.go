@@ -4,6 +4,7 @@ import (
 	"ALLinSSL/backend/internal/access"
 	"ALLinSSL/backend/public"
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
@@ -13,7 +14,7 @@ import (
 	"strconv"
 )
 
-func RequestSafeLineWaf(data *map[string]any, method, providerID, requestUrl string) (map[string]any, error) {
+func RequestSafeLineWaf(ctx context.Context, data *map[string]any, method, providerID, requestUrl string) (map[string]any, error) {
 	providerData, err := access.GetAccess(providerID)
 	if err != nil {
 		return nil, err
@@ -36,7 +37,7 @@ func RequestSafeLineWaf(data *map[string]any, method, providerID, requestUrl str
 	baseURL := fmt.Sprintf("%s://%s/", parsedURL.Scheme, parsedURL.Host)
 
 	jsonData, err := json.Marshal(data)
-	req, err := http.NewRequest(method, baseURL+requestUrl, bytes.NewReader(jsonData))
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+requestUrl, bytes.NewReader(jsonData))
 	if err != nil {
 		return nil, err
 	}
@@ -57,10 +58,13 @@ func RequestSafeLineWaf(data *map[string]any, method, providerID, requestUrl str
 	resp, err := client.Do(req)
 	if err != nil {
 		// fmt.Println(err)
-		return nil, fmt.Errorf("请求雷池WAF失败: %v", err)
+		return nil, fmt.Errorf("请求雷池WAF失败: %w", err)
 	}
-	body, _ := io.ReadAll(resp.Body)
 	defer resp.Body.Close()
+	if err = checkResponseStatus("雷池WAF", resp); err != nil {
+		return nil, err
+	}
+	body, _ := io.ReadAll(resp.Body)
 	var res map[string]interface{}
 	err = json.Unmarshal(body, &res)
 	if err != nil {
@@ -72,9 +76,9 @@ func RequestSafeLineWaf(data *map[string]any, method, providerID, requestUrl str
 	return res, nil
 }
 
-func GetSafeLineWafSiteList(page int, pageSize int, siteName string, providerId string) ([]any, error) {
+func GetSafeLineWafSiteList(ctx context.Context, page int, pageSize int, siteName string, providerId string) ([]any, error) {
 	requestUrl := fmt.Sprintf("api/open/site?page=%d&page_size=%d&site=%s", page, pageSize, siteName)
-	response, err := RequestSafeLineWaf(&map[string]any{}, "GET", providerId, requestUrl)
+	response, err := RequestSafeLineWaf(ctx, &map[string]any{}, "GET", providerId, requestUrl)
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +97,7 @@ func matchSafeLineSiteByColumn(siteList []any, column string, keyword string) (s
 }
 
 // 上传证书 certId="" 新上传证书 否则覆盖证书
-func uploadSafeLineCert(certId float64, key, cert, providerId string) (id float64, err error) {
+func uploadSafeLineCert(ctx context.Context, certId float64, key, cert, providerId string) (id float64, err error) {
 	data := map[string]any{
 		"type": 2,
 		"manual": map[string]any{
@@ -104,14 +108,14 @@ func uploadSafeLineCert(certId float64, key, cert, providerId string) (id float6
 	if certId != 0 {
 		data["id"] = certId
 	}
-	response, err := RequestSafeLineWaf(&data, "POST", providerId, "api/open/cert")
+	response, err := RequestSafeLineWaf(ctx, &data, "POST", providerId, "api/open/cert")
 	if err != nil {
 		return 0, err
 	}
 	return response["data"].(float64), nil
 }
 
-func DeploySafeLineWaf(cfg map[string]any) error {
+func DeploySafeLineWaf(ctx context.Context, cfg map[string]any) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -135,11 +139,11 @@ func DeploySafeLineWaf(cfg map[string]any) error {
 		return fmt.Errorf("参数错误：provider_id")
 	}
 
-	certId, err := uploadSafeLineCert(0, keyPem, certPem, providerID)
+	certId, err := uploadSafeLineCert(ctx, 0, keyPem, certPem, providerID)
 	data := map[string]any{
 		"cert_id": certId,
 	}
-	_, err = RequestSafeLineWaf(&data, "PUT", providerID, "api/open/system")
+	_, err = RequestSafeLineWaf(ctx, &data, "PUT", providerID, "api/open/system")
 
 	if err != nil {
 		return fmt.Errorf("证书部署失败: %v", err)
@@ -147,7 +151,7 @@ func DeploySafeLineWaf(cfg map[string]any) error {
 	return nil
 }
 
-func DeploySafeLineWafSite(cfg map[string]any, logger *public.Logger) error {
+func DeploySafeLineWafSite(ctx context.Context, cfg map[string]any, logger *public.Logger) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -174,7 +178,7 @@ func DeploySafeLineWafSite(cfg map[string]any, logger *public.Logger) error {
 	if !ok {
 		return fmt.Errorf("参数错误：siteName")
 	}
-	siteList, err := GetSafeLineWafSiteList(1, 100, siteName, providerID)
+	siteList, err := GetSafeLineWafSiteList(ctx, 1, 100, siteName, providerID)
 	if siteList == nil || err != nil {
 		return fmt.Errorf("雷池WAF找不到网站名称：%s", siteName)
 	}
@@ -188,7 +192,7 @@ func DeploySafeLineWafSite(cfg map[string]any, logger *public.Logger) error {
 	if certId == 0 {
 		//未部署证书
 		logger.Debug(fmt.Sprintf("网站%s未启用SSL,上传证书中...", siteName))
-		certId, err := uploadSafeLineCert(0, keyPem, certPem, providerID)
+		certId, err := uploadSafeLineCert(ctx, 0, keyPem, certPem, providerID)
 		if err != nil {
 			return fmt.Errorf("网站%s上传证书失败...：%s", siteName, err.Error())
 		}
@@ -196,7 +200,7 @@ func DeploySafeLineWafSite(cfg map[string]any, logger *public.Logger) error {
 	} else {
 		//已部署证书
 		logger.Debug(fmt.Sprintf("网站已启用SSL 证书ID：%d 更新证书中...", int(certId)))
-		_, err := uploadSafeLineCert(certId, keyPem, certPem, providerID)
+		_, err := uploadSafeLineCert(ctx, certId, keyPem, certPem, providerID)
 		if err != nil {
 			return fmt.Errorf("网站%s证书更新成功...：%s", siteName, err.Error())
 		}
@@ -206,7 +210,7 @@ func DeploySafeLineWafSite(cfg map[string]any, logger *public.Logger) error {
 }
 
 func SafeLineAPITest(providerID string) error {
-	_, err := RequestSafeLineWaf(&map[string]any{}, "GET", providerID, "api/open/site/group")
+	_, err := RequestSafeLineWaf(context.Background(), &map[string]any{}, "GET", providerID, "api/open/site/group")
 	if err != nil {
 		return fmt.Errorf("测试请求失败: %v", err)
 	}
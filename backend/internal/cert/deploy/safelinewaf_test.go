@@ -2,6 +2,7 @@ package deploy
 
 import (
 	"ALLinSSL/backend/public"
+	"context"
 	"fmt"
 	"testing"
 )
@@ -17,7 +18,7 @@ func TestSafeLineWAFSite(t *testing.T) {
 		},
 	}
 	log, err := public.NewLogger("/www/allinss/log/test.log")
-	err = DeploySafeLineWafSite(cfg, log)
+	err = DeploySafeLineWafSite(context.Background(), cfg, log)
 	fmt.Println(err)
 }
 
@@ -30,12 +31,12 @@ func TestSafeLineWAFP(t *testing.T) {
 			"issuer": "cert-issuer",
 		},
 	}
-	err := DeploySafeLineWaf(cfg)
+	err := DeploySafeLineWaf(context.Background(), cfg)
 	fmt.Println(err)
 }
 
 func TestGetSafeLineWAFSiteList(t *testing.T) {
-	res, err := GetSafeLineWafSiteList(1, 100, "测", "5")
+	res, err := GetSafeLineWafSiteList(context.Background(), 1, 100, "测", "5")
 	//for _, site := range res {
 	//sites := site.(map[string]any)
 	//fmt.Println(sites)
@@ -2,17 +2,21 @@ package deploy
 
 import (
 	"ALLinSSL/backend/internal/access"
+	"ALLinSSL/backend/internal/cert"
 	"ALLinSSL/backend/public"
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"github.com/pkg/sftp"
 	"golang.org/x/crypto/ssh"
+	"net"
 	"os"
 	"path"
 	"path/filepath"
 	"strconv"
+	"time"
 )
 
 type SSHConfig struct {
@@ -60,7 +64,31 @@ func buildAuthMethods(password, privateKey string) ([]ssh.AuthMethod, error) {
 	return methods, nil
 }
 
-func writeMultipleFilesViaSSH(config SSHConfig, files []RemoteFile, preCmd, postCmd string, logger *public.Logger) error {
+// dialSSH 建立 ssh 连接，ctx 取消时关闭底层连接，进行中的会话和文件传输随之中断
+func dialSSH(ctx context.Context, addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	dialer := net.Dialer{Timeout: 30 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	c, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		stop()
+		conn.Close()
+		return nil, err
+	}
+	client := ssh.NewClient(c, chans, reqs)
+	go func() {
+		client.Wait()
+		stop()
+	}()
+	return client, nil
+}
+
+func writeMultipleFilesViaSSH(ctx context.Context, config SSHConfig, files []RemoteFile, preCmd, postCmd string, logger *public.Logger) error {
 	var port string
 	switch v := config.Port.(type) {
 	case float64:
@@ -94,7 +122,7 @@ func writeMultipleFilesViaSSH(config SSHConfig, files []RemoteFile, preCmd, post
 		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
 	}
 
-	client, err := ssh.Dial("tcp", addr, sshConfig)
+	client, err := dialSSH(ctx, addr, sshConfig)
 	if err != nil {
 		return fmt.Errorf("failed to dial: %v", err)
 	}
@@ -196,7 +224,7 @@ func runSSHCommand(client *ssh.Client, cmd string) (string, string, error) {
 	return stdoutBuf.String(), stderrBuf.String(), err
 }
 
-func DeploySSH(cfg map[string]any, logger *public.Logger) error {
+func DeploySSH(ctx context.Context, cfg map[string]any, logger *public.Logger) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -227,6 +255,8 @@ func DeploySSH(cfg map[string]any, logger *public.Logger) error {
 	if !ok {
 		return fmt.Errorf("参数错误：certPath")
 	}
+	certPath = ExpandCertPath(certPath, cert)
+	keyPath = ExpandCertPath(keyPath, cert)
 	beforeCmd, ok := cfg["beforeCmd"].(string)
 	if !ok {
 		beforeCmd = ""
@@ -254,14 +284,37 @@ func DeploySSH(cfg map[string]any, logger *public.Logger) error {
 		{Path: certPath, Content: certPem},
 		{Path: keyPath, Content: keyPem},
 	}
-	err = writeMultipleFilesViaSSH(providerConfig, files, beforeCmd, afterCmd, logger)
+	if ocspPath, ok := cfg["ocspPath"].(string); ok && ocspPath != "" {
+		if ocspDER := getOCSPStapling(ctx, cert, logger); ocspDER != nil {
+			files = append(files, RemoteFile{Path: ExpandCertPath(ocspPath, cert), Content: string(ocspDER)})
+		}
+	}
+	err = writeMultipleFilesViaSSH(ctx, providerConfig, files, beforeCmd, afterCmd, logger)
 	if err != nil {
 		return fmt.Errorf("SSH 部署失败: %v", err)
 	}
 	return nil
 }
 
-func DeployLocalhost(cfg map[string]any) error {
+// WriteFilesViaSSH 使用指定的 ssh 授权写入远程文件，供部署之外的场景（如 HTTP-01 验证文件）复用
+func WriteFilesViaSSH(ctx context.Context, providerID string, files []RemoteFile, preCmd, postCmd string, logger *public.Logger) error {
+	providerData, err := access.GetAccess(providerID)
+	if err != nil {
+		return err
+	}
+	providerConfigStr, ok := providerData["config"].(string)
+	if !ok {
+		return fmt.Errorf("api配置错误")
+	}
+	var providerConfig SSHConfig
+	err = json.Unmarshal([]byte(providerConfigStr), &providerConfig)
+	if err != nil {
+		return err
+	}
+	return writeMultipleFilesViaSSH(ctx, providerConfig, files, preCmd, postCmd, logger)
+}
+
+func DeployLocalhost(ctx context.Context, cfg map[string]any, logger *public.Logger) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -283,9 +336,11 @@ func DeployLocalhost(cfg map[string]any) error {
 	if !ok {
 		return fmt.Errorf("参数错误：certPath")
 	}
+	certPath = ExpandCertPath(certPath, cert)
+	keyPath = ExpandCertPath(keyPath, cert)
 	beforeCmd, ok := cfg["beforeCmd"].(string)
 	if ok {
-		_, errout, err := public.ExecCommand(beforeCmd)
+		_, errout, err := public.ExecCommandContext(ctx, beforeCmd)
 		if err != nil {
 			return fmt.Errorf("前置命令执行失败: %v, %s", err, errout)
 		}
@@ -307,10 +362,21 @@ func DeployLocalhost(cfg map[string]any) error {
 	if err != nil {
 		return fmt.Errorf("写入私钥失败: %v", err)
 	}
+	if ocspPath, ok := cfg["ocspPath"].(string); ok && ocspPath != "" {
+		if ocspDER := getOCSPStapling(ctx, cert, logger); ocspDER != nil {
+			ocspPath = ExpandCertPath(ocspPath, cert)
+			if err := os.MkdirAll(filepath.Dir(ocspPath), os.ModePerm); err != nil {
+				return fmt.Errorf("创建OCSP文件目录失败: %v", err)
+			}
+			if err := os.WriteFile(ocspPath, ocspDER, 0644); err != nil {
+				return fmt.Errorf("写入OCSP文件失败: %v", err)
+			}
+		}
+	}
 
 	afterCmd, ok := cfg["afterCmd"].(string)
 	if ok {
-		_, errout, err := public.ExecCommand(afterCmd)
+		_, errout, err := public.ExecCommandContext(ctx, afterCmd)
 		if err != nil {
 			return fmt.Errorf("后置命令执行失败: %v, %s", err, errout)
 		}
@@ -382,3 +448,15 @@ func SSHAPITest(providerID string) error {
 
 	return nil
 }
+
+// getOCSPStapling 获取用于 OCSP Stapling 的响应文件内容，失败时记录日志并跳过，不影响证书部署
+func getOCSPStapling(ctx context.Context, certificate map[string]any, logger *public.Logger) []byte {
+	certPem, _ := certificate["cert"].(string)
+	ocspDER, err := cert.GetOCSPResponse(ctx, certPem, false)
+	if err != nil {
+		logger.Error("获取OCSP响应失败，跳过OCSP文件部署：" + err.Error())
+		return nil
+	}
+	logger.Debug("已获取OCSP响应")
+	return ocspDER
+}
@@ -1,6 +1,10 @@
 package deploy
 
-import "testing"
+import (
+	"ALLinSSL/backend/public"
+	"context"
+	"testing"
+)
 
 func TestSSH(t *testing.T) {
 	cfg := map[string]any{
@@ -14,7 +18,8 @@ func TestSSH(t *testing.T) {
 			"issuer": "cert-issuer",
 		},
 	}
-	err := DeploySSH(cfg)
+	logger, _ := public.NewLogger("/tmp/test.log")
+	err := DeploySSH(context.Background(), cfg, logger)
 	if err != nil {
 		t.Fatalf("DeploySSH failed: %v", err)
 	}
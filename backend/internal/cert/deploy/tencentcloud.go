@@ -2,6 +2,7 @@ package deploy
 
 import (
 	"ALLinSSL/backend/internal/access"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
@@ -25,13 +26,13 @@ func ClientTencentcloud(SecretId, SecretKey, region string) *ssl.Client {
 	return client
 }
 
-func UploadToTX(client *ssl.Client, key, cert string) (string, error) {
+func UploadToTX(ctx context.Context, client *ssl.Client, key, cert string) (string, error) {
 	request := ssl.NewUploadCertificateRequest()
 	request.CertificatePublicKey = common.StringPtr(cert)
 	request.CertificatePrivateKey = common.StringPtr(key)
 	request.Repeatable = common.BoolPtr(false)
 	// 返回的resp是一个UploadCertificateResponse的实例，与请求对象对应
-	response, err := client.UploadCertificate(request)
+	response, err := client.UploadCertificateWithContext(ctx, request)
 	if _, ok := err.(*errors.TencentCloudSDKError); ok {
 		return "", err
 	}
@@ -41,7 +42,7 @@ func UploadToTX(client *ssl.Client, key, cert string) (string, error) {
 	return *response.Response.CertificateId, nil
 }
 
-func DeployToTX(cfg map[string]any) error {
+func DeployToTX(ctx context.Context, cfg map[string]any) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -86,7 +87,7 @@ func DeployToTX(cfg map[string]any) error {
 	client := ClientTencentcloud(providerConfig["secret_id"], providerConfig["secret_key"], region)
 
 	// 上传证书
-	certificateId, err := UploadToTX(client, strings.TrimSpace(keyPem), strings.TrimSpace(certPem))
+	certificateId, err := UploadToTX(ctx, client, strings.TrimSpace(keyPem), strings.TrimSpace(certPem))
 	if err != nil {
 		return err
 	}
@@ -130,7 +131,7 @@ func DeployToTX(cfg map[string]any) error {
 	}
 
 	// 返回的resp是一个DeployCertificateInstanceResponse的实例，与请求对象对应
-	response, err := client.DeployCertificateInstance(request)
+	response, err := client.DeployCertificateInstanceWithContext(ctx, request)
 	if _, ok := err.(*errors.TencentCloudSDKError); ok {
 		return err
 	}
@@ -3,12 +3,13 @@ package deploy
 import (
 	"ALLinSSL/backend/internal/access"
 	volccdn "ALLinSSL/backend/internal/cert/deploy/client/volcengine"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
 )
 
-func DeployVolcEngineCdn(cfg map[string]any) error {
+func DeployVolcEngineCdn(ctx context.Context, cfg map[string]any) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -59,11 +60,11 @@ func DeployVolcEngineCdn(cfg map[string]any) error {
 		return fmt.Errorf("证书错误：cert")
 	}
 
-	certId, err := client.IUploadCert(certPem, keyPem)
+	certId, err := client.IUploadCert(ctx, certPem, keyPem)
 	if err != nil {
 		return fmt.Errorf("上传证书失败: %w", err)
 	}
-	err = client.IBatchDeployCert(certId, domain)
+	err = client.IBatchDeployCert(ctx, certId, domain)
 	if err != nil {
 		return fmt.Errorf("部署证书失败: %w", err)
 	}
@@ -74,7 +75,7 @@ func DeployVolcEngineCdn(cfg map[string]any) error {
 	return nil
 }
 
-func DeployVolcEngineDCdn(cfg map[string]any) error {
+func DeployVolcEngineDCdn(ctx context.Context, cfg map[string]any) error {
 	cert, ok := cfg["certificate"].(map[string]any)
 	if !ok {
 		return fmt.Errorf("证书不存在")
@@ -126,11 +127,11 @@ func DeployVolcEngineDCdn(cfg map[string]any) error {
 		return fmt.Errorf("证书错误：cert")
 	}
 
-	certId, err := cdnClient.IUploadCert(certPem, keyPem)
+	certId, err := cdnClient.IUploadCert(ctx, certPem, keyPem)
 	if err != nil {
 		return fmt.Errorf("上传证书失败: %w", err)
 	}
-	err = dcdnDlient.IDCDNCreateCertBindInput(certId, domain)
+	err = dcdnDlient.IDCDNCreateCertBindInput(ctx, certId, domain)
 	if err != nil {
 		return fmt.Errorf("部署证书失败: %w", err)
 	}
@@ -1,6 +1,9 @@
 package deploy
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 func TestDeployVolcEngineCdn(t *testing.T) {
 	cfg := map[string]any{
@@ -13,7 +16,7 @@ func TestDeployVolcEngineCdn(t *testing.T) {
 			"issuer": "cert-issuer",
 		},
 	}
-	err := DeployVolcEngineCdn(cfg)
+	err := DeployVolcEngineCdn(context.Background(), cfg)
 	if err != nil {
 		t.Errorf("TestDeployVolcEngineCdn failed: %v", err)
 	} else {
@@ -32,7 +35,7 @@ func TestDeployVolcEngineDCdn(t *testing.T) {
 			"issuer": "cert-issuer",
 		},
 	}
-	err := DeployVolcEngineDCdn(cfg)
+	err := DeployVolcEngineDCdn(context.Background(), cfg)
 	if err != nil {
 		t.Errorf("TestDeployVolcEngineDCdn failed: %v", err)
 	} else {
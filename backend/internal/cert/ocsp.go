@@ -0,0 +1,146 @@
+package cert
+
+import (
+	"ALLinSSL/backend/public"
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"golang.org/x/crypto/ocsp"
+	"io"
+	"net/http"
+	"time"
+)
+
+const ocspTimeLayout = "2006-01-02 15:04:05"
+
+// parseCertAndIssuer 解析证书及签发者证书，issuerPEM 为空时从证书链中获取
+func parseCertAndIssuer(certPEM, issuerPEM string) (*x509.Certificate, *x509.Certificate, error) {
+	certs, err := certcrypto.ParsePEMBundle([]byte(certPEM))
+	if err != nil {
+		return nil, nil, fmt.Errorf("解析证书失败: %v", err)
+	}
+	leaf := certs[0]
+	if len(certs) > 1 {
+		return leaf, certs[1], nil
+	}
+	if issuerPEM == "" {
+		return nil, nil, fmt.Errorf("缺少签发者证书，无法查询OCSP")
+	}
+	issuers, err := certcrypto.ParsePEMBundle([]byte(issuerPEM))
+	if err != nil {
+		return nil, nil, fmt.Errorf("解析签发者证书失败: %v", err)
+	}
+	return leaf, issuers[0], nil
+}
+
+// FetchOCSP 向证书中的 OCSP 地址查询证书状态，返回 DER 格式的 OCSP 响应，可直接作为 stapling 文件使用，
+// ctx 取消时中断请求
+func FetchOCSP(ctx context.Context, certPEM, issuerPEM string, httpClient *http.Client) ([]byte, *ocsp.Response, error) {
+	leaf, issuer, err := parseCertAndIssuer(certPEM, issuerPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(leaf.OCSPServer) == 0 {
+		return nil, nil, fmt.Errorf("证书未包含OCSP地址")
+	}
+	req, err := ocsp.CreateRequest(leaf, issuer, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, leaf.OCSPServer[0], bytes.NewReader(req))
+	if err != nil {
+		return nil, nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/ocsp-request")
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("请求OCSP服务器失败: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("OCSP服务器返回状态码：%d", resp.StatusCode)
+	}
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, nil, err
+	}
+	ocspResp, err := ocsp.ParseResponseForCert(raw, leaf, issuer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("解析OCSP响应失败: %v", err)
+	}
+	switch ocspResp.Status {
+	case ocsp.Good:
+	case ocsp.Revoked:
+		return nil, ocspResp, fmt.Errorf("证书已被吊销，吊销时间：%s", ocspResp.RevokedAt.Local().Format(ocspTimeLayout))
+	default:
+		return nil, ocspResp, fmt.Errorf("OCSP服务器返回证书状态未知")
+	}
+	return raw, ocspResp, nil
+}
+
+// getCachedOCSP 读取证书记录中缓存的 OCSP 响应，超过有效期一半时视为需要刷新
+func getCachedOCSP(certRow map[string]any, now time.Time) ([]byte, bool) {
+	respStr, _ := certRow["ocsp_response"].(string)
+	thisStr, _ := certRow["ocsp_this_update"].(string)
+	nextStr, _ := certRow["ocsp_next_update"].(string)
+	if respStr == "" || thisStr == "" || nextStr == "" {
+		return nil, false
+	}
+	thisUpdate, err := time.ParseInLocation(ocspTimeLayout, thisStr, time.Local)
+	if err != nil {
+		return nil, false
+	}
+	nextUpdate, err := time.ParseInLocation(ocspTimeLayout, nextStr, time.Local)
+	if err != nil {
+		return nil, false
+	}
+	if now.After(thisUpdate.Add(nextUpdate.Sub(thisUpdate) / 2)) {
+		return nil, false
+	}
+	raw, err := base64.StdEncoding.DecodeString(respStr)
+	if err != nil {
+		return nil, false
+	}
+	return raw, true
+}
+
+// GetOCSPResponse 获取证书的 OCSP 响应，优先使用缓存，缓存过期时重新查询并保存到证书记录
+func GetOCSPResponse(ctx context.Context, certPEM string, force bool) ([]byte, error) {
+	sha256, err := public.GetSHA256(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	certRow, err := GetCertRow(sha256)
+	if err != nil {
+		// 未保存的证书不缓存
+		raw, _, err := FetchOCSP(ctx, certPEM, "", nil)
+		return raw, err
+	}
+	if !force {
+		if raw, ok := getCachedOCSP(certRow, time.Now()); ok {
+			return raw, nil
+		}
+	}
+	issuerPEM, _ := certRow["issuer_cert"].(string)
+	raw, ocspResp, err := FetchOCSP(ctx, certPEM, issuerPEM, nil)
+	if err != nil {
+		return nil, err
+	}
+	nextUpdate := ocspResp.NextUpdate
+	if nextUpdate.IsZero() {
+		nextUpdate = ocspResp.ThisUpdate.Add(24 * time.Hour)
+	}
+	// 缓存失败不影响本次使用，下次会重新查询
+	_ = UpdateCert(sha256, map[string]any{
+		"ocsp_response":    base64.StdEncoding.EncodeToString(raw),
+		"ocsp_this_update": ocspResp.ThisUpdate.Local().Format(ocspTimeLayout),
+		"ocsp_next_update": nextUpdate.Local().Format(ocspTimeLayout),
+	})
+	return raw, nil
+}
@@ -0,0 +1,113 @@
+package cert
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"golang.org/x/crypto/ocsp"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFetchOCSP(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTpl, caTpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, _ := x509.ParseCertificate(caDER)
+
+	status := ocsp.Good
+	responder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		req, err := ocsp.ParseRequest(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp, err := ocsp.CreateResponse(caCert, caCert, ocsp.Response{
+			Status:       status,
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Minute),
+			NextUpdate:   time.Now().Add(time.Hour),
+			RevokedAt:    time.Now().Add(-time.Minute),
+		}, crypto.Signer(caKey))
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(resp)
+	}))
+	defer responder.Close()
+
+	leafKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		OCSPServer:   []string{responder.URL},
+	}, caCert, &leafKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER}))
+	issuerPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}))
+
+	raw, resp, err := FetchOCSP(context.Background(), certPEM, issuerPEM, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(raw) == 0 || resp.Status != ocsp.Good {
+		t.Fatalf("unexpected OCSP response status: %d", resp.Status)
+	}
+	// 证书链中包含签发者证书时无需单独提供
+	if _, _, err := FetchOCSP(context.Background(), certPEM+issuerPEM, "", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	status = ocsp.Revoked
+	if _, _, err := FetchOCSP(context.Background(), certPEM, issuerPEM, nil); err == nil {
+		t.Fatal("expected revoked certificate to fail")
+	}
+	if _, _, err := FetchOCSP(context.Background(), certPEM, "", nil); err == nil {
+		t.Fatal("expected missing issuer to fail")
+	}
+}
+
+func TestGetCachedOCSP(t *testing.T) {
+	now := time.Now()
+	row := map[string]any{
+		"ocsp_response":    "AQID",
+		"ocsp_this_update": now.Add(-time.Hour).Format(ocspTimeLayout),
+		"ocsp_next_update": now.Add(3 * time.Hour).Format(ocspTimeLayout),
+	}
+	if raw, ok := getCachedOCSP(row, now); !ok || len(raw) != 3 {
+		t.Fatal("expected cached OCSP response to be used")
+	}
+	if _, ok := getCachedOCSP(row, now.Add(2*time.Hour)); ok {
+		t.Fatal("expected OCSP response past half of its validity to be refreshed")
+	}
+}
@@ -0,0 +1,354 @@
+package privateca
+
+import (
+	"ALLinSSL/backend/internal/cert"
+	"ALLinSSL/backend/public"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ProviderName 使用内置私有 CA 签发证书的申请节点 ca 取值
+const ProviderName = "internal-ca"
+
+func GetSqlite() (*public.Sqlite, error) {
+	s, err := public.NewSqlite("data/data.db", "")
+	if err != nil {
+		return nil, err
+	}
+	s.TableName = "private_ca"
+	return s, nil
+}
+
+func randomSerial() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+}
+
+// parseKeyPair 解析 PEM 格式的证书及私钥
+func parseKeyPair(certPEM, keyPEM string) (*x509.Certificate, crypto.Signer, error) {
+	certObj, err := public.ParseCertificate([]byte(certPEM))
+	if err != nil {
+		return nil, nil, fmt.Errorf("解析CA证书失败: %v", err)
+	}
+	key, err := public.ParsePrivateKey([]byte(keyPEM))
+	if err != nil {
+		return nil, nil, fmt.Errorf("解析CA私钥失败: %v", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("不支持的CA私钥类型")
+	}
+	return certObj, signer, nil
+}
+
+// newCAPair 生成根证书及由其签发的中间证书，返回 PEM 格式的证书及私钥
+func newCAPair(commonName, organization string, keyType certcrypto.KeyType, rootDays, intermediateDays int) (rootCert, rootKey, cert, key string, err error) {
+	subject := func(cn string) pkix.Name {
+		name := pkix.Name{CommonName: cn}
+		if organization != "" {
+			name.Organization = []string{organization}
+		}
+		return name
+	}
+	now := time.Now()
+
+	rootPriv, err := certcrypto.GeneratePrivateKey(keyType)
+	if err != nil {
+		return
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return
+	}
+	rootTpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               subject(commonName + " Root CA"),
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              now.AddDate(0, 0, rootDays),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLen:            1,
+	}
+	rootSigner := rootPriv.(crypto.Signer)
+	rootDER, err := x509.CreateCertificate(rand.Reader, rootTpl, rootTpl, rootSigner.Public(), rootSigner)
+	if err != nil {
+		return
+	}
+	rootObj, err := x509.ParseCertificate(rootDER)
+	if err != nil {
+		return
+	}
+
+	intPriv, err := certcrypto.GeneratePrivateKey(keyType)
+	if err != nil {
+		return
+	}
+	serial, err = randomSerial()
+	if err != nil {
+		return
+	}
+	notAfter := now.AddDate(0, 0, intermediateDays)
+	if notAfter.After(rootObj.NotAfter) {
+		notAfter = rootObj.NotAfter
+	}
+	intTpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               subject(commonName + " Intermediate CA"),
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	intSigner := intPriv.(crypto.Signer)
+	intDER, err := x509.CreateCertificate(rand.Reader, intTpl, rootObj, intSigner.Public(), rootSigner)
+	if err != nil {
+		return
+	}
+
+	rootCert = string(certcrypto.PEMEncode(certcrypto.DERCertificateBytes(rootDER)))
+	rootKey = string(certcrypto.PEMEncode(rootPriv))
+	cert = string(certcrypto.PEMEncode(certcrypto.DERCertificateBytes(intDER)))
+	key = string(certcrypto.PEMEncode(intPriv))
+	return
+}
+
+func checkCRLBaseURL(crlBaseURL string) error {
+	if crlBaseURL == "" {
+		return nil
+	}
+	u, err := url.Parse(crlBaseURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("CRL发布地址格式错误")
+	}
+	return nil
+}
+
+// AddCA 创建私有 CA，生成根证书及中间证书，私钥加密后保存
+//
+// crlBaseURL 为客户端可访问的本服务地址（如 https://pki.example.internal:7979），
+// 不为空时签发的证书中会包含 CRL 分发点 <crlBaseURL>/crl/<id>.crl
+func AddCA(name, commonName, organization string, keyType certcrypto.KeyType, rootDays, intermediateDays int, crlBaseURL string) error {
+	name, commonName = strings.TrimSpace(name), strings.TrimSpace(commonName)
+	crlBaseURL = strings.TrimRight(strings.TrimSpace(crlBaseURL), "/")
+	if name == "" {
+		return fmt.Errorf("CA名称不能为空")
+	}
+	if commonName == "" {
+		commonName = name
+	}
+	if rootDays <= 0 {
+		rootDays = 3650
+	}
+	if intermediateDays <= 0 {
+		intermediateDays = 1825
+	}
+	if err := checkCRLBaseURL(crlBaseURL); err != nil {
+		return err
+	}
+	db, err := GetSqlite()
+	if err != nil {
+		return fmt.Errorf("failed to get sqlite: %w", err)
+	}
+	defer db.Close()
+	data, err := db.Where("name=?", []any{name}).Select()
+	if err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		return fmt.Errorf("CA【%s】已存在", name)
+	}
+
+	rootCert, rootKey, cert, key, err := newCAPair(commonName, strings.TrimSpace(organization), keyType, rootDays, intermediateDays)
+	if err != nil {
+		return fmt.Errorf("生成CA证书失败: %v", err)
+	}
+	rootKey, err = encrypt(rootKey)
+	if err != nil {
+		return err
+	}
+	key, err = encrypt(key)
+	if err != nil {
+		return err
+	}
+	now := time.Now().Format("2006-01-02 15:04:05")
+	id, err := db.Insert(map[string]any{
+		"name":         name,
+		"root_cert":    rootCert,
+		"root_key":     rootKey,
+		"cert":         cert,
+		"key":          key,
+		"crl_base_url": crlBaseURL,
+		"create_time":  now,
+		"update_time":  now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert private ca: %w", err)
+	}
+	// 创建后立即发布一份空的 CRL
+	return PublishCRL(fmt.Sprintf("%d", id))
+}
+
+// UpdateCA 修改 CA 名称及 CRL 发布地址，已签发证书中的 CRL 分发点不会改变
+func UpdateCA(id, name, crlBaseURL string) error {
+	name = strings.TrimSpace(name)
+	crlBaseURL = strings.TrimRight(strings.TrimSpace(crlBaseURL), "/")
+	if name == "" {
+		return fmt.Errorf("CA名称不能为空")
+	}
+	if err := checkCRLBaseURL(crlBaseURL); err != nil {
+		return err
+	}
+	db, err := GetSqlite()
+	if err != nil {
+		return fmt.Errorf("failed to get sqlite: %w", err)
+	}
+	defer db.Close()
+	data, err := db.Where("name=? and id!=?", []any{name, id}).Select()
+	if err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		return fmt.Errorf("CA【%s】已存在", name)
+	}
+	_, err = db.Where("id=?", []any{id}).Update(map[string]any{
+		"name":         name,
+		"crl_base_url": crlBaseURL,
+		"update_time":  time.Now().Format("2006-01-02 15:04:05"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update private ca: %w", err)
+	}
+	return nil
+}
+
+// DelCA 删除私有 CA，仍有未过期的证书由该 CA 签发时拒绝删除，
+// 否则这些证书将无法吊销，CRL 分发点也会失效
+func DelCA(id string) error {
+	s, err := cert.GetSqlite()
+	if err != nil {
+		return fmt.Errorf("failed to get sqlite: %w", err)
+	}
+	count, err := s.Where("private_ca_id=? and end_time>?", []any{id, time.Now().Format(timeLayout)}).Count()
+	s.Close()
+	if err != nil {
+		return fmt.Errorf("failed to count issued certs: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("该CA签发的 %d 张证书尚未过期，需等待证书全部过期后才能删除CA", count)
+	}
+
+	db, err := GetSqlite()
+	if err != nil {
+		return fmt.Errorf("failed to get sqlite: %w", err)
+	}
+	defer db.Close()
+	_, err = db.Where("id=?", []any{id}).Delete()
+	if err != nil {
+		return fmt.Errorf("failed to delete private ca: %w", err)
+	}
+	return nil
+}
+
+// GetCAList 获取私有 CA 列表，不返回私钥
+func GetCAList(search string, p, limit int64) ([]map[string]any, int, error) {
+	db, err := GetSqlite()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get sqlite: %w", err)
+	}
+	defer db.Close()
+	whereSql := "1=1"
+	var whereArgs []any
+	limits := []int64{0, 100}
+	if p >= 0 && limit >= 0 {
+		limits = []int64{0, limit}
+		if p > 1 {
+			limits[0] = (p - 1) * limit
+			limits[1] = limit
+		}
+	}
+	if search != "" {
+		whereSql += " and name like ?"
+		whereArgs = append(whereArgs, "%"+search+"%")
+	}
+	count, err := db.Where(whereSql, whereArgs).Count()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get private ca list: %w", err)
+	}
+	data, err := db.Field([]string{"id", "name", "root_cert", "cert", "crl_base_url", "crl_number", "crl_next_update", "create_time", "update_time"}).Where(whereSql, whereArgs).Limit(limits).Select()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get private ca list: %w", err)
+	}
+	for i := range data {
+		if rootCert, ok := data[i]["root_cert"].(string); ok {
+			if certObj, err := public.ParseCertificate([]byte(rootCert)); err == nil {
+				data[i]["end_time"] = certObj.NotAfter.Format("2006-01-02 15:04:05")
+			}
+		}
+		if id, ok := data[i]["id"].(int64); ok {
+			if base, _ := data[i]["crl_base_url"].(string); base != "" {
+				data[i]["crl_url"] = crlURL(base, fmt.Sprintf("%d", id))
+			}
+		}
+	}
+	return data, int(count), nil
+}
+
+// getCA 获取 CA 及解密后的中间证书私钥
+func getCA(id string) (map[string]any, *x509.Certificate, crypto.Signer, error) {
+	db, err := GetSqlite()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer db.Close()
+	data, err := db.Where("id=?", []any{id}).Select()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil, nil, fmt.Errorf("私有CA不存在")
+	}
+	certPEM, _ := data[0]["cert"].(string)
+	encKey, _ := data[0]["key"].(string)
+	keyPEM, err := decrypt(encKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	certObj, signer, err := parseKeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return data[0], certObj, signer, nil
+}
+
+// GetRootCert 获取 CA 的根证书，用于分发到客户端的信任库
+func GetRootCert(id string) (string, string, error) {
+	db, err := GetSqlite()
+	if err != nil {
+		return "", "", err
+	}
+	defer db.Close()
+	data, err := db.Field([]string{"name", "root_cert"}).Where("id=?", []any{id}).Select()
+	if err != nil {
+		return "", "", err
+	}
+	if len(data) == 0 {
+		return "", "", fmt.Errorf("私有CA不存在")
+	}
+	name, _ := data[0]["name"].(string)
+	rootCert, _ := data[0]["root_cert"].(string)
+	return name, rootCert, nil
+}
+
+func crlURL(base, id string) string {
+	return fmt.Sprintf("%s/crl/%s.crl", base, id)
+}
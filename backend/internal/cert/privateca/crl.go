@@ -0,0 +1,128 @@
+package privateca
+
+import (
+	"ALLinSSL/backend/internal/cert"
+	"ALLinSSL/backend/public"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+const (
+	timeLayout = "2006-01-02 15:04:05"
+	// crlValidity CRL 的有效期，超过一半时重新签发
+	crlValidity = 7 * 24 * time.Hour
+)
+
+// createCRL 由中间证书签发 CRL，返回 DER 格式
+func createCRL(issuer *x509.Certificate, issuerKey crypto.Signer, entries []x509.RevocationListEntry, number int64, now time.Time) ([]byte, error) {
+	return x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(number),
+		ThisUpdate:                now,
+		NextUpdate:                now.Add(crlValidity),
+		RevokedCertificateEntries: entries,
+	}, issuer, issuerKey)
+}
+
+// revokedEntries 获取 CA 签发且已吊销的证书
+func revokedEntries(id string) ([]x509.RevocationListEntry, error) {
+	s, err := cert.GetSqlite()
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	rows, err := s.Where("private_ca_id=? and revoked=1", []any{id}).Select()
+	if err != nil {
+		return nil, err
+	}
+	var entries []x509.RevocationListEntry
+	for _, row := range rows {
+		certStr, _ := row["cert"].(string)
+		certObj, err := public.ParseCertificate([]byte(certStr))
+		if err != nil {
+			continue
+		}
+		entry := x509.RevocationListEntry{SerialNumber: certObj.SerialNumber, RevocationTime: time.Now()}
+		if revokeTime, _ := row["revoke_time"].(string); revokeTime != "" {
+			if t, err := time.ParseInLocation(timeLayout, revokeTime, time.Local); err == nil {
+				entry.RevocationTime = t
+			}
+		}
+		if reason, _ := row["revoke_reason"].(string); reason != "" {
+			entry.ReasonCode, _ = strconv.Atoi(reason)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// PublishCRL 重新签发并保存 CA 的 CRL，吊销证书后调用
+func PublishCRL(id string) error {
+	_, err := publishCRL(id)
+	return err
+}
+
+func publishCRL(id string) ([]byte, error) {
+	caData, issuer, issuerKey, err := getCA(id)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := revokedEntries(id)
+	if err != nil {
+		return nil, err
+	}
+	number, _ := caData["crl_number"].(int64)
+	number++
+	now := time.Now()
+	der, err := createCRL(issuer, issuerKey, entries, number, now)
+	if err != nil {
+		return nil, fmt.Errorf("签发CRL失败: %v", err)
+	}
+	db, err := GetSqlite()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	_, err = db.Where("id=?", []any{id}).Update(map[string]any{
+		"crl":             string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})),
+		"crl_number":      number,
+		"crl_next_update": now.Add(crlValidity).Format(timeLayout),
+		"update_time":     now.Format(timeLayout),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("保存CRL失败: %v", err)
+	}
+	return der, nil
+}
+
+// GetCRL 获取 DER 格式的 CRL，超过有效期一半时重新签发
+func GetCRL(id string) ([]byte, error) {
+	db, err := GetSqlite()
+	if err != nil {
+		return nil, err
+	}
+	data, err := db.Field([]string{"crl", "crl_next_update"}).Where("id=?", []any{id}).Select()
+	db.Close()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("私有CA不存在")
+	}
+	crlPEM, _ := data[0]["crl"].(string)
+	nextStr, _ := data[0]["crl_next_update"].(string)
+	if crlPEM != "" && nextStr != "" {
+		nextUpdate, err := time.ParseInLocation(timeLayout, nextStr, time.Local)
+		if err == nil && time.Now().Before(nextUpdate.Add(-crlValidity/2)) {
+			if block, _ := pem.Decode([]byte(crlPEM)); block != nil {
+				return block.Bytes, nil
+			}
+		}
+	}
+	return publishCRL(id)
+}
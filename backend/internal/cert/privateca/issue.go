@@ -0,0 +1,97 @@
+package privateca
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"net"
+	"strings"
+	"time"
+)
+
+// signLeaf 使用中间证书签发服务器证书，names 可包含域名（含通配符）及 IP 地址，
+// 有效期不超过中间证书的有效期
+func signLeaf(issuer *x509.Certificate, issuerKey crypto.Signer, names []string, keyType certcrypto.KeyType, days int, crlURL string) (certPEM, keyPEM string, err error) {
+	if len(names) == 0 {
+		return "", "", fmt.Errorf("域名不能为空")
+	}
+	if days <= 0 {
+		return "", "", fmt.Errorf("证书有效期必须大于0天")
+	}
+	now := time.Now()
+	if now.After(issuer.NotAfter) {
+		return "", "", fmt.Errorf("私有CA中间证书已过期")
+	}
+	priv, err := certcrypto.GeneratePrivateKey(keyType)
+	if err != nil {
+		return "", "", err
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return "", "", err
+	}
+	notAfter := now.AddDate(0, 0, days)
+	if notAfter.After(issuer.NotAfter) {
+		notAfter = issuer.NotAfter
+	}
+	tpl := &x509.Certificate{
+		SerialNumber:          serial,
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+	if _, ok := priv.(*rsa.PrivateKey); ok {
+		tpl.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if ip := net.ParseIP(name); ip != nil {
+			tpl.IPAddresses = append(tpl.IPAddresses, ip)
+		} else {
+			tpl.DNSNames = append(tpl.DNSNames, name)
+		}
+		// 通用名最长 64 个字符
+		if tpl.Subject.CommonName == "" && len(name) <= 64 {
+			tpl.Subject = pkix.Name{CommonName: name}
+		}
+	}
+	if crlURL != "" {
+		tpl.CRLDistributionPoints = []string{crlURL}
+	}
+	signer := priv.(crypto.Signer)
+	der, err := x509.CreateCertificate(rand.Reader, tpl, issuer, signer.Public(), issuerKey)
+	if err != nil {
+		return "", "", err
+	}
+	certPEM = string(certcrypto.PEMEncode(certcrypto.DERCertificateBytes(der)))
+	keyPEM = string(certcrypto.PEMEncode(priv))
+	return certPEM, keyPEM, nil
+}
+
+// Issue 使用私有 CA 签发证书，返回的 cert 为包含中间证书的完整链，issuerCert 为中间证书，
+// 与 ACME 申请的证书格式一致
+func Issue(id string, names []string, keyType certcrypto.KeyType, days int) (cert, key, issuerCert string, err error) {
+	caData, issuer, issuerKey, err := getCA(id)
+	if err != nil {
+		return "", "", "", err
+	}
+	var url string
+	if base, _ := caData["crl_base_url"].(string); base != "" {
+		url = crlURL(base, id)
+	}
+	leaf, key, err := signLeaf(issuer, issuerKey, names, keyType, days, url)
+	if err != nil {
+		return "", "", "", fmt.Errorf("签发证书失败: %v", err)
+	}
+	issuerCert, _ = caData["cert"].(string)
+	return leaf + issuerCert, key, issuerCert, nil
+}
@@ -0,0 +1,96 @@
+package privateca
+
+import (
+	"ALLinSSL/backend/public"
+	"crypto/x509"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	secretFile = filepath.Join(t.TempDir(), "private_ca.key")
+	enc, err := encrypt("secret key material")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(secretFile); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("expected secret file with mode 0600: %v", err)
+	}
+	plain, err := decrypt(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain != "secret key material" {
+		t.Fatalf("unexpected plain text: %s", plain)
+	}
+
+	// 更换密钥文件后无法解密
+	secretFile = filepath.Join(t.TempDir(), "private_ca.key")
+	if _, err = decrypt(enc); err == nil {
+		t.Fatal("expected decryption with another secret to fail")
+	}
+}
+
+func TestIssueChainAndCRL(t *testing.T) {
+	rootCert, _, intCert, intKey, err := newCAPair("Example", "Example Corp", certcrypto.EC256, 3650, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, issuerKey, err := parseKeyPair(intCert, intKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafPEM, keyPEM, err := signLeaf(issuer, issuerKey, []string{"svc.internal", "*.svc.internal", "10.0.0.1"}, certcrypto.RSA2048, 365, "https://pki.example.internal/crl/1.crl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = public.ValidateSSLCertificate(leafPEM, keyPEM); err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := public.ParseCertificate([]byte(leafPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !leaf.IPAddresses[0].Equal(net.ParseIP("10.0.0.1")) || len(leaf.DNSNames) != 2 {
+		t.Fatalf("unexpected names: %v %v", leaf.DNSNames, leaf.IPAddresses)
+	}
+	// 有效期不超过中间证书
+	if leaf.NotAfter.After(issuer.NotAfter) {
+		t.Fatal("leaf must not outlive the intermediate")
+	}
+	if len(leaf.CRLDistributionPoints) != 1 {
+		t.Fatal("expected CRL distribution point")
+	}
+
+	root, _ := public.ParseCertificate([]byte(rootCert))
+	roots, intermediates := x509.NewCertPool(), x509.NewCertPool()
+	roots.AddCert(root)
+	intermediates.AddCert(issuer)
+	for _, name := range []string{"svc.internal", "a.svc.internal", "10.0.0.1"} {
+		if _, err = leaf.Verify(x509.VerifyOptions{DNSName: name, Roots: roots, Intermediates: intermediates}); err != nil {
+			t.Fatalf("verify %s: %v", name, err)
+		}
+	}
+
+	der, err := createCRL(issuer, issuerKey, []x509.RevocationListEntry{
+		{SerialNumber: leaf.SerialNumber, RevocationTime: time.Now(), ReasonCode: 1},
+	}, 2, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	crl, err := x509.ParseRevocationList(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = crl.CheckSignatureFrom(issuer); err != nil {
+		t.Fatal(err)
+	}
+	if crl.Number.Cmp(big.NewInt(2)) != 0 || len(crl.RevokedCertificateEntries) != 1 || crl.RevokedCertificateEntries[0].SerialNumber.Cmp(leaf.SerialNumber) != 0 {
+		t.Fatal("unexpected CRL content")
+	}
+}
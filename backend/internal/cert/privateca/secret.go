@@ -0,0 +1,87 @@
+package privateca
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// secretFile 加密 CA 私钥的密钥文件，与数据库分开保存，备份数据库时不会泄露私钥
+var secretFile = "data/private_ca.key"
+
+var secretMu sync.Mutex
+
+// getSecret 读取加密密钥，首次使用时生成
+func getSecret() ([]byte, error) {
+	secretMu.Lock()
+	defer secretMu.Unlock()
+	secret, err := os.ReadFile(secretFile)
+	if err == nil {
+		if len(secret) != 32 {
+			return nil, fmt.Errorf("私有CA密钥文件 %s 已损坏", secretFile)
+		}
+		return secret, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	secret = make([]byte, 32)
+	if _, err = io.ReadFull(rand.Reader, secret); err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(filepath.Dir(secretFile), 0700); err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(secretFile, secret, 0600); err != nil {
+		return nil, fmt.Errorf("保存私有CA密钥文件失败: %v", err)
+	}
+	return secret, nil
+}
+
+func newGCM() (cipher.AEAD, error) {
+	secret, err := getSecret()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt 使用 AES-256-GCM 加密私钥，返回 base64(nonce + 密文)
+func encrypt(plain string) (string, error) {
+	gcm, err := newGCM()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(plain), nil)), nil
+}
+
+func decrypt(encoded string) (string, error) {
+	gcm, err := newGCM()
+	if err != nil {
+		return "", err
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(raw) < gcm.NonceSize() {
+		return "", fmt.Errorf("CA私钥格式错误")
+	}
+	plain, err := gcm.Open(nil, raw[:gcm.NonceSize()], raw[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("CA私钥解密失败，请检查密钥文件 %s 是否与数据库匹配", secretFile)
+	}
+	return string(plain), nil
+}
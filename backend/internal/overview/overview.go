@@ -15,7 +15,7 @@ func GetWorkflowCount() (map[string]any, error) {
 	defer s.Close()
 	workflow, err := s.Query(`select count(*) as count,
        count(case when exec_type='auto' then 1 end ) as active,
-       count(case when last_run_status='fail' then 1 end ) as failure
+       count(case when last_run_status in ('fail', 'timeout') then 1 end ) as failure
        from workflow
 `)
 	if err != nil {
@@ -44,6 +44,7 @@ func GetCertCount() (map[string]int, error) {
 		"count": len(data),
 		"will":  0,
 		"end":   0,
+		"due":   0,
 	}
 	for _, v := range data {
 		endTimeStr, ok := v["end_time"].(string)
@@ -60,6 +61,13 @@ func GetCertCount() (map[string]int, error) {
 			if endTime.Sub(time.Now()).Hours() < 24*30 {
 				result["will"]++
 			}
+			// 已进入 CA 通过 ARI 建议的续期窗口
+			if ariStartStr, ok := v["ari_start"].(string); ok && ariStartStr != "" {
+				ariStart, err := time.ParseInLocation("2006-01-02 15:04:05", ariStartStr, time.Local)
+				if err == nil && ariStart.Before(time.Now()) {
+					result["due"]++
+				}
+			}
 		}
 	}
 	return result, nil
@@ -108,6 +116,12 @@ func GetWorkflowHistory() ([]map[string]any, error) {
 			state = -1
 		case "running":
 			state = 0
+		case "cancelled":
+			state = -2
+		case "timeout":
+			state = -3
+		case "interrupted":
+			state = -4
 		}
 		switch v["exec_type"] {
 		case "manual":
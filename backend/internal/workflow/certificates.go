@@ -0,0 +1,194 @@
+package workflow
+
+import (
+	certDeploy "ALLinSSL/backend/internal/cert/deploy"
+	"ALLinSSL/backend/public"
+	"fmt"
+	"strings"
+)
+
+// selectCertificates 按部署节点的 cert_algorithm 从上个节点的输出中选择要部署的证书
+//
+//	为空时部署主证书；为 all 时部署全部证书（仅 ssh、localhost 目标，路径需用占位符区分，见 checkMultiDeploy，
+//	宝塔面板接口只能保存一张证书，不支持）；
+//	其他值按算法匹配，支持完整名称（EC256）或类型前缀（ec、rsa）。
+//	上个节点拆分签发了多张证书时，按 certDomain（站点域名，多个以逗号分隔）选择包含该域名的证书。
+func selectCertificates(certificateMap map[string]any, certAlgorithm, certDomain string) ([]map[string]any, error) {
+	certAlgorithm = strings.ToUpper(strings.TrimSpace(certAlgorithm))
+	if _, ok := certificateMap["cert_group"]; ok {
+		return selectSplitCertificates(certificateMap, certAlgorithm, certDomain)
+	}
+	if certAlgorithm == "" {
+		return []map[string]any{certificateMap}, nil
+	}
+
+	certs := listCertificates(certificateMap)
+	if len(certs) == 0 {
+		// 上个节点只有一张证书
+		certStr, _ := certificateMap["cert"].(string)
+		certs = []map[string]any{certificateMap}
+		if certAlgorithm != "ALL" && !strings.HasPrefix(public.GetCertKeyAlgorithm(certStr), certAlgorithm) {
+			return nil, fmt.Errorf("上个节点未输出%s算法的证书", certAlgorithm)
+		}
+		return certs, nil
+	}
+	if certAlgorithm == "ALL" {
+		return certs, nil
+	}
+	for _, c := range certs {
+		if algorithm, _ := c["algorithm"].(string); strings.HasPrefix(strings.ToUpper(algorithm), certAlgorithm) {
+			return []map[string]any{c}, nil
+		}
+	}
+	return nil, fmt.Errorf("上个节点未输出%s算法的证书", certAlgorithm)
+}
+
+// pathDeployProviders 按路径写入证书文件的部署目标，路径可通过占位符区分多张证书
+var pathDeployProviders = map[string]bool{"ssh": true, "localhost": true}
+
+// btPanelProviders 宝塔面板及宝塔WAF部署目标。宝塔的接口每个站点只保存一张证书，后设置的会替换前一张，
+// 暂不支持通过面板接口部署双证书；需要 ECC+RSA 双证书时用 ssh、localhost 写入站点 nginx 配置引用的证书路径
+var btPanelProviders = map[string]bool{
+	"btpanel":            true,
+	"btpanel-site":       true,
+	"btpanel-dockersite": true,
+	"btpanel-singlesite": true,
+	"btwaf-site":         true,
+}
+
+// checkMultiDeploy 同一部署节点需要部署多张证书时，检查目标能否区分它们，避免后部署的证书覆盖前一张。
+// 面板和云厂商目标每次只能设置一张证书，直接拒绝；ssh、localhost 要求各证书展开后的证书、私钥及 OCSP 路径互不相同。
+func checkMultiDeploy(params map[string]any, certs []map[string]any) error {
+	if len(certs) < 2 {
+		return nil
+	}
+	provider, _ := params["provider"].(string)
+	if btPanelProviders[provider] {
+		return fmt.Errorf("宝塔面板、宝塔WAF接口每个站点只能设置一张证书，当前选中了 %d 张；如需 ECC+RSA 双证书，请改用 ssh 或 localhost 部署到站点配置引用的证书路径（路径中使用 {algorithm} 占位符）", len(certs))
+	}
+	if !pathDeployProviders[provider] {
+		return fmt.Errorf("部署目标 %s 每次只能部署一张证书，当前选中了 %d 张，请将 cert_algorithm 设为单一算法，或通过 cert_domain 只选择一个站点", provider, len(certs))
+	}
+	for _, key := range []string{"certPath", "keyPath", "ocspPath"} {
+		path, _ := params[key].(string)
+		if path == "" && key == "ocspPath" {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, c := range certs {
+			expanded := certDeploy.ExpandCertPath(path, c)
+			if seen[expanded] {
+				return fmt.Errorf("部署 %d 张证书时 %s 需包含 {algorithm} 或 {domain} 占位符，否则后部署的证书会覆盖前一张", len(certs), key)
+			}
+			seen[expanded] = true
+		}
+	}
+	return nil
+}
+
+func listCertificates(certificateMap map[string]any) []map[string]any {
+	var certs []map[string]any
+	if list, ok := certificateMap["certs"].([]any); ok {
+		for _, item := range list {
+			if c, ok := item.(map[string]any); ok {
+				certs = append(certs, c)
+			}
+		}
+	}
+	return certs
+}
+
+// domainMatch 判断证书域名是否覆盖站点域名，通配符只匹配一级子域名
+func domainMatch(pattern, domain string) bool {
+	pattern, domain = strings.ToLower(strings.TrimSpace(pattern)), strings.ToLower(strings.TrimSpace(domain))
+	if pattern == domain {
+		return true
+	}
+	if strings.HasPrefix(pattern, "*.") {
+		if i := strings.Index(domain, "."); i > 0 {
+			return domain[i+1:] == pattern[2:]
+		}
+	}
+	return false
+}
+
+// selectSplitCertificates 从拆分签发的证书中选择包含站点域名的证书，再按算法筛选
+func selectSplitCertificates(certificateMap map[string]any, certAlgorithm, certDomain string) ([]map[string]any, error) {
+	certs := listCertificates(certificateMap)
+	if strings.TrimSpace(certDomain) != "" {
+		// 每个站点域名使用第一组包含它的证书
+		var groups []string
+		for _, site := range strings.Split(certDomain, ",") {
+			if site = strings.TrimSpace(site); site == "" {
+				continue
+			}
+			found := false
+			for _, c := range certs {
+				domains, _ := c["domains"].(string)
+				for _, d := range strings.Split(domains, ",") {
+					if domainMatch(d, site) {
+						found = true
+						break
+					}
+				}
+				if found {
+					if !containsString(groups, domains) {
+						groups = append(groups, domains)
+					}
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("上个节点未输出包含域名 %s 的证书", site)
+			}
+		}
+		var matched []map[string]any
+		for _, c := range certs {
+			if domains, _ := c["domains"].(string); containsString(groups, domains) {
+				matched = append(matched, c)
+			}
+		}
+		certs = matched
+	} else if certAlgorithm != "ALL" {
+		return nil, fmt.Errorf("上个节点拆分签发了多张证书，请设置 cert_domain 指定站点域名，或将 cert_algorithm 设为 all")
+	}
+
+	var result []map[string]any
+	var seen []string
+	for _, c := range certs {
+		domains, _ := c["domains"].(string)
+		switch certAlgorithm {
+		case "ALL":
+			result = append(result, c)
+		case "":
+			// 每组只部署主证书（第一个算法）
+			if !containsString(seen, domains) {
+				seen = append(seen, domains)
+				result = append(result, c)
+			}
+		default:
+			algorithm, _ := c["algorithm"].(string)
+			if algorithm == "" {
+				certStr, _ := c["cert"].(string)
+				algorithm = public.GetCertKeyAlgorithm(certStr)
+			}
+			if strings.HasPrefix(strings.ToUpper(algorithm), certAlgorithm) && !containsString(seen, domains) {
+				seen = append(seen, domains)
+				result = append(result, c)
+			}
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("上个节点未输出%s算法的证书", certAlgorithm)
+	}
+	return result, nil
+}
+
+func containsString(slice []string, target string) bool {
+	for _, v := range slice {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
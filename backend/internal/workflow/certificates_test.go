@@ -0,0 +1,184 @@
+package workflow
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testECCert(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestDomainMatch(t *testing.T) {
+	cases := []struct {
+		pattern, domain string
+		want            bool
+	}{
+		{"example.com", "example.com", true},
+		{"Example.com ", "example.COM", true},
+		{"*.example.com", "www.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"*.example.com", "a.b.example.com", false},
+		{"www.example.com", "example.com", false},
+	}
+	for _, c := range cases {
+		if got := domainMatch(c.pattern, c.domain); got != c.want {
+			t.Errorf("domainMatch(%q, %q) = %v, want %v", c.pattern, c.domain, got, c.want)
+		}
+	}
+}
+
+func TestSelectCertificates(t *testing.T) {
+	ec := map[string]any{"cert": "ec-cert", "algorithm": "EC256"}
+	rsa := map[string]any{"cert": "rsa-cert", "algorithm": "RSA2048"}
+	output := map[string]any{"cert": "ec-cert", "certs": []any{ec, rsa}}
+
+	certs, err := selectCertificates(output, "", "")
+	if err != nil || len(certs) != 1 || certs[0]["cert"] != "ec-cert" {
+		t.Fatalf("expected primary cert: %v %v", certs, err)
+	}
+	certs, err = selectCertificates(output, "rsa", "")
+	if err != nil || len(certs) != 1 || certs[0]["cert"] != "rsa-cert" {
+		t.Fatalf("expected rsa cert: %v %v", certs, err)
+	}
+	certs, err = selectCertificates(output, "all", "")
+	if err != nil || len(certs) != 2 {
+		t.Fatalf("expected both certs: %v %v", certs, err)
+	}
+	if _, err = selectCertificates(output, "EC384", ""); err == nil {
+		t.Fatal("expected missing algorithm to fail")
+	}
+
+	// 上个节点只输出了一张证书时按证书公钥判断算法
+	single := map[string]any{"cert": testECCert(t)}
+	if certs, err = selectCertificates(single, "ec", ""); err != nil || len(certs) != 1 {
+		t.Fatalf("expected single ec cert: %v %v", certs, err)
+	}
+	if _, err = selectCertificates(single, "rsa", ""); err == nil {
+		t.Fatal("expected rsa selection on ec cert to fail")
+	}
+}
+
+func TestSelectSplitCertificates(t *testing.T) {
+	a := map[string]any{"cert": "a-ec", "algorithm": "EC256", "domains": "a.com,*.a.com"}
+	aRSA := map[string]any{"cert": "a-rsa", "algorithm": "RSA2048", "domains": "a.com,*.a.com"}
+	b := map[string]any{"cert": "b-ec", "algorithm": "EC256", "domains": "b.com"}
+	output := map[string]any{"cert_group": "g1", "certs": []any{a, aRSA, b}}
+
+	certs, err := selectCertificates(output, "", "www.a.com")
+	if err != nil || len(certs) != 1 || certs[0]["cert"] != "a-ec" {
+		t.Fatalf("expected primary cert of group a: %v %v", certs, err)
+	}
+	certs, err = selectCertificates(output, "rsa", "a.com,b.com")
+	if err != nil || len(certs) != 1 || certs[0]["cert"] != "a-rsa" {
+		t.Fatalf("expected rsa cert of group a: %v %v", certs, err)
+	}
+	certs, err = selectCertificates(output, "", "a.com, b.com")
+	if err != nil || len(certs) != 2 || certs[0]["cert"] != "a-ec" || certs[1]["cert"] != "b-ec" {
+		t.Fatalf("expected one cert per group: %v %v", certs, err)
+	}
+	certs, err = selectCertificates(output, "all", "")
+	if err != nil || len(certs) != 3 {
+		t.Fatalf("expected all certs: %v %v", certs, err)
+	}
+	if _, err = selectCertificates(output, "", ""); err == nil {
+		t.Fatal("expected split output without cert_domain to fail")
+	}
+	if _, err = selectCertificates(output, "", "c.com"); err == nil {
+		t.Fatal("expected unmatched domain to fail")
+	}
+}
+
+func TestCheckMultiDeploy(t *testing.T) {
+	ec := map[string]any{"cert": "ec-cert", "algorithm": "EC256"}
+	rsa := map[string]any{"cert": "rsa-cert", "algorithm": "RSA2048"}
+	certs := []map[string]any{ec, rsa}
+
+	if err := checkMultiDeploy(map[string]any{"provider": "btpanel"}, certs[:1]); err != nil {
+		t.Fatalf("expected single cert to pass: %v", err)
+	}
+	params := map[string]any{"provider": "localhost", "certPath": "/etc/ssl/site.pem", "keyPath": "/etc/ssl/site.key"}
+	if err := checkMultiDeploy(params, certs); err == nil {
+		t.Fatal("expected fixed paths to fail")
+	}
+	params["certPath"] = "/etc/ssl/site-{algorithm}.pem"
+	if err := checkMultiDeploy(params, certs); err == nil {
+		t.Fatal("expected fixed key path to fail")
+	}
+	params["keyPath"] = "/etc/ssl/site-{algorithm}.key"
+	params["provider"] = "ssh"
+	if err := checkMultiDeploy(params, certs); err != nil {
+		t.Fatalf("expected placeholder paths to pass: %v", err)
+	}
+	// 所有证书的 OCSP 响应写入同一文件时，stapling 文件会与证书不匹配
+	params["ocspPath"] = "/etc/ssl/site.ocsp"
+	if err := checkMultiDeploy(params, certs); err == nil {
+		t.Fatal("expected fixed ocsp path to fail")
+	}
+	params["ocspPath"] = "/etc/ssl/site-{algorithm}.ocsp"
+	if err := checkMultiDeploy(params, certs); err != nil {
+		t.Fatalf("expected placeholder ocsp path to pass: %v", err)
+	}
+}
+
+func TestCheckMultiDeploySplit(t *testing.T) {
+	a := map[string]any{"cert": "a-ec", "algorithm": "EC256", "domains": "*.a.com,a.com"}
+	b := map[string]any{"cert": "b-ec", "algorithm": "EC256", "domains": "b.com"}
+	certs := []map[string]any{a, b}
+
+	if err := checkMultiDeploy(map[string]any{"provider": "1panel"}, certs); err == nil {
+		t.Fatal("expected panel target with two split certs to fail")
+	}
+	params := map[string]any{"provider": "localhost", "certPath": "/etc/ssl/{algorithm}.pem", "keyPath": "/etc/ssl/{algorithm}.key"}
+	if err := checkMultiDeploy(params, certs); err == nil {
+		t.Fatal("expected algorithm-only paths to fail for split certs")
+	}
+	params["certPath"] = "/etc/ssl/{domain}.pem"
+	params["keyPath"] = "/etc/ssl/{domain}.key"
+	if err := checkMultiDeploy(params, certs); err != nil {
+		t.Fatalf("expected domain paths to pass: %v", err)
+	}
+}
+
+func TestCheckMultiDeployBtPanel(t *testing.T) {
+	ec := map[string]any{"cert": "ec-cert", "algorithm": "EC256"}
+	rsa := map[string]any{"cert": "rsa-cert", "algorithm": "RSA2048"}
+	certs := []map[string]any{ec, rsa}
+
+	// cert_algorithm 为 all 时宝塔面板会用后一张证书覆盖前一张，明确拒绝并提示改用 ssh、localhost
+	for _, provider := range []string{"btpanel", "btpanel-site", "btpanel-dockersite", "btpanel-singlesite", "btwaf-site"} {
+		params := map[string]any{"provider": provider, "siteName": "example.com"}
+		err := checkMultiDeploy(params, certs)
+		if err == nil || !strings.Contains(err.Error(), "宝塔") {
+			t.Fatalf("expected %s with two certs to be rejected, got %v", provider, err)
+		}
+		if err := checkMultiDeploy(params, certs[1:]); err != nil {
+			t.Fatalf("expected %s with single cert to pass: %v", provider, err)
+		}
+	}
+	if err := checkMultiDeploy(map[string]any{"provider": "aliyun-cdn"}, certs); err == nil {
+		t.Fatal("expected cloud target with two certs to fail")
+	}
+}
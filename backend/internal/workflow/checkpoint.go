@@ -0,0 +1,151 @@
+package workflow
+
+import (
+	"ALLinSSL/backend/public"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// checkpoint 执行记录中已成功节点的输出，恢复执行时这些节点不再执行
+type checkpoint struct {
+	RunID   string
+	Outputs map[string]any
+}
+
+// GetSqliteObjNO 节点输出表对象，按执行记录保存每个节点的输出及状态
+func GetSqliteObjNO() (*public.Sqlite, error) {
+	s, err := public.NewSqlite("data/data.db", "")
+	if err != nil {
+		return nil, err
+	}
+	s.TableName = "workflow_node_output"
+	return s, nil
+}
+
+// saveNodeOutput 保存节点的输出及状态，输出无法序列化时只保存状态
+func saveNodeOutput(runID, nodeID string, output any, status ExecutionStatus) error {
+	data, err := json.Marshal(output)
+	if err != nil {
+		data = []byte("null")
+	}
+	s, err := GetSqliteObjNO()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	_, err = s.Upsert([]string{"run_id", "node_id"}, map[string]interface{}{
+		"run_id":      runID,
+		"node_id":     nodeID,
+		"status":      string(status),
+		"output":      string(data),
+		"update_time": time.Now().Format("2006-01-02 15:04:05"),
+	}, "status=excluded.status, output=excluded.output, update_time=excluded.update_time")
+	return err
+}
+
+// resumeRerunKey 恢复执行时标记节点的上游已重新执行，该节点不使用保存的输出
+const resumeRerunKey = "_resumeRerun"
+
+func markResumeRerun(node *WorkflowNode) {
+	children := append([]*WorkflowNode{node.ChildNode}, node.ConditionNodes...)
+	for _, child := range children {
+		if child == nil {
+			continue
+		}
+		if child.Config == nil {
+			child.Config = make(map[string]any)
+		}
+		child.Config[resumeRerunKey] = true
+	}
+}
+
+// loadCheckpoint 读取执行记录中执行成功的节点及其输出
+func loadCheckpoint(runID string) (*checkpoint, error) {
+	s, err := GetSqliteObjNO()
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	data, err := s.Where("run_id=? and status=?", []interface{}{runID, string(StatusSuccess)}).Select()
+	if err != nil {
+		return nil, err
+	}
+	cp := &checkpoint{RunID: runID, Outputs: make(map[string]any, len(data))}
+	for _, v := range data {
+		nodeID, _ := v["node_id"].(string)
+		outputStr, _ := v["output"].(string)
+		var output any
+		if outputStr != "" {
+			if err = json.Unmarshal([]byte(outputStr), &output); err != nil {
+				return nil, fmt.Errorf("解析节点【%s】的输出失败: %v", nodeID, err)
+			}
+		}
+		cp.Outputs[nodeID] = output
+	}
+	return cp, nil
+}
+
+// ResumeWorkflow 从执行失败的节点恢复执行，id 为执行记录 ID。
+// 上次执行中已成功的节点（如申请证书）直接使用保存的输出，从第一个未成功的节点开始执行
+func ResumeWorkflow(id string) error {
+	s, err := GetSqliteObjWH()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	runs, err := s.Where("id=?", []interface{}{id}).Select()
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		return fmt.Errorf("执行记录不存在")
+	}
+	run := runs[0]
+	switch status, _ := run["status"].(string); ExecutionStatus(status) {
+	case StatusFailed, StatusTimeout, StatusCancelled, StatusInterrupted:
+	case StatusSuccess:
+		return fmt.Errorf("该执行记录已执行成功，无需恢复")
+	default:
+		return fmt.Errorf("执行记录状态为%s，无法恢复执行", status)
+	}
+	workflowID, _ := run["workflow_id"].(string)
+	s.TableName = "workflow"
+	wfs, err := s.Where("id=?", []interface{}{workflowID}).Select()
+	if err != nil {
+		return err
+	}
+	if len(wfs) == 0 {
+		return fmt.Errorf("workflow not found")
+	}
+	if status, _ := wfs[0]["last_run_status"].(string); status == "running" {
+		return fmt.Errorf("工作流正在执行中")
+	}
+	// 执行后修改过的工作流节点可能已变化，保存的输出不再对应，需重新执行
+	if editedAfterRun(wfs[0], run) {
+		return fmt.Errorf("工作流在该次执行后已被修改，无法恢复执行，请重新执行工作流")
+	}
+	content, _ := wfs[0]["content"].(string)
+	cp, err := loadCheckpoint(id)
+	if err != nil {
+		return err
+	}
+
+	go runWithHistory(workflowID, "manual", content, cp)
+	return nil
+}
+
+// editedAfterRun 工作流的 update_time 晚于执行记录的 create_time 时返回 true
+func editedAfterRun(wf, run map[string]any) bool {
+	updateTime, _ := wf["update_time"].(string)
+	createTime, _ := run["create_time"].(string)
+	updated, err := time.ParseInLocation("2006-01-02 15:04:05", updateTime, time.Local)
+	if err != nil {
+		return false
+	}
+	created, err := time.ParseInLocation("2006-01-02 15:04:05", createTime, time.Local)
+	if err != nil {
+		return false
+	}
+	return updated.After(created)
+}
@@ -0,0 +1,82 @@
+package workflow
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestCheckpointOutput(t *testing.T) {
+	ctx := &ExecutionContext{}
+	if _, ok := ctx.checkpointOutput("apply-1"); ok {
+		t.Fatal("run without checkpoint should not reuse outputs")
+	}
+	ctx.checkpoint = &checkpoint{RunID: "run-1", Outputs: map[string]any{"apply-1": map[string]any{"cert": "c"}}}
+	if _, ok := ctx.checkpointOutput("apply-1"); !ok {
+		t.Fatal("expected saved output of successful node")
+	}
+	if _, ok := ctx.checkpointOutput("deploy-1"); ok {
+		t.Fatal("node missing from checkpoint should be executed")
+	}
+}
+
+func TestMarkResumeRerun(t *testing.T) {
+	child := &WorkflowNode{Id: "deploy-1"}
+	branch := &WorkflowNode{Id: "condition-1", Config: map[string]any{"type": "fail"}}
+	node := &WorkflowNode{Id: "apply-1", ChildNode: child, ConditionNodes: []*WorkflowNode{branch}}
+	markResumeRerun(node)
+	for _, n := range []*WorkflowNode{child, branch} {
+		if rerun, _ := n.Config[resumeRerunKey].(bool); !rerun {
+			t.Fatalf("node %s should be marked for rerun", n.Id)
+		}
+	}
+	if branch.Config["type"] != "fail" {
+		t.Fatal("existing config should be kept")
+	}
+}
+
+func TestRunNodeResumeFromCheckpoint(t *testing.T) {
+	e := &testExecutor{}
+	useTestExecutor(t, e)
+	ctx := newTestContext(t, context.Background())
+	// 上次执行中申请成功、部署失败，部署之后的通知节点在其他分支中成功过
+	ctx.checkpoint = &checkpoint{RunID: "run-1", Outputs: map[string]any{
+		"apply-1":  map[string]any{"cert": "saved"},
+		"notify-1": map[string]any{"node": "notify-1"},
+	}}
+	tree := &WorkflowNode{
+		Id:   "apply-1",
+		Type: "apply",
+		ChildNode: &WorkflowNode{
+			Id:        "deploy-1",
+			Type:      "deploy",
+			Inputs:    []WorkflowNodeParams{{Name: "certificate", FromNodeID: "apply-1"}},
+			ChildNode: &WorkflowNode{Id: "notify-1", Type: "notify"},
+		},
+	}
+	if err := RunNode(tree, ctx); err != nil {
+		t.Fatal(err)
+	}
+	// 申请节点使用保存的输出，不重新下单；失败的部署及其下游节点重新执行
+	if ran := e.executed(); !reflect.DeepEqual(ran, []string{"deploy-1", "notify-1"}) {
+		t.Fatalf("unexpected executed nodes: %v", ran)
+	}
+	if cert := tree.ChildNode.Config["certificate"]; !reflect.DeepEqual(cert, map[string]any{"cert": "saved"}) {
+		t.Fatalf("deploy should use the saved certificate, got %v", cert)
+	}
+	for _, id := range []string{"apply-1", "deploy-1", "notify-1"} {
+		if status := ctx.GetStatus(id); status != StatusSuccess {
+			t.Fatalf("node %s status = %q, want %q", id, status, StatusSuccess)
+		}
+	}
+}
+
+func TestEditedAfterRun(t *testing.T) {
+	run := map[string]any{"create_time": "2024-05-01 10:00:00"}
+	if editedAfterRun(map[string]any{"update_time": "2024-05-01 09:59:59"}, run) {
+		t.Fatal("workflow edited before the run can be resumed")
+	}
+	if !editedAfterRun(map[string]any{"update_time": "2024-05-01 10:30:00"}, run) {
+		t.Fatal("workflow edited after the run should not be resumed")
+	}
+}
@@ -1,22 +1,72 @@
 package workflow
 
-import "ALLinSSL/backend/public"
+import (
+	"ALLinSSL/backend/public"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// runCancels 本进程中正在执行的工作流，按执行记录 ID 保存取消函数
+var runCancels = struct {
+	sync.Mutex
+	m map[string]context.CancelFunc
+}{m: make(map[string]context.CancelFunc)}
 
 func NewExecutionContext(RunID string) *ExecutionContext {
 	Logger, _ := public.NewLogger(public.GetSettingIgnoreError("workflow_log_path") + RunID + ".log")
+	c, cancel := context.WithCancel(context.Background())
+	runCancels.Lock()
+	runCancels.m[RunID] = cancel
+	runCancels.Unlock()
 	return &ExecutionContext{
 		Data:   make(map[string]any),
 		Status: make(map[string]ExecutionStatus),
 		RunID:  RunID,
 		Logger: Logger,
+		Ctx:    c,
+		cancel: cancel,
+	}
+}
+
+// Close 执行结束后从注册表中移除并关闭日志
+func (ctx *ExecutionContext) Close() {
+	runCancels.Lock()
+	delete(runCancels.m, ctx.RunID)
+	runCancels.Unlock()
+	ctx.cancel()
+	ctx.Logger.Close()
+}
+
+// CancelRun 取消正在执行的工作流，执行记录不在本进程中执行时返回 false
+func CancelRun(runID string) bool {
+	runCancels.Lock()
+	defer runCancels.Unlock()
+	cancel, ok := runCancels.m[runID]
+	if ok {
+		cancel()
 	}
+	return ok
 }
 
+// SetOutput 记录节点的输出及状态，并按执行记录保存，用于从失败的节点恢复执行
 func (ctx *ExecutionContext) SetOutput(nodeID string, output any, status ExecutionStatus) {
 	ctx.mu.Lock()
-	defer ctx.mu.Unlock()
 	ctx.Data[nodeID] = output
 	ctx.Status[nodeID] = status
+	ctx.mu.Unlock()
+	if err := saveNodeOutput(ctx.RunID, nodeID, output, status); err != nil {
+		ctx.Logger.Debug(fmt.Sprintf("保存节点【%s】的输出失败: %v", nodeID, err))
+	}
+}
+
+// checkpointOutput 恢复执行时获取节点在上次执行中的输出，节点未成功时返回 false
+func (ctx *ExecutionContext) checkpointOutput(nodeID string) (any, bool) {
+	if ctx.checkpoint == nil {
+		return nil, false
+	}
+	output, ok := ctx.checkpoint.Outputs[nodeID]
+	return output, ok
 }
 
 func (ctx *ExecutionContext) GetOutput(nodeID string) (any, bool) {
@@ -6,9 +6,11 @@ import (
 	certDeploy "ALLinSSL/backend/internal/cert/deploy"
 	"ALLinSSL/backend/internal/report"
 	"ALLinSSL/backend/public"
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 // var executors map[string]func(map[string]any) (any, error)
@@ -17,26 +19,32 @@ import (
 // 	executors[executorName] = executor
 // }
 
-func Executors(exec string, params map[string]any) (any, error) {
+// nodeExecutor 执行节点的函数，测试时替换为模拟的执行器
+var nodeExecutor = Executors
+
+// Executors 执行节点，ctx 在停止工作流时取消
+func Executors(ctx context.Context, exec string, params map[string]any) (any, error) {
 	switch exec {
 	case "apply":
-		return apply(params)
+		return apply(ctx, params)
 	case "deploy":
-		return deploy(params)
+		return deploy(ctx, params)
 	case "upload":
 		return upload(params)
 	case "notify":
 		return notify(params)
+	case "revoke":
+		return revoke(ctx, params)
 	default:
 		return nil, nil
 	}
 }
 
-func apply(params map[string]any) (any, error) {
+func apply(ctx context.Context, params map[string]any) (any, error) {
 	logger := params["logger"].(*public.Logger)
 
 	logger.Info("=============申请证书=============")
-	certificate, err := certApply.Apply(params, logger)
+	certificate, err := certApply.Apply(ctx, params, logger)
 	if err != nil {
 		logger.Error(err.Error())
 		logger.Info("=============申请失败=============")
@@ -46,7 +54,7 @@ func apply(params map[string]any) (any, error) {
 	return certificate, nil
 }
 
-func deploy(params map[string]any) (any, error) {
+func deploy(ctx context.Context, params map[string]any) (any, error) {
 	logger := params["logger"].(*public.Logger)
 	logger.Info("=============部署证书=============")
 	certificate := params["certificate"]
@@ -61,18 +69,39 @@ func deploy(params map[string]any) (any, error) {
 		logger.Info("=============部署失败=============")
 		return nil, errors.New("证书不存在")
 	}
-	certStr, ok := certificateMap["cert"].(string)
-	if !ok {
-		logger.Error("证书格式错误")
-		logger.Info("=============部署失败=============")
-		return nil, errors.New("证书格式错误")
+	// 上个节点签发了多种算法的证书时，按 cert_algorithm 选择要部署的证书
+	certAlgorithm, _ := params["cert_algorithm"].(string)
+	// 上个节点拆分签发了多张证书时，按站点域名选择证书，未指定 cert_domain 时使用部署目标的 domain
+	certDomain, _ := params["cert_domain"].(string)
+	if strings.TrimSpace(certDomain) == "" {
+		certDomain, _ = params["domain"].(string)
+	}
+	certificates, err := selectCertificates(certificateMap, certAlgorithm, certDomain)
+	if err == nil {
+		err = checkMultiDeploy(params, certificates)
 	}
-	nowSha256, err := public.GetSHA256(certStr)
 	if err != nil {
-		logger.Error("解析证书sha256失败：" + err.Error())
+		logger.Error(err.Error())
 		logger.Info("=============部署失败=============")
 		return nil, err
 	}
+	var sha256List []string
+	for _, c := range certificates {
+		certStr, ok := c["cert"].(string)
+		if !ok {
+			logger.Error("证书格式错误")
+			logger.Info("=============部署失败=============")
+			return nil, errors.New("证书格式错误")
+		}
+		sha256, err := public.GetSHA256(certStr)
+		if err != nil {
+			logger.Error("解析证书sha256失败：" + err.Error())
+			logger.Info("=============部署失败=============")
+			return nil, err
+		}
+		sha256List = append(sha256List, sha256)
+	}
+	nowSha256 := strings.Join(sha256List, ",")
 
 	s, err := public.NewSqlite("data/data.db", "")
 	if err != nil {
@@ -126,7 +155,25 @@ func deploy(params map[string]any) (any, error) {
 		}
 	}
 
-	err = certDeploy.Deploy(params, logger)
+	for _, c := range certificates {
+		if algorithm, ok := c["algorithm"].(string); ok && len(certificates) > 1 {
+			logger.Debug("部署" + algorithm + "证书")
+		}
+		if domains, ok := c["domains"].(string); ok && len(certificates) > 1 {
+			logger.Debug("部署域名为 " + domains + " 的证书")
+		}
+		params["certificate"] = c
+		err = certDeploy.Deploy(ctx, params, logger)
+		if err != nil {
+			break
+		}
+	}
+	// 超时或停止后执行器可能已被放弃，工作流按失败处理，此时不再记录部署状态，避免被放弃的部署写入成功
+	if cause := context.Cause(ctx); cause != nil {
+		logger.Error("部署已中止，不记录部署状态：" + cause.Error())
+		logger.Info("=============部署失败=============")
+		return nil, cause
+	}
 	var status string
 	if err != nil {
 		status = "fail"
@@ -241,3 +288,56 @@ func notify(params map[string]any) (any, error) {
 	logger.Info("=============发送成功=============")
 	return fmt.Sprintf("通知到: %s", params["message"]), nil
 }
+
+func revoke(ctx context.Context, params map[string]any) (any, error) {
+	logger := params["logger"].(*public.Logger)
+	logger.Info("=============吊销证书=============")
+	// 优先使用指定的证书 ID，否则吊销上一个节点输出的证书
+	certId := ""
+	switch v := params["cert_id"].(type) {
+	case float64:
+		certId = strconv.Itoa(int(v))
+	case string:
+		certId = v
+	}
+	if certId == "" {
+		certificateMap, ok := params["certificate"].(map[string]any)
+		if !ok {
+			logger.Error("证书不存在")
+			logger.Info("=============吊销失败=============")
+			return nil, errors.New("证书不存在")
+		}
+		certStr, ok := certificateMap["cert"].(string)
+		if !ok {
+			logger.Error("证书格式错误")
+			logger.Info("=============吊销失败=============")
+			return nil, errors.New("证书格式错误")
+		}
+		sha256, err := public.GetSHA256(certStr)
+		if err != nil {
+			logger.Error("解析证书sha256失败：" + err.Error())
+			logger.Info("=============吊销失败=============")
+			return nil, err
+		}
+		certId = sha256
+	}
+	reason, err := certApply.ParseRevokeReason(params["reason"])
+	if err != nil {
+		logger.Error(err.Error())
+		logger.Info("=============吊销失败=============")
+		return nil, err
+	}
+	mode, _ := params["mode"].(string)
+	email, _ := params["email"].(string)
+	ca, _ := params["ca"].(string)
+
+	logger.Debug(fmt.Sprintf("证书 ID: %s，吊销原因代码: %d", certId, reason))
+	err = certApply.RevokeCert(certId, reason, mode, email, ca, certApply.ContextHTTPClient(ctx, nil), logger)
+	if err != nil {
+		logger.Error(err.Error())
+		logger.Info("=============吊销失败=============")
+		return nil, err
+	}
+	logger.Info("=============吊销成功=============")
+	return nil, nil
+}
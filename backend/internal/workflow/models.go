@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"ALLinSSL/backend/public"
+	"context"
 	"sync"
 )
 
@@ -10,6 +11,12 @@ type ExecutionStatus string
 const (
 	StatusSuccess ExecutionStatus = "success"
 	StatusFailed  ExecutionStatus = "fail"
+	// StatusCancelled 执行被手动停止
+	StatusCancelled ExecutionStatus = "cancelled"
+	// StatusTimeout 节点或工作流执行超时
+	StatusTimeout ExecutionStatus = "timeout"
+	// StatusInterrupted 进程退出导致执行中断
+	StatusInterrupted ExecutionStatus = "interrupted"
 )
 
 type WorkflowNodeParams struct {
@@ -38,6 +45,11 @@ type ExecutionContext struct {
 	mu     sync.RWMutex
 	RunID  string
 	Logger *public.Logger
+	// Ctx 在停止执行时取消，传递给各节点的执行器
+	Ctx    context.Context
+	cancel context.CancelFunc
+	// checkpoint 恢复执行时上次执行中已成功节点的输出
+	checkpoint *checkpoint
 }
 
 type ExecTime struct {
@@ -0,0 +1,112 @@
+package workflow
+
+import (
+	"ALLinSSL/backend/public"
+	"encoding/json"
+	"fmt"
+	ps "github.com/mitchellh/go-ps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// runOwnerAlive 判断执行记录所属的进程是否仍在执行该记录。
+// 本进程的记录以是否在 runCancels 中为准（容器中重启后 PID 可能相同），其他进程需仍存在且为同一程序
+func runOwnerAlive(runID string, pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	if pid == os.Getpid() {
+		runCancels.Lock()
+		defer runCancels.Unlock()
+		_, ok := runCancels.m[runID]
+		return ok
+	}
+	proc, err := ps.FindProcess(pid)
+	if err != nil || proc == nil {
+		return false
+	}
+	self, err := ps.FindProcess(os.Getpid())
+	if err != nil || self == nil {
+		return true
+	}
+	return proc.Executable() == self.Executable()
+}
+
+// rerunInterrupted 工作流是否开启了中断后自动重新执行，配置在根节点 config 的 rerun_interrupted 中
+func rerunInterrupted(content string) bool {
+	var node WorkflowNode
+	if err := json.Unmarshal([]byte(content), &node); err != nil {
+		return false
+	}
+	switch v := node.Config["rerun_interrupted"].(type) {
+	case bool:
+		return v
+	case float64:
+		return v == 1
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	return false
+}
+
+// RecoverInterruptedRuns 将所属进程已退出的执行中记录标记为 interrupted 并在执行日志中记录，
+// 开启 rerun_interrupted 的工作流从中断的节点恢复执行一次。上一次执行同样被中断时不再重新执行，避免反复中断
+func RecoverInterruptedRuns() {
+	s, err := GetSqliteObjWH()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer s.Close()
+	runs, err := s.Where("status=?", []interface{}{"running"}).Order("create_time", "asc").Select()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	type interruptedRun struct {
+		runID    string
+		execType string
+	}
+	rerun := map[string]interruptedRun{}
+	for _, run := range runs {
+		runID, _ := run["id"].(string)
+		workflowID, _ := run["workflow_id"].(string)
+		pid, _ := run["pid"].(int64)
+		if runOwnerAlive(runID, int(pid)) {
+			continue
+		}
+		SetWorkflowStatus(workflowID, runID, string(StatusInterrupted))
+		if logger, err := public.NewLogger(filepath.Join(public.GetSettingIgnoreError("workflow_log_path"), runID+".log")); err == nil {
+			logger.Error(fmt.Sprintf("执行进程（PID %d）已退出，执行于 %s 中断", pid, time.Now().Format("2006-01-02 15:04:05")))
+			logger.Info("=============执行中断=============")
+			logger.Close()
+		}
+		previous, err := s.Where("workflow_id=? and create_time<? and id<>?", []interface{}{workflowID, run["create_time"], runID}).Order("create_time", "desc").Limit([]int64{0, 1}).Select()
+		if err != nil || (len(previous) > 0 && previous[0]["status"] == string(StatusInterrupted)) {
+			delete(rerun, workflowID)
+			continue
+		}
+		execType, _ := run["exec_type"].(string)
+		rerun[workflowID] = interruptedRun{runID: runID, execType: execType}
+	}
+
+	for workflowID, run := range rerun {
+		wfs, err := s.Table("workflow").Where("id=?", []interface{}{workflowID}).Select()
+		if err != nil || len(wfs) == 0 {
+			continue
+		}
+		content, _ := wfs[0]["content"].(string)
+		if !rerunInterrupted(content) {
+			continue
+		}
+		cp, err := loadCheckpoint(run.runID)
+		if err != nil {
+			fmt.Println(err)
+			cp = nil
+		}
+		go runWithHistory(workflowID, run.execType, content, cp)
+	}
+}
@@ -0,0 +1,39 @@
+package workflow
+
+import (
+	"os"
+	"testing"
+)
+
+func TestRunOwnerAlive(t *testing.T) {
+	if runOwnerAlive("run-1", 0) {
+		t.Fatal("record without pid should be treated as interrupted")
+	}
+	if runOwnerAlive("run-1", os.Getpid()) {
+		t.Fatal("record of this process that is not executing should be treated as interrupted")
+	}
+	runCancels.Lock()
+	runCancels.m["run-1"] = func() {}
+	runCancels.Unlock()
+	defer func() {
+		runCancels.Lock()
+		delete(runCancels.m, "run-1")
+		runCancels.Unlock()
+	}()
+	if !runOwnerAlive("run-1", os.Getpid()) {
+		t.Fatal("record executing in this process should be alive")
+	}
+}
+
+func TestRerunInterrupted(t *testing.T) {
+	for content, want := range map[string]bool{
+		`{"id":"start","type":"start","config":{"rerun_interrupted":true}}`: true,
+		`{"id":"start","type":"start","config":{"rerun_interrupted":"1"}}`:  true,
+		`{"id":"start","type":"start","config":{}}`:                         false,
+		`invalid`: false,
+	} {
+		if got := rerunInterrupted(content); got != want {
+			t.Errorf("rerunInterrupted(%s) = %v, want %v", content, got, want)
+		}
+	}
+}
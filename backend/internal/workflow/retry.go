@@ -0,0 +1,237 @@
+package workflow
+
+import (
+	certDeploy "ALLinSSL/backend/internal/cert/deploy"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-acme/lego/v4/acme"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// retryPolicy 节点的重试策略，配置在节点 config 的 retry 中：
+//
+//	{"max_attempts": 3, "initial_delay": 5, "max_delay": 60, "retry_on": ["throttle", "server", "network"]}
+//
+// 延迟为秒数或 time.ParseDuration 支持的格式，retry_on 为可重试的错误分类，默认全部
+type retryPolicy struct {
+	MaxAttempts  int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	RetryOn      map[certDeploy.ErrorClass]bool
+}
+
+var retryClasses = []certDeploy.ErrorClass{certDeploy.ErrorThrottle, certDeploy.ErrorServer, certDeploy.ErrorNetwork}
+
+// parseDuration 解析秒数或 time.ParseDuration 支持的格式，未配置时返回 def
+func parseDuration(v any, def time.Duration) (time.Duration, error) {
+	switch val := v.(type) {
+	case nil:
+		return def, nil
+	case int:
+		return time.Duration(val) * time.Second, nil
+	case float64:
+		return time.Duration(val * float64(time.Second)), nil
+	case string:
+		val = strings.TrimSpace(val)
+		if val == "" {
+			return def, nil
+		}
+		if n, err := strconv.ParseFloat(val, 64); err == nil {
+			return time.Duration(n * float64(time.Second)), nil
+		}
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return 0, fmt.Errorf("无效的时间：%s", val)
+		}
+		return d, nil
+	}
+	return 0, fmt.Errorf("无效的时间：%v", v)
+}
+
+// parseRetryPolicy 解析节点的 retry 配置，未配置时 max_attempts 为 1，即不重试
+func parseRetryPolicy(v any) (*retryPolicy, error) {
+	policy := &retryPolicy{
+		MaxAttempts:  1,
+		InitialDelay: 5 * time.Second,
+		MaxDelay:     time.Minute,
+		RetryOn:      map[certDeploy.ErrorClass]bool{},
+	}
+	for _, c := range retryClasses {
+		policy.RetryOn[c] = true
+	}
+	var cfg map[string]any
+	switch val := v.(type) {
+	case nil:
+		return policy, nil
+	case map[string]any:
+		cfg = val
+	case string:
+		if strings.TrimSpace(val) == "" {
+			return policy, nil
+		}
+		if err := json.Unmarshal([]byte(val), &cfg); err != nil {
+			return nil, fmt.Errorf("参数错误：retry 格式错误: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("参数错误：retry 格式错误")
+	}
+
+	switch val := cfg["max_attempts"].(type) {
+	case nil:
+	case int:
+		policy.MaxAttempts = val
+	case float64:
+		policy.MaxAttempts = int(val)
+	case string:
+		n, err := strconv.Atoi(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("参数错误：retry.max_attempts 必须为整数")
+		}
+		policy.MaxAttempts = n
+	default:
+		return nil, fmt.Errorf("参数错误：retry.max_attempts 必须为整数")
+	}
+	if policy.MaxAttempts < 1 {
+		return nil, fmt.Errorf("参数错误：retry.max_attempts 不能小于1")
+	}
+	var err error
+	if policy.InitialDelay, err = parseDuration(cfg["initial_delay"], policy.InitialDelay); err != nil {
+		return nil, fmt.Errorf("参数错误：retry.initial_delay %v", err)
+	}
+	if policy.MaxDelay, err = parseDuration(cfg["max_delay"], policy.MaxDelay); err != nil {
+		return nil, fmt.Errorf("参数错误：retry.max_delay %v", err)
+	}
+	if policy.InitialDelay < 0 || policy.MaxDelay < 0 {
+		return nil, fmt.Errorf("参数错误：retry 的延迟不能为负数")
+	}
+	if policy.MaxDelay < policy.InitialDelay {
+		policy.MaxDelay = policy.InitialDelay
+	}
+
+	var names []string
+	switch val := cfg["retry_on"].(type) {
+	case nil:
+		return policy, nil
+	case string:
+		names = strings.Split(val, ",")
+	case []any:
+		for _, item := range val {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("参数错误：retry.retry_on 格式错误")
+			}
+			names = append(names, s)
+		}
+	case []string:
+		names = val
+	default:
+		return nil, fmt.Errorf("参数错误：retry.retry_on 格式错误")
+	}
+	policy.RetryOn = map[certDeploy.ErrorClass]bool{}
+	for _, name := range names {
+		class := certDeploy.ErrorClass(strings.ToLower(strings.TrimSpace(name)))
+		if class == "" {
+			continue
+		}
+		valid := false
+		for _, c := range retryClasses {
+			if c == class {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("参数错误：retry.retry_on 不支持 %s，可选值为 throttle、server、network", name)
+		}
+		policy.RetryOn[class] = true
+	}
+	return policy, nil
+}
+
+// backoff 第 attempt 次失败后的等待时间：初始延迟按 2 的指数增长并以 max_delay 为上限，
+// 取其中一半固定、一半随机，避免多个节点同时重试
+func (p *retryPolicy) backoff(attempt int) time.Duration {
+	delay := p.InitialDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// classifyError 对节点执行错误分类，ACME 错误按问题类型及状态码判断，其他错误按部署目标的错误判断
+func classifyError(err error) certDeploy.ErrorClass {
+	var problem *acme.ProblemDetails
+	if errors.As(err, &problem) {
+		switch {
+		case problem.Type == "urn:ietf:params:acme:error:rateLimited" || problem.HTTPStatus == 429:
+			return certDeploy.ErrorThrottle
+		case problem.HTTPStatus >= 500:
+			return certDeploy.ErrorServer
+		}
+		return certDeploy.ErrorPermanent
+	}
+	return certDeploy.ClassifyError(err)
+}
+
+// runWithRetry 按节点的重试策略执行节点，只有限流、服务端错误及网络错误可重试，
+// 授权、参数等错误直接失败，每次重试都记录到执行日志。c 为节点的执行上下文，超时或停止时取消
+func runWithRetry(c context.Context, node *WorkflowNode, ctx *ExecutionContext) (any, error) {
+	policy, err := parseRetryPolicy(node.Config["retry"])
+	if err != nil {
+		ctx.Logger.Error(err.Error())
+		return nil, err
+	}
+	name := nodeName(node)
+	for attempt := 1; ; attempt++ {
+		// 执行器会改写参数（如部署时选出的证书），且超时后被放弃的执行器仍可能在后台运行，
+		// 每次执行都使用原始参数的副本，不直接传入 node.Config
+		params := make(map[string]any, len(node.Config))
+		for k, v := range node.Config {
+			params[k] = v
+		}
+		result, err := nodeExecutor(c, node.Type, params)
+		if err == nil {
+			if attempt > 1 {
+				ctx.Logger.Info(fmt.Sprintf("节点【%s】第 %d/%d 次执行成功", name, attempt, policy.MaxAttempts))
+			}
+			return result, nil
+		}
+		if c.Err() != nil {
+			return nil, err
+		}
+		class := classifyError(err)
+		if class == certDeploy.ErrorPermanent || !policy.RetryOn[class] {
+			if attempt > 1 {
+				ctx.Logger.Error(fmt.Sprintf("节点【%s】第 %d/%d 次执行失败，错误不可重试: %v", name, attempt, policy.MaxAttempts, err))
+			}
+			return nil, err
+		}
+		if attempt >= policy.MaxAttempts {
+			if policy.MaxAttempts > 1 {
+				ctx.Logger.Error(fmt.Sprintf("节点【%s】第 %d/%d 次执行失败（%s），已达最大重试次数: %v", name, attempt, policy.MaxAttempts, class, err))
+			}
+			return nil, err
+		}
+		delay := policy.backoff(attempt)
+		ctx.Logger.Info(fmt.Sprintf("节点【%s】第 %d/%d 次执行失败（%s），%.1f 秒后重试: %v", name, attempt, policy.MaxAttempts, class, delay.Seconds(), err))
+		timer := time.NewTimer(delay)
+		select {
+		case <-c.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("执行已停止: %w", context.Cause(c))
+		case <-timer.C:
+		}
+	}
+}
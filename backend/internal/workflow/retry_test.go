@@ -0,0 +1,85 @@
+package workflow
+
+import (
+	certDeploy "ALLinSSL/backend/internal/cert/deploy"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-acme/lego/v4/acme"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestParseRetryPolicy(t *testing.T) {
+	p, err := parseRetryPolicy(nil)
+	if err != nil || p.MaxAttempts != 1 {
+		t.Fatalf("unexpected default policy: %+v %v", p, err)
+	}
+	p, err = parseRetryPolicy(map[string]any{"max_attempts": float64(4), "initial_delay": "2", "max_delay": "30s", "retry_on": []any{"throttle", "network"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.MaxAttempts != 4 || p.InitialDelay != 2*time.Second || p.MaxDelay != 30*time.Second {
+		t.Fatalf("unexpected policy: %+v", p)
+	}
+	if !p.RetryOn[certDeploy.ErrorThrottle] || p.RetryOn[certDeploy.ErrorServer] || !p.RetryOn[certDeploy.ErrorNetwork] {
+		t.Fatalf("unexpected retry_on: %v", p.RetryOn)
+	}
+	if _, err = parseRetryPolicy(`{"max_attempts":3,"retry_on":"server,auth"}`); err == nil {
+		t.Fatal("expected unknown retry_on class to fail")
+	}
+	if _, err = parseRetryPolicy(map[string]any{"max_attempts": 0}); err == nil {
+		t.Fatal("expected max_attempts 0 to fail")
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := &retryPolicy{InitialDelay: 2 * time.Second, MaxDelay: 10 * time.Second}
+	for attempt, max := range map[int]time.Duration{1: 2 * time.Second, 2: 4 * time.Second, 3: 8 * time.Second, 5: 10 * time.Second} {
+		for i := 0; i < 20; i++ {
+			if d := p.backoff(attempt); d < max/2 || d > max {
+				t.Fatalf("attempt %d: delay %v out of [%v, %v]", attempt, d, max/2, max)
+			}
+		}
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want certDeploy.ErrorClass
+	}{
+		{&acme.ProblemDetails{Type: "urn:ietf:params:acme:error:rateLimited", HTTPStatus: 429}, certDeploy.ErrorThrottle},
+		{fmt.Errorf("obtain: %w", &acme.ProblemDetails{HTTPStatus: 503}), certDeploy.ErrorServer},
+		{&acme.ProblemDetails{Type: "urn:ietf:params:acme:error:unauthorized", HTTPStatus: 403}, certDeploy.ErrorPermanent},
+		{fmt.Errorf("请求BT失败: %w", &certDeploy.StatusError{Target: "宝塔面板", StatusCode: 502}), certDeploy.ErrorServer},
+		{fmt.Errorf("请求BT失败: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")}), certDeploy.ErrorNetwork},
+		{errors.New("部署失败: Too Many Requests"), certDeploy.ErrorThrottle},
+		{errors.New("签名验证失败，请检查AccessKey"), certDeploy.ErrorPermanent},
+	}
+	for _, c := range cases {
+		if got := classifyError(c.err); got != c.want {
+			t.Errorf("classifyError(%v) = %q, want %q", c.err, got, c.want)
+		}
+	}
+}
+
+func TestRunWithRetryCopiesParams(t *testing.T) {
+	old := nodeExecutor
+	defer func() { nodeExecutor = old }()
+	nodeExecutor = func(c context.Context, exec string, params map[string]any) (any, error) {
+		params["certificate"] = "selected"
+		return nil, errors.New("签名验证失败")
+	}
+	ctx := newTestContext(t, context.Background())
+	for _, retry := range []any{nil, map[string]any{"max_attempts": 2}} {
+		node := &WorkflowNode{Id: "deploy-1", Type: "deploy", Config: map[string]any{"retry": retry}}
+		if _, err := runWithRetry(ctx.Ctx, node, ctx); err == nil {
+			t.Fatal("expected executor error")
+		}
+		if _, ok := node.Config["certificate"]; ok {
+			t.Fatalf("retry %v: executor should not modify node config", retry)
+		}
+	}
+}
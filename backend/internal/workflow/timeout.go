@@ -0,0 +1,80 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// errTimeout 节点或工作流执行超时
+var errTimeout = errors.New("执行超时")
+
+// abortGracePeriod 超时或停止后等待执行器自行退出（如清理验证记录）的时间，超过后不再等待
+var abortGracePeriod = 10 * time.Second
+
+// parseTimeout 解析节点 config 中的 timeout，未配置或为 0 时不限制
+func parseTimeout(cfg map[string]any) (time.Duration, error) {
+	d, err := parseDuration(cfg["timeout"], 0)
+	if err != nil {
+		return 0, fmt.Errorf("参数错误：timeout %v", err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("参数错误：timeout 不能为负数")
+	}
+	return d, nil
+}
+
+func nodeName(node *WorkflowNode) string {
+	if node.Name != "" {
+		return node.Name
+	}
+	return node.Id
+}
+
+// runWithTimeout 在节点的超时时间内执行节点，超时、停止或工作流超时后取消执行器的上下文，
+// 执行器在 abortGracePeriod 内仍未退出时直接中止，避免卡住的节点使工作流一直处于执行中。
+// 被放弃的执行器仍可能在后台继续运行，执行器在持久化结果前需检查 ctx 是否已取消
+func runWithTimeout(node *WorkflowNode, ctx *ExecutionContext) (any, error) {
+	timeout, err := parseTimeout(node.Config)
+	if err != nil {
+		ctx.Logger.Error(err.Error())
+		return nil, err
+	}
+	c := ctx.Ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		c, cancel = context.WithTimeoutCause(c, timeout, fmt.Errorf("节点【%s】%w（%s）", nodeName(node), errTimeout, timeout))
+		defer cancel()
+	}
+
+	type nodeResult struct {
+		result any
+		err    error
+	}
+	done := make(chan nodeResult, 1)
+	go func() {
+		result, err := runWithRetry(c, node, ctx)
+		done <- nodeResult{result, err}
+	}()
+
+	var r nodeResult
+	select {
+	case r = <-done:
+	case <-c.Done():
+		grace := time.NewTimer(abortGracePeriod)
+		defer grace.Stop()
+		select {
+		case r = <-done:
+		case <-grace.C:
+			ctx.Logger.Error(fmt.Sprintf("节点【%s】在 %s 内未响应中止，已放弃等待，该节点可能仍在后台运行", nodeName(node), abortGracePeriod))
+			r.err = context.Cause(c)
+		}
+	}
+	// 超时导致的失败统一返回超时错误，便于记录为 timeout
+	if cause := context.Cause(c); r.err != nil && errors.Is(cause, errTimeout) {
+		ctx.Logger.Error(cause.Error() + "，已中止")
+		return nil, cause
+	}
+	return r.result, r.err
+}
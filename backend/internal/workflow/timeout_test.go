@@ -0,0 +1,170 @@
+package workflow
+
+import (
+	"ALLinSSL/backend/public"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseTimeout(t *testing.T) {
+	cases := []struct {
+		cfg  map[string]any
+		want time.Duration
+	}{
+		{map[string]any{}, 0},
+		{map[string]any{"timeout": float64(300)}, 5 * time.Minute},
+		{map[string]any{"timeout": "90"}, 90 * time.Second},
+		{map[string]any{"timeout": "2h"}, 2 * time.Hour},
+	}
+	for _, c := range cases {
+		got, err := parseTimeout(c.cfg)
+		if err != nil || got != c.want {
+			t.Errorf("parseTimeout(%v) = %v, %v, want %v", c.cfg, got, err, c.want)
+		}
+	}
+	for _, v := range []any{"-1", "abc", true} {
+		if _, err := parseTimeout(map[string]any{"timeout": v}); err == nil {
+			t.Errorf("parseTimeout(%v) expected error", v)
+		}
+	}
+}
+
+// newTestContext 创建测试用的执行上下文，parent 为工作流的上下文
+func newTestContext(t *testing.T, parent context.Context) *ExecutionContext {
+	logger, err := public.NewLogger(filepath.Join(t.TempDir(), "run.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, cancel := context.WithCancel(parent)
+	t.Cleanup(func() {
+		cancel()
+		logger.Close()
+	})
+	return &ExecutionContext{
+		Data:   make(map[string]any),
+		Status: make(map[string]ExecutionStatus),
+		RunID:  "run-test",
+		Logger: logger,
+		Ctx:    c,
+		cancel: cancel,
+	}
+}
+
+// testExecutor 记录执行过的节点，blocking 中的节点忽略 ctx 一直阻塞，failing 中的节点执行失败
+type testExecutor struct {
+	mu       sync.Mutex
+	ran      []string
+	blocking map[string]bool
+	failing  map[string]bool
+	release  chan struct{}
+}
+
+func useTestExecutor(t *testing.T, e *testExecutor) {
+	e.release = make(chan struct{})
+	oldExecutor, oldGrace := nodeExecutor, abortGracePeriod
+	nodeExecutor = e.run
+	abortGracePeriod = 50 * time.Millisecond
+	t.Cleanup(func() {
+		close(e.release)
+		nodeExecutor, abortGracePeriod = oldExecutor, oldGrace
+	})
+}
+
+func (e *testExecutor) run(c context.Context, exec string, params map[string]any) (any, error) {
+	id, _ := params["NodeId"].(string)
+	e.mu.Lock()
+	e.ran = append(e.ran, id)
+	e.mu.Unlock()
+	if e.blocking[id] {
+		<-e.release
+		return nil, nil
+	}
+	if e.failing[id] {
+		return nil, fmt.Errorf("节点 %s 执行失败", id)
+	}
+	return map[string]any{"node": id}, nil
+}
+
+func (e *testExecutor) executed() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.ran...)
+}
+
+// resultBranchTree deploy 节点之后接执行结果分支，成功、失败分支各有一个通知节点
+func resultBranchTree(timeout string) *WorkflowNode {
+	return &WorkflowNode{
+		Id:     "deploy-1",
+		Type:   "deploy",
+		Config: map[string]any{"timeout": timeout},
+		ChildNode: &WorkflowNode{
+			Id:     "result-1",
+			Type:   "execute_result_branch",
+			Config: map[string]any{"fromNodeId": "deploy-1"},
+			ConditionNodes: []*WorkflowNode{
+				{Id: "cond-success", Type: "execute_result_condition", Config: map[string]any{"type": "success"},
+					ChildNode: &WorkflowNode{Id: "notify-success", Type: "notify"}},
+				{Id: "cond-fail", Type: "execute_result_condition", Config: map[string]any{"type": "fail"},
+					ChildNode: &WorkflowNode{Id: "notify-fail", Type: "notify"}},
+			},
+		},
+	}
+}
+
+func contains(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRunNodeTimeout(t *testing.T) {
+	e := &testExecutor{blocking: map[string]bool{"deploy-1": true}}
+	useTestExecutor(t, e)
+	ctx := newTestContext(t, context.Background())
+
+	start := time.Now()
+	if err := RunNode(resultBranchTree("100ms"), ctx); err != nil {
+		t.Fatalf("node timeout should take the fail branch, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("hung executor should be aborted")
+	}
+	if status := ctx.GetStatus("deploy-1"); status != StatusTimeout {
+		t.Fatalf("node status = %q, want %q", status, StatusTimeout)
+	}
+	ran := e.executed()
+	if !contains(ran, "notify-fail") || contains(ran, "notify-success") {
+		t.Fatalf("node timeout should only run the fail branch, ran %v", ran)
+	}
+}
+
+func TestRunNodeWorkflowTimeout(t *testing.T) {
+	e := &testExecutor{blocking: map[string]bool{"deploy-1": true}}
+	useTestExecutor(t, e)
+	parent, cancel := context.WithTimeoutCause(context.Background(), 100*time.Millisecond, fmt.Errorf("工作流%w", errTimeout))
+	defer cancel()
+	ctx := newTestContext(t, parent)
+
+	start := time.Now()
+	err := RunNode(resultBranchTree(""), ctx)
+	if !errors.Is(err, errTimeout) {
+		t.Fatalf("expected workflow timeout error, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("hung executor should be aborted")
+	}
+	if status := ctx.GetStatus("deploy-1"); status != StatusTimeout {
+		t.Fatalf("node status = %q, want %q", status, StatusTimeout)
+	}
+	if ran := e.executed(); contains(ran, "notify-fail") || contains(ran, "notify-success") {
+		t.Fatalf("workflow timeout should not run any result branch, ran %v", ran)
+	}
+}
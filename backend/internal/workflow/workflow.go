@@ -2,7 +2,9 @@ package workflow
 
 import (
 	"ALLinSSL/backend/public"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -165,29 +167,56 @@ func ExecuteWorkflow(id string) error {
 	}
 	content := data[0]["content"].(string)
 
-	go func(id, c string) {
-		// defer wg.Done()
-		// WorkflowID := strconv.FormatInt(id, 10)
-		RunID, err := AddWorkflowHistory(id, "manual")
-		if err != nil {
-			return
-		}
-		ctx := NewExecutionContext(RunID)
-		defer ctx.Logger.Close()
-		err = RunWorkflow(c, ctx)
-		if err != nil {
-			fmt.Println("执行工作流失败:", err)
-			SetWorkflowStatus(id, RunID, "fail")
-		} else {
-			SetWorkflowStatus(id, RunID, "success")
-		}
-	}(id, content)
+	go RunWithHistory(id, "manual", content)
 	return nil
 }
 
+// RunWithHistory 添加执行记录并执行工作流，结束后记录执行状态，被停止时为 cancelled，超时为 timeout
+func RunWithHistory(workflowID, execType, content string) {
+	runWithHistory(workflowID, execType, content, nil)
+}
+
+// runWithHistory cp 不为空时为恢复执行，cp 中已成功的节点不再执行
+func runWithHistory(workflowID, execType, content string, cp *checkpoint) {
+	RunID, err := AddWorkflowHistory(workflowID, execType)
+	if err != nil {
+		return
+	}
+	ctx := NewExecutionContext(RunID)
+	defer ctx.Close()
+	if cp != nil {
+		ctx.checkpoint = cp
+		_ = UpdateWorkflowHistoryResume(RunID, cp.RunID)
+		ctx.Logger.Info(fmt.Sprintf("从执行记录 %s 恢复执行，已成功的 %d 个节点使用保存的输出", cp.RunID, len(cp.Outputs)))
+	}
+	err = RunWorkflow(content, ctx)
+	switch {
+	case errors.Is(err, errTimeout):
+		SetWorkflowStatus(workflowID, RunID, string(StatusTimeout))
+	case err != nil && ctx.Ctx.Err() != nil:
+		SetWorkflowStatus(workflowID, RunID, string(StatusCancelled))
+	case err != nil:
+		fmt.Println("执行工作流失败:", err)
+		SetWorkflowStatus(workflowID, RunID, "fail")
+	default:
+		SetWorkflowStatus(workflowID, RunID, "success")
+	}
+}
+
 func SetWorkflowStatus(id, RunID, status string) {
 	_ = UpdateWorkflowHistory(RunID, status)
-	_ = UpdDb(id, map[string]interface{}{"last_run_status": status})
+	_ = updRunStatus(id, map[string]interface{}{"last_run_status": status})
+}
+
+// updRunStatus 更新工作流的执行状态，不修改 update_time，update_time 只记录工作流被编辑的时间
+func updRunStatus(id string, data map[string]any) error {
+	s, err := GetSqlite()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	_, err = s.Where("id=?", []interface{}{id}).Update(data)
+	return err
 }
 
 func resolveInputs(inputs []WorkflowNodeParams, ctx *ExecutionContext) map[string]any {
@@ -211,6 +240,10 @@ func resolveInputs(inputs []WorkflowNodeParams, ctx *ExecutionContext) map[strin
 }
 
 func RunNode(node *WorkflowNode, ctx *ExecutionContext) error {
+	// 已停止执行时不再执行后续节点
+	if err := ctx.Ctx.Err(); err != nil {
+		return fmt.Errorf("执行已停止: %w", context.Cause(ctx.Ctx))
+	}
 	// 获取上下文
 	inputs := resolveInputs(node.Inputs, ctx)
 	// 组装参数
@@ -224,13 +257,33 @@ func RunNode(node *WorkflowNode, ctx *ExecutionContext) error {
 	node.Config["logger"] = ctx.Logger
 	node.Config["NodeId"] = node.Id
 
-	// 执行当前节点
-	result, err := Executors(node.Type, node.Config)
+	var (
+		result any
+		err    error
+	)
+	// 恢复执行时，已成功且上游节点均未重新执行的节点使用上次的输出，不再执行（如不再重新申请证书）
+	rerun, _ := node.Config[resumeRerunKey].(bool)
+	if output, ok := ctx.checkpointOutput(node.Id); ok && !rerun {
+		ctx.Logger.Info(fmt.Sprintf("节点【%s】已在执行记录 %s 中执行成功，跳过", nodeName(node), ctx.checkpoint.RunID))
+		result = output
+	} else {
+		// 执行当前节点，失败时按 retry 配置重试，超过 timeout 时中止
+		result, err = runWithTimeout(node, ctx)
+		// 节点重新执行后输出可能变化，下游节点也需重新执行
+		if ctx.checkpoint != nil {
+			markResumeRerun(node)
+		}
+	}
 
 	var status ExecutionStatus
 	if err != nil {
 		status = StatusFailed
-		if node.ChildNode == nil || node.ChildNode.Type != "execute_result_branch" {
+		if errors.Is(err, errTimeout) {
+			status = StatusTimeout
+		}
+		// 停止执行或工作流超时导致的失败不进入失败分支，节点超时进入失败分支
+		if node.ChildNode == nil || node.ChildNode.Type != "execute_result_branch" || ctx.Ctx.Err() != nil {
+			ctx.SetOutput(node.Id, nil, status)
 			return err
 		}
 	} else {
@@ -273,6 +326,10 @@ func RunNode(node *WorkflowNode, ctx *ExecutionContext) error {
 		//
 		if len(node.ConditionNodes) > 0 {
 			lastStatus := ctx.GetStatus(node.Config["fromNodeId"].(string))
+			// 节点超时走失败分支
+			if lastStatus == StatusTimeout {
+				lastStatus = StatusFailed
+			}
 			for _, branch := range node.ConditionNodes {
 				if branch.Config["type"] == string(lastStatus) {
 					if branch.ChildNode != nil {
@@ -313,11 +370,31 @@ func RunWorkflow(content string, ctx *ExecutionContext) error {
 	if err != nil {
 		return err
 	} else {
+		// 根节点的 timeout 为整个工作流的超时时间
+		timeout, err := parseTimeout(node.Config)
+		if err != nil {
+			return err
+		}
+		if timeout > 0 {
+			parent := ctx.Ctx
+			c, cancel := context.WithTimeoutCause(parent, timeout, fmt.Errorf("工作流%w（%s）", errTimeout, timeout))
+			ctx.Ctx = c
+			defer func() {
+				cancel()
+				ctx.Ctx = parent
+			}()
+		}
 		ctx.Logger.Info("=============开始执行=============")
 		err = RunNode(&node, ctx)
 		// fmt.Println(err)
 		if err != nil {
-			ctx.Logger.Info("=============执行失败=============")
+			if errors.Is(err, errTimeout) {
+				ctx.Logger.Info("=============执行超时=============")
+			} else if ctx.Ctx.Err() != nil {
+				ctx.Logger.Info("=============执行已停止=============")
+			} else {
+				ctx.Logger.Info("=============执行失败=============")
+			}
 			return err
 		}
 		ctx.Logger.Info("=============执行完成=============")
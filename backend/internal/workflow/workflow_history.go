@@ -66,11 +66,12 @@ func AddWorkflowHistory(workflowID, execType string) (string, error) {
 		"status":      "running",
 		"exec_type":   execType,
 		"create_time": now,
+		"pid":         os.Getpid(),
 	})
 	if err != nil {
 		return "", err
 	}
-	_ = UpdDb(workflowID, map[string]interface{}{"last_run_status": "running", "last_run_time": now})
+	_ = updRunStatus(workflowID, map[string]interface{}{"last_run_status": "running", "last_run_time": now})
 	return ID, nil
 }
 
@@ -92,7 +93,24 @@ func UpdateWorkflowHistory(id, status string) error {
 	return nil
 }
 
+// UpdateWorkflowHistoryResume 记录恢复执行的来源执行记录
+func UpdateWorkflowHistoryResume(id, resumeFrom string) error {
+	s, err := GetSqliteObjWH()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	_, err = s.Where("id=?", []interface{}{id}).Update(map[string]interface{}{
+		"resume_from": resumeFrom,
+	})
+	return err
+}
+
+// StopWorkflow 停止执行，id 为执行记录 ID。正在执行的工作流被取消后由执行协程记录为 cancelled
 func StopWorkflow(id string) error {
+	if CancelRun(id) {
+		return nil
+	}
 	s, err := GetSqliteObjWH()
 	if err != nil {
 		return err
@@ -105,7 +123,10 @@ func StopWorkflow(id string) error {
 	if len(data) == 0 {
 		return nil
 	}
-	SetWorkflowStatus(data[0]["workflow_id"].(string), id, "fail")
+	// 不在本进程中执行的运行中记录（如服务重启前遗留）直接标记为已取消
+	if status, _ := data[0]["status"].(string); status == "running" {
+		SetWorkflowStatus(data[0]["workflow_id"].(string), id, string(StatusCancelled))
+	}
 	return nil
 }
 
@@ -146,6 +167,11 @@ func CleanWorkflowHistory() error {
 	if err != nil {
 		return err
 	}
+	// 删除执行记录保存的节点输出
+	s.TableName = "workflow_node_output"
+	if _, err = s.Where("run_id NOT IN (select id from workflow_history)", nil).Delete(); err != nil {
+		return err
+	}
 	// 删除工作流执行日志
 	logPath := public.GetSettingIgnoreError("workflow_log_path")
 	if logPath == "" {
@@ -26,6 +26,11 @@ func SessionAuthMiddleware() gin.HandlerFunc {
 		if checkApiKey(c) {
 			return
 		}
+		// 私有 CA 的 CRL 需要对证书使用方公开
+		if c.Request.Method == "GET" && strings.HasPrefix(c.Request.URL.Path, "/crl/") {
+			c.Next()
+			return
+		}
 
 		routePath := c.Request.URL.Path
 		method := c.Request.Method
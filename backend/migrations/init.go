@@ -83,7 +83,57 @@ func init() {
 	    start_time  TEXT,
 	    end_time    TEXT,
 	    end_day     TEXT,
-	    workflow_id TEXT
+	    workflow_id TEXT,
+	    ari_start       TEXT,
+	    ari_end         TEXT,
+	    ari_retry_after TEXT,
+	    acme_email      TEXT,
+	    acme_ca         TEXT,
+	    revoked         integer default 0,
+	    revoke_reason   TEXT,
+	    revoke_time     TEXT,
+	    key_source      TEXT,
+	    key_sha256      TEXT,
+	    chain_issuers   TEXT,
+	    ocsp_response   TEXT,
+	    ocsp_this_update TEXT,
+	    ocsp_next_update TEXT,
+	    private_ca_id   TEXT,
+	    cert_group      TEXT
+	);
+	
+	create table IF NOT EXISTS order_log
+	(
+	    id           integer not null
+	        constraint order_log_pk
+	            primary key autoincrement,
+	    domain       TEXT    not null,
+	    domains      TEXT    not null,
+	    ca           TEXT,
+	    status       TEXT,
+	    error        TEXT,
+	    rate_limited integer default 0,
+	    create_time  TEXT
+	);
+	
+	create table IF NOT EXISTS private_ca
+	(
+	    id                integer not null
+	        constraint private_ca_pk
+	            primary key autoincrement,
+	    name              TEXT    not null
+	        constraint private_ca_name_uk
+	            unique,
+	    root_cert         TEXT    not null,
+	    root_key          TEXT    not null,
+	    cert              TEXT    not null,
+	    key               TEXT    not null,
+	    crl_base_url      TEXT,
+	    crl               TEXT,
+	    crl_number        integer default 0,
+	    crl_next_update   TEXT,
+	    create_time       TEXT,
+	    update_time       TEXT
 	);
 	
 	create table IF NOT EXISTS report
@@ -124,7 +174,20 @@ func init() {
 	    exec_type   TEXT,
 	    create_time TEXT,
 	    end_time    TEXT,
-	    workflow_id TEXT not null
+	    workflow_id TEXT not null,
+	    pid         integer,
+	    resume_from TEXT
+	);
+
+	create table IF NOT EXISTS workflow_node_output
+	(
+		run_id      TEXT not null,
+		node_id     TEXT not null,
+		status      TEXT,
+		output      TEXT,
+		update_time TEXT,
+		constraint workflow_node_output_pk
+			primary key (run_id, node_id)
 	);
 
 	create table IF NOT EXISTS workflow_deploy
@@ -138,6 +201,34 @@ func init() {
 	);
 
 	`)
+	// 证书 ARI 续期窗口
+	AddColumnIfNotExists(db, "cert", "ari_start", "TEXT")
+	AddColumnIfNotExists(db, "cert", "ari_end", "TEXT")
+	AddColumnIfNotExists(db, "cert", "ari_retry_after", "TEXT")
+	// 签发账号及吊销状态
+	AddColumnIfNotExists(db, "cert", "acme_email", "TEXT")
+	AddColumnIfNotExists(db, "cert", "acme_ca", "TEXT")
+	AddColumnIfNotExists(db, "cert", "revoked", "integer default 0")
+	AddColumnIfNotExists(db, "cert", "revoke_reason", "TEXT")
+	AddColumnIfNotExists(db, "cert", "revoke_time", "TEXT")
+	// 私钥来源及公钥指纹
+	AddColumnIfNotExists(db, "cert", "key_source", "TEXT")
+	AddColumnIfNotExists(db, "cert", "key_sha256", "TEXT")
+	// 证书链各级签发者
+	AddColumnIfNotExists(db, "cert", "chain_issuers", "TEXT")
+	// OCSP 响应缓存
+	AddColumnIfNotExists(db, "cert", "ocsp_response", "TEXT")
+	AddColumnIfNotExists(db, "cert", "ocsp_this_update", "TEXT")
+	AddColumnIfNotExists(db, "cert", "ocsp_next_update", "TEXT")
+	// 内置私有 CA 签发的证书
+	AddColumnIfNotExists(db, "cert", "private_ca_id", "TEXT")
+	// 拆分签发的证书组
+	AddColumnIfNotExists(db, "cert", "cert_group", "TEXT")
+	// 执行工作流的进程，用于重启后识别中断的执行记录
+	AddColumnIfNotExists(db, "workflow_history", "pid", "integer")
+	// 恢复执行的来源执行记录
+	AddColumnIfNotExists(db, "workflow_history", "resume_from", "TEXT")
+
 	insertDefaultData(db, "access_type", `
 	INSERT INTO access_type (name, type) VALUES ('aliyun', 'dns');
 	INSERT INTO access_type (name, type) VALUES ('tencentcloud', 'dns');
@@ -182,6 +273,10 @@ func init() {
 	InsertIfNotExists(db, "access_type", map[string]any{"name": "bunny", "type": "dns"}, []string{"name", "type"}, []any{"bunny", "dns"})
 	InsertIfNotExists(db, "access_type", map[string]any{"name": "namedotcom", "type": "dns"}, []string{"name", "type"}, []any{"namedotcom", "dns"})
 	InsertIfNotExists(db, "access_type", map[string]any{"name": "namesilo", "type": "dns"}, []string{"name", "type"}, []any{"namesilo", "dns"})
+	// RFC 2136 动态更新（BIND、PowerDNS 等自建 DNS）
+	InsertIfNotExists(db, "access_type", map[string]any{"name": "rfc2136", "type": "dns"}, []string{"name", "type"}, []any{"rfc2136", "dns"})
+	// lego 通用 DNS provider，按环境变量配置
+	InsertIfNotExists(db, "access_type", map[string]any{"name": "lego-generic", "type": "dns"}, []string{"name", "type"}, []any{"lego-generic", "dns"})
 
 	err = sqlite_migrate.EnsureDatabaseWithTables(
 		"data/site_monitor.db",
@@ -322,7 +417,26 @@ INSERT INTO settings (key, value, create_time, update_time, active, type) VALUES
 		create_time TEXT,
 		update_time TEXT
 	);
+
+	create table if not exists ca
+	(
+		id           integer not null
+			constraint ca_pk
+				primary key autoincrement,
+		name         TEXT    not null
+			constraint ca_name_uk
+				unique,
+		dir_url      TEXT    not null,
+		root_pem     TEXT,
+		eab_required integer default 0,
+		key_types    TEXT,
+		create_time  TEXT,
+		update_time  TEXT
+	);
        `)
+	// 账号在 CA 的状态及更换密钥时暂存的新密钥
+	AddColumnIfNotExists(dbAcc, "accounts", "status", "TEXT")
+	AddColumnIfNotExists(dbAcc, "accounts", "pending_key", "TEXT")
 	insertSql := `
 	insert into accounts (id, private_key, reg, email, create_time, update_time, type, Kid, HmacEncoded)
 	select a.id, a.private_key, a.reg, a.email, a.create_time, a.update_time, case when a.type like 'sslcom%' then 'sslcom' else a.type end, b.Kid,b.HmacEncoded
@@ -407,3 +521,35 @@ func InsertIfNotExists(
 
 	return nil
 }
+
+// AddColumnIfNotExists 为旧版本数据库中已存在的表补充新增字段
+func AddColumnIfNotExists(db *sql.DB, table, column, columnType string) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("query table info failed: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			typ       string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &dfltValue, &pk); err != nil {
+			return fmt.Errorf("scan table info failed: %w", err)
+		}
+		if name == column {
+			return nil // 已存在
+		}
+	}
+	rows.Close()
+
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, columnType))
+	if err != nil {
+		return fmt.Errorf("add column failed: %w", err)
+	}
+	return nil
+}
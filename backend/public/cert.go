@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"github.com/pavlo-v-chernykh/keystore-go/v4"
 	"software.sslmate.com/src/go-pkcs12"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -127,6 +128,32 @@ func ValidateSSLCertificate(certStr, keyStr string) error {
 	return nil
 }
 
+// GetPublicKeySHA256 获取公钥（SubjectPublicKeyInfo）的 sha256，用于判断证书是否共用私钥
+func GetPublicKeySHA256(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// GetCertKeyAlgorithm 根据证书公钥返回算法名称，如 RSA2048、EC256，无法识别时返回空字符串
+func GetCertKeyAlgorithm(certStr string) string {
+	certObj, err := ParseCertificate([]byte(certStr))
+	if err != nil {
+		return ""
+	}
+	switch pub := certObj.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return "RSA" + strconv.Itoa(pub.N.BitLen())
+	case *ecdsa.PublicKey:
+		return "EC" + strconv.Itoa(pub.Curve.Params().BitSize)
+	default:
+		return ""
+	}
+}
+
 // 获取sha256
 func GetSHA256(certStr string) (string, error) {
 	certPEM := []byte(certStr)
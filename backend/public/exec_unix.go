@@ -0,0 +1,17 @@
+//go:build !windows
+
+package public
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setCommandProcessGroup 让命令在独立进程组中运行，取消时结束整个进程组，
+// 避免 bash -c 派生的子进程继续占用输出管道导致 Wait 无法返回
+func setCommandProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package public
+
+import "os/exec"
+
+// setCommandProcessGroup Windows 下沿用默认的结束方式
+func setCommandProcessGroup(cmd *exec.Cmd) {}
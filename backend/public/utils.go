@@ -2,6 +2,7 @@ package public
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"fmt"
 	"github.com/google/uuid"
@@ -12,6 +13,7 @@ import (
 	"os/exec"
 	"runtime"
 	"strings"
+	"time"
 )
 
 const defaultCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
@@ -203,14 +205,25 @@ func ContainsAllIgnoreBRepeats(a, b []string) bool {
 
 // ExecCommand 执行系统命令，并返回 stdout、stderr 和错误
 func ExecCommand(command string) (string, string, error) {
+	return ExecCommandContext(context.Background(), command)
+}
+
+// execWaitDelay 命令被取消后等待输出管道关闭的最长时间
+var execWaitDelay = 5 * time.Second
+
+// ExecCommandContext 执行系统命令，ctx 取消时结束命令进程
+func ExecCommandContext(ctx context.Context, command string) (string, string, error) {
 	var cmd *exec.Cmd
 
 	// 根据操作系统选择解释器
 	if runtime.GOOS == "windows" {
-		cmd = exec.Command("cmd", "/C", command)
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
 	} else {
-		cmd = exec.Command("bash", "-c", command)
+		cmd = exec.CommandContext(ctx, "bash", "-c", command)
 	}
+	setCommandProcessGroup(cmd)
+	// 结束进程后若仍有子进程占用输出管道，最多再等待这么久
+	cmd.WaitDelay = execWaitDelay
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -35,6 +35,7 @@ func Register(r *gin.Engine) {
 		workflow.POST("/get_workflow_history", api.GetWorkflowHistory)
 		workflow.POST("/get_exec_log", api.GetExecLog)
 		workflow.POST("/stop", api.StopWorkflow)
+		workflow.POST("/resume", api.ResumeWorkflow)
 	}
 	access := v1.Group("/access")
 	{
@@ -44,6 +45,7 @@ func Register(r *gin.Engine) {
 		access.POST("/upd_access", api.UpdateAccess)
 		access.POST("/get_all", api.GetAllAccess)
 		access.POST("/test_access", api.TestAccess)
+		access.POST("/dns_diagnose", api.DiagnoseDNS)
 		access.POST("/get_sites", api.GetSiteList)
 
 		access.POST("/get_eab_list", api.GetEABList)
@@ -64,14 +66,36 @@ func Register(r *gin.Engine) {
 		acmeAccount.POST("/add_account", api.AddAccount)
 		acmeAccount.POST("/del_account", api.DelAccount)
 		acmeAccount.POST("/upd_account", api.UpdateAccount)
+		// 账号在 CA 的管理
+		acmeAccount.POST("/key_rollover", api.RolloverAccountKey)
+		acmeAccount.POST("/update_contact", api.UpdateAccountContact)
+		acmeAccount.POST("/deactivate", api.DeactivateAccount)
+		// CA 注册表
+		acmeAccount.POST("/get_ca_registry", api.GetCARegistryList)
+		acmeAccount.POST("/add_ca", api.AddCA)
+		acmeAccount.POST("/upd_ca", api.UpdateCA)
+		acmeAccount.POST("/del_ca", api.DelCA)
 	}
 	cert := v1.Group("/cert")
 	{
 		cert.POST("/get_list", api.GetCertList)
 		cert.POST("/upload_cert", api.UploadCert)
 		cert.POST("/del_cert", api.DelCert)
+		cert.POST("/revoke", api.RevokeCert)
+		cert.POST("/refresh_ocsp", api.RefreshOCSP)
 		cert.GET("/download", api.DownloadCert)
 	}
+	privateCA := v1.Group("/private_ca")
+	{
+		privateCA.POST("/get_list", api.GetPrivateCAList)
+		privateCA.POST("/add_ca", api.AddPrivateCA)
+		privateCA.POST("/upd_ca", api.UpdatePrivateCA)
+		privateCA.POST("/del_ca", api.DelPrivateCA)
+		privateCA.POST("/publish_crl", api.PublishPrivateCACRL)
+		privateCA.GET("/download_root", api.DownloadPrivateCARoot)
+	}
+	// 私有 CA 的 CRL 分发点，客户端无需登录即可获取
+	r.GET("/crl/:file", api.GetPrivateCACRL)
 	report := v1.Group("/report")
 	{
 		report.POST("/get_list", api.GetReportList)
@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	wf "ALLinSSL/backend/internal/workflow"
 	"context"
 	"sync"
 	"time"
@@ -62,6 +63,9 @@ func (s *Scheduler) Restart() {
 func (s *Scheduler) loop() {
 	defer s.wg.Done()
 
+	// 启动时处理上次进程退出时未结束的工作流执行记录
+	wf.RecoverInterruptedRuns()
+
 	for {
 		// fmt.Println("Scheduler loop")
 		select {
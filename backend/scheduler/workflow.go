@@ -83,20 +83,7 @@ func RunWorkflows() {
 			wg.Add(1)
 			go func(id int64, c string) {
 				defer wg.Done()
-				WorkflowID := strconv.FormatInt(id, 10)
-				RunID, err := wf.AddWorkflowHistory(WorkflowID, "auto")
-				if err != nil {
-					return
-				}
-				ctx := wf.NewExecutionContext(RunID)
-				defer ctx.Logger.Close()
-				err = wf.RunWorkflow(c, ctx)
-				if err != nil {
-					fmt.Println("执行工作流失败:", err)
-					wf.SetWorkflowStatus(WorkflowID, RunID, "fail")
-				} else {
-					wf.SetWorkflowStatus(WorkflowID, RunID, "success")
-				}
+				wf.RunWithHistory(strconv.FormatInt(id, 10), "auto", c)
 			}(workflow["id"].(int64), content)
 		}
 	}